@@ -7,10 +7,20 @@
 package ckd_test
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"math/big"
+	"strings"
 	"testing"
 
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
 	. "github.com/bnb-chain/tss-lib/v2/crypto/ckd"
+	"github.com/bnb-chain/tss-lib/v2/tss"
 	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+	"golang.org/x/crypto/blake2b"
 )
 
 func TestPublicDerivation(t *testing.T) {
@@ -130,3 +140,196 @@ tests:
 		}
 	}
 }
+
+func TestEd25519PublicDerivation(t *testing.T) {
+	ec := tss.Edwards()
+	chainCode := make([]byte, 32)
+	if _, err := rand.Read(chainCode); err != nil {
+		t.Fatal(err)
+	}
+	x := common.GetRandomPositiveInt(ec.Params().N)
+	pub := crypto.ScalarBaseMult(ec, x)
+	master := &ExtendedKey{
+		PublicKey: *pub.ToECDSAPubKey(),
+		ChainCode: chainCode,
+		ParentFP:  []byte{0x00, 0x00, 0x00, 0x00},
+	}
+
+	delta, child, err := DeriveEd25519ChildKeyFromHierarchy([]uint32{0, 1, 2147483647}, master, ec.Params().N, ec)
+	if err != nil {
+		t.Fatalf("derivation failed: %v", err)
+	}
+	// the secret of the child key is the secret of the master key plus the delta
+	childPub := crypto.ScalarBaseMult(ec, new(big.Int).Mod(new(big.Int).Add(x, delta), ec.Params().N))
+	if childPub.X().Cmp(child.X) != 0 || childPub.Y().Cmp(child.Y) != 0 {
+		t.Fatal("the child key does not match the secret plus the delta")
+	}
+	if child.Depth != 3 || child.ChildIndex != 2147483647 || len(child.ChainCode) != 32 {
+		t.Fatalf("unexpected child key metadata: %+v", child)
+	}
+
+	// the same path gives the same key, another index gives another key
+	_, again, err := DeriveEd25519ChildKeyFromHierarchy([]uint32{0, 1, 2147483647}, master, ec.Params().N, ec)
+	if err != nil || again.X.Cmp(child.X) != 0 {
+		t.Fatal("the derivation is not deterministic")
+	}
+	_, other, err := DeriveEd25519ChildKey(1, master, ec)
+	if err != nil || other.X.Cmp(child.X) == 0 {
+		t.Fatal("different indexes should derive different keys")
+	}
+
+	if _, _, err = DeriveEd25519ChildKey(HardenedKeyStart, master, ec); err == nil {
+		t.Fatal("hardened derivation should not be possible from a public key")
+	}
+}
+
+func TestEd25519PublicDerivationKnownAnswer(t *testing.T) {
+	// the account key m/1852'/1815'/0' of the Cardano wallet with the entropy df9ed25ed146bf43336a5d7cf7395994 ("test
+	// walk nut penalty hip pave soap entry language right filter choice", root_key_12 in the tests of
+	// cardano-serialization-lib). The key hashes are the payment and stake parts of its base address
+	// addr1qx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzer3jcu5d8ps7zex2k2xt3uqxgjqnnj83ws8lhrn648jjxtwqfjkjv7
+	accountPub := "cf779aa32f35083707808532471cb64ee41426c9bbd46134dac2ac5b2a0ec0e9"
+	accountChainCode := "8fa5fcd46abd9d46d4d8a97a8f3465e2c4e8f3c9dad9ff66823a161ecadca604"
+
+	tests := []struct {
+		name          string
+		path          []uint32
+		wantPub       string
+		wantChainCode string
+		wantKeyHash   string
+	}{
+		{
+			name:          "external chain",
+			path:          []uint32{0},
+			wantPub:       "51b1648f4ab0e87354ec563e10ea04d753120f6526095b509823df897d27f9c5",
+			wantChainCode: "5e4d2767f0ac40f22a79502b0c174b8be73330b278ec52b056a08d4631ae4be3",
+		},
+		{
+			name:          "payment key 0/0",
+			path:          []uint32{0, 0},
+			wantPub:       "73fea80d424276ad0978d4fe5310e8bc2d485f5f6bb3bf87612989f112ad5a7d",
+			wantChainCode: "dd75e154da417becec55cdd249327454138f082110297d5e87ab25e15fad150f",
+			wantKeyHash:   "9493315cd92eb5d8c4304e67b7e16ae36d61d34502694657811a2c8e",
+		},
+		{
+			name:          "staking chain",
+			path:          []uint32{2},
+			wantPub:       "0bb43f3cb9b5715d19a9b3ee7e8db2577669b516fc6551de326c44987b5cd22b",
+			wantChainCode: "6634a538bb9ce020ea06e311f022f83bd1e25f6f2d9fb5b27f13e978b2ea5611",
+		},
+		{
+			name:          "stake key 2/0",
+			path:          []uint32{2, 0},
+			wantPub:       "2c041c9c6a676ac54d25e2fdce44c56581e316ae43adc4c7bf17f23214d8d892",
+			wantChainCode: "abbec7b28b61543d4b1401a1bb5b799770b79f5cb49ee7c539e5e6e4eb047367",
+			wantKeyHash:   "32c728d3861e164cab28cb8f006448139c8f1740ffb8e7aa9e5232dc",
+		},
+	}
+
+	ec := tss.Edwards()
+	pubBz, _ := hex.DecodeString(accountPub)
+	pub, err := edwards.ParsePubKey(pubBz)
+	if err != nil {
+		t.Fatalf("failed to parse the account key: %v", err)
+	}
+	chainCode, _ := hex.DecodeString(accountChainCode)
+	account := &ExtendedKey{
+		PublicKey: *pub.ToECDSA(),
+		Depth:     3,
+		ChainCode: chainCode,
+		ParentFP:  []byte{0x00, 0x00, 0x00, 0x00},
+	}
+
+	for _, test := range tests {
+		_, child, err := DeriveEd25519ChildKeyFromHierarchy(test.path, account, ec.Params().N, ec)
+		if err != nil {
+			t.Errorf("%s: derivation failed: %v", test.name, err)
+			continue
+		}
+		childPub := edwards.NewPublicKey(child.X, child.Y).Serialize()
+		if got := hex.EncodeToString(childPub); got != test.wantPub {
+			t.Errorf("%s: mismatched public key: got %s, want %s", test.name, got, test.wantPub)
+		}
+		if got := hex.EncodeToString(child.ChainCode); got != test.wantChainCode {
+			t.Errorf("%s: mismatched chain code: got %s, want %s", test.name, got, test.wantChainCode)
+		}
+		if test.wantKeyHash == "" {
+			continue
+		}
+		keyHash, _ := blake2b.New(28, nil)
+		keyHash.Write(childPub)
+		if got := hex.EncodeToString(keyHash.Sum(nil)); got != test.wantKeyHash {
+			t.Errorf("%s: mismatched key hash: got %s, want %s", test.name, got, test.wantKeyHash)
+		}
+	}
+}
+
+func TestExtendedPublicKeyExport(t *testing.T) {
+	// the master key and a child of test vector 1 in [BIP32]
+	master, err := NewExtendedKeyFromString("xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8NqtwybGhePY2gZ29ESFjqJoCu1Rupje8YtGqsefD265TMg7usUDFdp6W1EGMcet8", btcec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, err := crypto.NewECPoint(btcec.S256(), master.X, master.Y)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		net    *chaincfg.Params
+		format KeyFormat
+		prefix string
+	}{
+		{&chaincfg.MainNetParams, FormatLegacy, "xpub"},
+		{&chaincfg.TestNet3Params, FormatLegacy, "tpub"},
+		{&chaincfg.MainNetParams, FormatNestedSegWit, "ypub"},
+		{&chaincfg.TestNet3Params, FormatNestedSegWit, "upub"},
+		{&chaincfg.MainNetParams, FormatNativeSegWit, "zpub"},
+		{&chaincfg.TestNet3Params, FormatNativeSegWit, "vpub"},
+		{&chaincfg.MainNetParams, FormatNestedSegWitMultisig, "Ypub"},
+		{&chaincfg.TestNet3Params, FormatNestedSegWitMultisig, "Upub"},
+		{&chaincfg.MainNetParams, FormatNativeSegWitMultisig, "Zpub"},
+		{&chaincfg.TestNet3Params, FormatNativeSegWitMultisig, "Vpub"},
+	}
+	for _, test := range tests {
+		version, err := PublicKeyVersion(test.net, test.format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pk, err := NewExtendedPublicKey(pub, master.ChainCode, version)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := pk.String(); !strings.HasPrefix(got, test.prefix) {
+			t.Errorf("format %d on %s: got %s, want prefix %s", test.format, test.net.Name, got, test.prefix)
+		}
+	}
+	if _, err = PublicKeyVersion(&chaincfg.SimNetParams, FormatNativeSegWit); err == nil {
+		t.Error("SLIP-132 versions should not be defined for simnet")
+	}
+
+	version, _ := PublicKeyVersion(&chaincfg.MainNetParams, FormatLegacy)
+	pk, err := NewExtendedPublicKey(pub, master.ChainCode, version)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pk.String() != master.String() {
+		t.Fatalf("got %s, want %s", pk.String(), master.String())
+	}
+	_, child, err := DerivePublicKeyFromPath(pk, "m/0/1/2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "xpub6BqyndF6rhZqmgktFCBcapkwubGxPqoAZtQaYewJHXVKZcLdnqBVC8N6f6FSHWUghjuTLeubWyQWfJdk2G3tGgvgj3qngo4vLTnnSjAZckv"; child.String() != want {
+		t.Fatalf("got %s, want %s", child.String(), want)
+	}
+
+	for _, path := range []string{"m/0'/1", "m/0h", "m/2147483648", "m/a", "m//1"} {
+		if _, err = ParseDerivationPath(path); err == nil {
+			t.Errorf("the path %s should be rejected", path)
+		}
+	}
+	if _, err = NewExtendedPublicKey(pub, master.ChainCode[:31], version); err == nil {
+		t.Error("a short chain code should be rejected")
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package ckd
+
+import (
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"math/big"
+
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+)
+
+// Ed25519 keys are derived with the non-hardened (public) derivation of BIP32-Ed25519 (Khovratovich and Law, 2017),
+// the scheme that Cardano uses for its wallets:
+//
+//	Z    = HMAC-SHA512(Key = chainCode, Data = 0x02 || A || ser32LE(index))
+//	A'   = A + 8*ZL*G, where ZL is the integer of the first 28 bytes of Z in little-endian order
+//	c'   = the last 32 bytes of HMAC-SHA512(Key = chainCode, Data = 0x03 || A || ser32LE(index))
+//
+// where A is the public key in the encoding of RFC 8032. The delta 8*ZL is added to the secret scalar, so it can be
+// added to the shares of a threshold key just like the BIP-32 delta of secp256k1 keys.
+
+const (
+	ed25519ZLLength = 28
+
+	ed25519KeyTag   byte = 0x02
+	ed25519ChainTag byte = 0x03
+)
+
+// DeriveEd25519ChildKeyFromHierarchy derives the Ed25519 child key at the path `indicesHierarchy` from `pk`. It
+// returns the sum of the key derivation deltas modulo `mod`, and the child key.
+func DeriveEd25519ChildKeyFromHierarchy(indicesHierarchy []uint32, pk *ExtendedKey, mod *big.Int, curve elliptic.Curve) (*big.Int, *ExtendedKey, error) {
+	var k = pk
+	var err error
+	var childKey *ExtendedKey
+	mod_ := common.ModInt(mod)
+	deltaSum := big.NewInt(0)
+	for index := range indicesHierarchy {
+		var delta *big.Int
+		delta, childKey, err = DeriveEd25519ChildKey(indicesHierarchy[index], k, curve)
+		if err != nil {
+			return nil, nil, err
+		}
+		k = childKey
+		deltaSum = mod_.Add(deltaSum, delta)
+	}
+	return deltaSum, k, nil
+}
+
+// DeriveEd25519ChildKey derives the non-hardened Ed25519 child key with the given index from the given parent key.
+// The function returns the key derivation delta 8*ZL and the derived child key.
+func DeriveEd25519ChildKey(index uint32, pk *ExtendedKey, curve elliptic.Curve) (*big.Int, *ExtendedKey, error) {
+	if index >= HardenedKeyStart {
+		return nil, nil, errors.New("the index must be non-hardened")
+	}
+	if pk.Depth == maxDepth {
+		return nil, nil, errors.New("cannot derive key beyond max depth")
+	}
+	if len(pk.ChainCode) != 32 {
+		return nil, nil, errors.New("the chain code must be 32 bytes long")
+	}
+
+	cryptoPk, err := crypto.NewECPoint(curve, pk.X, pk.Y)
+	if err != nil {
+		common.Logger.Error("error getting pubkey from extendedkey")
+		return nil, nil, err
+	}
+	pkPublicKeyBytes := edwards.NewPublicKey(pk.X, pk.Y).Serialize()
+
+	data := make([]byte, 1+len(pkPublicKeyBytes)+4)
+	copy(data[1:], pkPublicKeyBytes)
+	binary.LittleEndian.PutUint32(data[1+len(pkPublicKeyBytes):], index)
+
+	// Z = HMAC-SHA512(Key = chainCode, Data = 0x02 || A || index)
+	data[0] = ed25519KeyTag
+	hmac512 := hmac.New(sha512.New, pk.ChainCode)
+	hmac512.Write(data)
+	z := hmac512.Sum(nil)
+
+	// the chain code comes from the right half of HMAC-SHA512(Key = chainCode, Data = 0x03 || A || index)
+	data[0] = ed25519ChainTag
+	hmac512 = hmac.New(sha512.New, pk.ChainCode)
+	hmac512.Write(data)
+	childChainCode := hmac512.Sum(nil)[32:]
+
+	zl := make([]byte, ed25519ZLLength)
+	for i := range zl {
+		zl[i] = z[ed25519ZLLength-1-i]
+	}
+	delta := new(big.Int).Lsh(new(big.Int).SetBytes(zl), 3)
+
+	deltaG := crypto.ScalarBaseMult(curve, delta)
+	childCryptoPk, err := cryptoPk.Add(deltaG)
+	if err != nil {
+		common.Logger.Error("error adding delta G to parent key")
+		return nil, nil, err
+	}
+	if childCryptoPk.X().Sign() == 0 {
+		return nil, nil, errors.New("invalid child")
+	}
+
+	childPk := &ExtendedKey{
+		PublicKey:  *childCryptoPk.ToECDSAPubKey(),
+		Depth:      pk.Depth + 1,
+		ChildIndex: index,
+		ChainCode:  childChainCode,
+		ParentFP:   hash160(pkPublicKeyBytes)[:4],
+		Version:    pk.Version,
+	}
+	return delta, childPk, nil
+}
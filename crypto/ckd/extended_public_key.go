@@ -0,0 +1,124 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package ckd
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/btcsuite/btcd/chaincfg"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+)
+
+// KeyFormat selects the version bytes of a serialized extended public key, see
+// https://github.com/satoshilabs/slips/blob/master/slip-0132.md
+type KeyFormat int
+
+const (
+	// FormatLegacy is xpub on mainnet and tpub on testnet, used for P2PKH (BIP-44)
+	FormatLegacy KeyFormat = iota
+	// FormatNestedSegWit is ypub/upub, used for P2WPKH nested in P2SH (BIP-49)
+	FormatNestedSegWit
+	// FormatNativeSegWit is zpub/vpub, used for P2WPKH (BIP-84)
+	FormatNativeSegWit
+	// FormatNestedSegWitMultisig is Ypub/Upub, used for P2WSH nested in P2SH
+	FormatNestedSegWitMultisig
+	// FormatNativeSegWitMultisig is Zpub/Vpub, used for P2WSH
+	FormatNativeSegWitMultisig
+)
+
+// SLIP-132 version bytes of extended public keys by format, for mainnet and testnet
+var slip132Versions = map[KeyFormat][2][4]byte{
+	FormatNestedSegWit:         {{0x04, 0x9d, 0x7c, 0xb2}, {0x04, 0x4a, 0x52, 0x62}},
+	FormatNativeSegWit:         {{0x04, 0xb2, 0x47, 0x46}, {0x04, 0x5f, 0x1c, 0xf6}},
+	FormatNestedSegWitMultisig: {{0x02, 0x95, 0xb4, 0x3f}, {0x02, 0x42, 0x89, 0xef}},
+	FormatNativeSegWitMultisig: {{0x02, 0xaa, 0x7e, 0xd3}, {0x02, 0x57, 0x54, 0x83}},
+}
+
+// PublicKeyVersion returns the version bytes of an extended public key in the given format for the network `net`.
+// FormatLegacy uses the HDPublicKeyID of `net`; the SLIP-132 formats are defined for networks that use xpub or tpub.
+func PublicKeyVersion(net *chaincfg.Params, format KeyFormat) ([]byte, error) {
+	if net == nil {
+		return nil, errors.New("the network is missing")
+	}
+	if format == FormatLegacy {
+		return append([]byte{}, net.HDPublicKeyID[:]...), nil
+	}
+	versions, ok := slip132Versions[format]
+	if !ok {
+		return nil, fmt.Errorf("unknown key format %d", format)
+	}
+	switch net.HDPublicKeyID {
+	case chaincfg.MainNetParams.HDPublicKeyID:
+		return append([]byte{}, versions[0][:]...), nil
+	case chaincfg.TestNet3Params.HDPublicKeyID:
+		return append([]byte{}, versions[1][:]...), nil
+	}
+	return nil, fmt.Errorf("key format %d is not defined for network %s", format, net.Name)
+}
+
+// NewExtendedPublicKey returns the master extended public key for the public key `pub` of a threshold key, e.g.
+// LocalPartySaveData.ECDSAPub, and its chain code. The version bytes can be obtained from PublicKeyVersion.
+// The key can be serialized with String() and used for watch-only wallets; child keys are derived with
+// DeriveChildKeyFromHierarchy.
+func NewExtendedPublicKey(pub *crypto.ECPoint, chainCode []byte, version []byte) (*ExtendedKey, error) {
+	if pub == nil || !pub.ValidateBasic() {
+		return nil, errors.New("the public key is invalid")
+	}
+	if len(chainCode) != 32 {
+		return nil, errors.New("the chain code must be 32 bytes long")
+	}
+	if len(version) != 4 {
+		return nil, errors.New("the version must be 4 bytes long")
+	}
+	return &ExtendedKey{
+		PublicKey:  *pub.ToECDSAPubKey(),
+		Depth:      0,
+		ChildIndex: 0,
+		ChainCode:  append([]byte{}, chainCode...),
+		ParentFP:   []byte{0x00, 0x00, 0x00, 0x00},
+		Version:    append([]byte{}, version...),
+	}, nil
+}
+
+// DerivePublicKeyFromPath derives the child key at `path`, e.g. "m/0/1", from the extended public key `pk`.
+// It returns the key derivation delta, which signing parties need to sign for the child key, and the child key.
+func DerivePublicKeyFromPath(pk *ExtendedKey, path string) (*big.Int, *ExtendedKey, error) {
+	indices, err := ParseDerivationPath(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return DeriveChildKeyFromHierarchy(indices, pk, pk.Curve.Params().N, pk.Curve)
+}
+
+// ParseDerivationPath parses a BIP-32 path of non-hardened indexes such as "m/44/0" into its indexes.
+// Hardened indexes such as "0'" or "0h" are rejected because they cannot be derived from public keys.
+func ParseDerivationPath(path string) ([]uint32, error) {
+	parts := strings.Split(strings.TrimSpace(path), "/")
+	if parts[0] == "m" || parts[0] == "M" {
+		parts = parts[1:]
+	}
+	indices := make([]uint32, 0, len(parts))
+	for _, part := range parts {
+		if strings.HasSuffix(part, "'") || strings.HasSuffix(part, "h") || strings.HasSuffix(part, "H") {
+			return nil, fmt.Errorf("the path %q has a hardened index, which cannot be derived from a public key", path)
+		}
+		index, err := strconv.ParseUint(part, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("the path %q has an invalid index %q", path, part)
+		}
+		if index >= HardenedKeyStart {
+			return nil, fmt.Errorf("the path %q has a hardened index, which cannot be derived from a public key", path)
+		}
+		indices = append(indices, uint32(index))
+	}
+	return indices, nil
+}
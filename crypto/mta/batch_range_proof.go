@@ -0,0 +1,117 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package mta
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
+)
+
+// batchWeightBits is the size of the random weights that combine the proofs of a batch; a batch with an invalid proof
+// passes the combined check with a probability of at most 2^-batchWeightBits
+const batchWeightBits = 128
+
+type (
+	// BatchRangeProofAlice is Alice's range proof of GG18Spec (9) Fig. 9 for several ciphertexts under the same keys, e.g.
+	// the ciphertexts of the sessions of a batch signing. The proofs share one challenge, which is derived from all of
+	// them, and they are verified together: their equations are combined with random weights, so that the costly
+	// exponentiations of the verification, e.g. s^N mod N^2, are computed once for the batch instead of once per proof.
+	// A batch of one proof is a RangeProofAlice.
+	BatchRangeProofAlice []*RangeProofAlice
+)
+
+// ProveBatchRangeAlice proves that each of the ciphertexts `cs` encrypts the value of `ms` at the same index with the
+// randomness of `rs` at that index.
+func ProveBatchRangeAlice(ec elliptic.Curve, pk *paillier.PublicKey, cs []*big.Int, NTilde, h1, h2 *big.Int, ms, rs []*big.Int) (BatchRangeProofAlice, error) {
+	if pk == nil || NTilde == nil || h1 == nil || h2 == nil || len(cs) == 0 || len(ms) != len(cs) || len(rs) != len(cs) {
+		return nil, errors.New("ProveBatchRangeAlice constructor received invalid value(s)")
+	}
+	for k := range cs {
+		if cs[k] == nil || ms[k] == nil || rs[k] == nil {
+			return nil, errors.New("ProveBatchRangeAlice constructor received nil value(s)")
+		}
+	}
+
+	pf := make(BatchRangeProofAlice, len(cs))
+	wits := make([]*rangeProofAliceWitness, len(cs))
+	for k := range cs {
+		pf[k], wits[k] = commitRangeAlice(ec, pk, NTilde, h1, h2, ms[k])
+	}
+	e := pf.challenge(ec, pk, cs)
+	for k := range cs {
+		pf[k].respond(pk, e, ms[k], rs[k], wits[k])
+	}
+	return pf, nil
+}
+
+// Verify checks the proofs of the batch against the ciphertexts `cs`, in the same order.
+func (pf BatchRangeProofAlice) Verify(ec elliptic.Curve, pk *paillier.PublicKey, NTilde, h1, h2 *big.Int, cs []*big.Int) bool {
+	if len(pf) == 0 || len(cs) != len(pf) || pk == nil || NTilde == nil || h1 == nil || h2 == nil {
+		return false
+	}
+	NSquare := pk.NSquare()
+	for k, proof := range pf {
+		if proof == nil || !proof.ValidateBasic() || cs[k] == nil || !proof.inBounds(ec, pk, NTilde) {
+			return false
+		}
+		// c must be invertible, as it is moved to the other side of the equations
+		if !common.IsInInterval(cs[k], NSquare) || new(big.Int).GCD(nil, nil, cs[k], NSquare).Cmp(one) != 0 {
+			return false
+		}
+	}
+	e := pf.challenge(ec, pk, cs)
+
+	// With a random weight dk for each proof, the equations of steps 4 and 5 combine into
+	//   gamma^(sum dk*s1k) * (prod sk^dk)^N == (prod uk^dk) * (prod ck^dk)^e mod N^2
+	//   h1^(sum dk*s1k) * h2^(sum dk*s2k) == (prod wk^dk) * (prod zk^dk)^e mod NTilde
+	// The weights are even, so that the elements of order 2 of Z*_NTilde cancel out.
+	modNSquared, modNTilde := common.ModInt(NSquare), common.ModInt(NTilde)
+	sumS1, sumS2 := big.NewInt(0), big.NewInt(0)
+	prodS, prodU, prodC := big.NewInt(1), big.NewInt(1), big.NewInt(1)
+	prodW, prodZ := big.NewInt(1), big.NewInt(1)
+	for k, proof := range pf {
+		d := new(big.Int).Lsh(common.MustGetRandomInt(batchWeightBits), 1)
+		sumS1.Add(sumS1, new(big.Int).Mul(d, proof.S1))
+		sumS2.Add(sumS2, new(big.Int).Mul(d, proof.S2))
+		prodS = modNSquared.Mul(prodS, modNSquared.Exp(proof.S, d))
+		prodU = modNSquared.Mul(prodU, modNSquared.Exp(proof.U, d))
+		prodC = modNSquared.Mul(prodC, modNSquared.Exp(cs[k], d))
+		prodW = modNTilde.Mul(prodW, modNTilde.Exp(proof.W, d))
+		prodZ = modNTilde.Mul(prodZ, modNTilde.Exp(proof.Z, d))
+	}
+
+	{ // 4.
+		left := modNSquared.Mul(modNSquared.Exp(pk.Gamma(), sumS1), modNSquared.Exp(prodS, pk.N))
+		right := modNSquared.Mul(prodU, modNSquared.Exp(prodC, e))
+		if left.Cmp(right) != 0 {
+			return false
+		}
+	}
+
+	{ // 5.
+		left := modNTilde.Mul(modNTilde.Exp(h1, sumS1), modNTilde.Exp(h2, sumS2))
+		right := modNTilde.Mul(prodW, modNTilde.Exp(prodZ, e))
+		if left.Cmp(right) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// challenge derives the challenge e that the proofs of the batch share from all of their ciphertexts and commitments
+func (pf BatchRangeProofAlice) challenge(ec elliptic.Curve, pk *paillier.PublicKey, cs []*big.Int) *big.Int {
+	ins := pk.AsInts()
+	for k, proof := range pf {
+		ins = append(ins, cs[k], proof.Z, proof.U, proof.W)
+	}
+	// must use RejectionSample
+	return common.RejectionSample(ec.Params().N, common.SHA512_256i(ins...))
+}
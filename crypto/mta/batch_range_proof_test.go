@@ -0,0 +1,66 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package mta
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func TestProveBatchRangeAlice(t *testing.T) {
+	q := tss.EC().Params().N
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	sk, pk, err := paillier.GenerateKeyPair(ctx, testPaillierKeyLength)
+	assert.NoError(t, err)
+
+	primes := [2]*big.Int{common.GetRandomPrimeInt(testSafePrimeBits), common.GetRandomPrimeInt(testSafePrimeBits)}
+	NTildei, h1i, h2i, err := crypto.GenerateNTildei(primes)
+	assert.NoError(t, err)
+
+	const batchSize = 3
+	cs, ms, rs := make([]*big.Int, batchSize), make([]*big.Int, batchSize), make([]*big.Int, batchSize)
+	for k := range cs {
+		ms[k] = common.GetRandomPositiveInt(q)
+		cs[k], rs[k], err = sk.EncryptAndReturnRandomness(ms[k])
+		assert.NoError(t, err)
+	}
+	proof, err := ProveBatchRangeAlice(tss.EC(), pk, cs, NTildei, h1i, h2i, ms, rs)
+	assert.NoError(t, err)
+	assert.True(t, proof.Verify(tss.EC(), pk, NTildei, h1i, h2i, cs), "proof must verify")
+
+	// the proofs are bound to the order of the ciphertexts and to each other by their challenge
+	swapped := []*big.Int{cs[1], cs[0], cs[2]}
+	assert.False(t, proof.Verify(tss.EC(), pk, NTildei, h1i, h2i, swapped), "proof must not verify")
+	assert.False(t, proof[:2].Verify(tss.EC(), pk, NTildei, h1i, h2i, cs[:2]), "proof must not verify")
+	single, err := ProveRangeAlice(tss.EC(), pk, cs[1], NTildei, h1i, h2i, ms[1], rs[1])
+	assert.NoError(t, err)
+	mixed := BatchRangeProofAlice{proof[0], single, proof[2]}
+	assert.False(t, mixed.Verify(tss.EC(), pk, NTildei, h1i, h2i, cs), "proof must not verify")
+
+	// a single invalid proof fails the batch
+	forged := BatchRangeProofAlice{proof[0], &RangeProofAlice{}, proof[2]}
+	*forged[1] = *proof[1]
+	forged[1].S2 = new(big.Int).Add(proof[1].S2, big.NewInt(1))
+	assert.False(t, forged.Verify(tss.EC(), pk, NTildei, h1i, h2i, cs), "proof must not verify")
+
+	// a batch of one proof is a RangeProofAlice
+	assert.True(t, BatchRangeProofAlice{single}.Verify(tss.EC(), pk, NTildei, h1i, h2i, cs[1:2]), "proof must verify")
+	assert.False(t, proof[0].Verify(tss.EC(), pk, NTildei, h1i, h2i, cs[0]), "proof must not verify alone")
+	assert.False(t, proof.Verify(tss.EC(), pk, NTildei, h1i, h2i, cs[:2]), "proof must not verify")
+}
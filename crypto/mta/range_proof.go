@@ -37,6 +37,26 @@ func ProveRangeAlice(ec elliptic.Curve, pk *paillier.PublicKey, c, NTilde, h1, h
 		return nil, errors.New("ProveRangeAlice constructor received nil value(s)")
 	}
 
+	pf, wit := commitRangeAlice(ec, pk, NTilde, h1, h2, m)
+
+	// 8-9. e'
+	var e *big.Int
+	{ // must use RejectionSample
+		eHash := common.SHA512_256i(append(pk.AsInts(), c, pf.Z, pf.U, pf.W)...)
+		e = common.RejectionSample(ec.Params().N, eHash)
+	}
+
+	pf.respond(pk, e, m, r, wit)
+	return pf, nil
+}
+
+// rangeProofAliceWitness holds the randomness that Alice draws to commit to her proof
+type rangeProofAliceWitness struct {
+	alpha, beta, gamma, rho *big.Int
+}
+
+// commitRangeAlice implements steps 1-7 of Alice's range proof and returns a proof that only holds z, u and w
+func commitRangeAlice(ec elliptic.Curve, pk *paillier.PublicKey, NTilde, h1, h2, m *big.Int) (*RangeProofAlice, *rangeProofAliceWitness) {
 	q := ec.Params().N
 	q3 := new(big.Int).Mul(q, q)
 	q3 = new(big.Int).Mul(q, q3)
@@ -68,26 +88,24 @@ func ProveRangeAlice(ec elliptic.Curve, pk *paillier.PublicKey, c, NTilde, h1, h
 	w := modNTilde.Exp(h1, alpha)
 	w = modNTilde.Mul(w, modNTilde.Exp(h2, gamma))
 
-	// 8-9. e'
-	var e *big.Int
-	{ // must use RejectionSample
-		eHash := common.SHA512_256i(append(pk.AsInts(), c, z, u, w)...)
-		e = common.RejectionSample(q, eHash)
-	}
+	return &RangeProofAlice{Z: z, U: u, W: w}, &rangeProofAliceWitness{alpha, beta, gamma, rho}
+}
 
+// respond implements steps 10-12 of Alice's range proof, the response to the challenge e
+func (pf *RangeProofAlice) respond(pk *paillier.PublicKey, e, m, r *big.Int, wit *rangeProofAliceWitness) {
 	modN := common.ModInt(pk.N)
 	s := modN.Exp(r, e)
-	s = modN.Mul(s, beta)
+	s = modN.Mul(s, wit.beta)
 
 	// s1 = e * m + alpha
 	s1 := new(big.Int).Mul(e, m)
-	s1 = new(big.Int).Add(s1, alpha)
+	s1 = new(big.Int).Add(s1, wit.alpha)
 
 	// s2 = e * rho + gamma
-	s2 := new(big.Int).Mul(e, rho)
-	s2 = new(big.Int).Add(s2, gamma)
+	s2 := new(big.Int).Mul(e, wit.rho)
+	s2 = new(big.Int).Add(s2, wit.gamma)
 
-	return &RangeProofAlice{Z: z, U: u, W: w, S: s, S1: s1, S2: s2}, nil
+	pf.S, pf.S1, pf.S2 = s, s1, s2
 }
 
 func RangeProofAliceFromBytes(bzs [][]byte) (*RangeProofAlice, error) {
@@ -109,40 +127,7 @@ func (pf *RangeProofAlice) Verify(ec elliptic.Curve, pk *paillier.PublicKey, NTi
 		return false
 	}
 
-	q := ec.Params().N
-	q3 := new(big.Int).Mul(q, q)
-	q3 = new(big.Int).Mul(q, q3)
-
-	if !common.IsInInterval(pf.Z, NTilde) {
-		return false
-	}
-	if !common.IsInInterval(pf.U, pk.NSquare()) {
-		return false
-	}
-	if !common.IsInInterval(pf.W, NTilde) {
-		return false
-	}
-	if !common.IsInInterval(pf.S, pk.N) {
-		return false
-	}
-	if new(big.Int).GCD(nil, nil, pf.Z, NTilde).Cmp(one) != 0 {
-		return false
-	}
-	if new(big.Int).GCD(nil, nil, pf.U, pk.NSquare()).Cmp(one) != 0 {
-		return false
-	}
-	if new(big.Int).GCD(nil, nil, pf.W, NTilde).Cmp(one) != 0 {
-		return false
-	}
-	if pf.S1.Cmp(q) == -1 {
-		return false
-	}
-	if pf.S2.Cmp(q) == -1 {
-		return false
-	}
-
-	// 3.
-	if pf.S1.Cmp(q3) == 1 {
+	if !pf.inBounds(ec, pk, NTilde) {
 		return false
 	}
 
@@ -150,7 +135,7 @@ func (pf *RangeProofAlice) Verify(ec elliptic.Curve, pk *paillier.PublicKey, NTi
 	var e *big.Int
 	{ // must use RejectionSample
 		eHash := common.SHA512_256i(append(pk.AsInts(), c, pf.Z, pf.U, pf.W)...)
-		e = common.RejectionSample(q, eHash)
+		e = common.RejectionSample(ec.Params().N, eHash)
 	}
 
 	var products *big.Int // for the following conditionals
@@ -186,6 +171,44 @@ func (pf *RangeProofAlice) Verify(ec elliptic.Curve, pk *paillier.PublicKey, NTi
 	return true
 }
 
+// inBounds checks that the values of the proof are in their ranges, which includes the check of s1 <= q^3 in step 3
+func (pf *RangeProofAlice) inBounds(ec elliptic.Curve, pk *paillier.PublicKey, NTilde *big.Int) bool {
+	q := ec.Params().N
+	q3 := new(big.Int).Mul(q, q)
+	q3 = new(big.Int).Mul(q, q3)
+
+	if !common.IsInInterval(pf.Z, NTilde) {
+		return false
+	}
+	if !common.IsInInterval(pf.U, pk.NSquare()) {
+		return false
+	}
+	if !common.IsInInterval(pf.W, NTilde) {
+		return false
+	}
+	if !common.IsInInterval(pf.S, pk.N) {
+		return false
+	}
+	if new(big.Int).GCD(nil, nil, pf.Z, NTilde).Cmp(one) != 0 {
+		return false
+	}
+	if new(big.Int).GCD(nil, nil, pf.U, pk.NSquare()).Cmp(one) != 0 {
+		return false
+	}
+	if new(big.Int).GCD(nil, nil, pf.W, NTilde).Cmp(one) != 0 {
+		return false
+	}
+	if pf.S1.Cmp(q) == -1 {
+		return false
+	}
+	if pf.S2.Cmp(q) == -1 {
+		return false
+	}
+
+	// 3.
+	return pf.S1.Cmp(q3) != 1
+}
+
 func (pf *RangeProofAlice) ValidateBasic() bool {
 	return pf.Z != nil &&
 		pf.U != nil &&
@@ -40,24 +40,20 @@ func BobMid(
 		err = errors.New("RangeProofAlice.Verify() returned false")
 		return
 	}
-	q := ec.Params().N
-	q5 := new(big.Int).Mul(q, q)  // q^2
-	q5 = new(big.Int).Mul(q5, q5) // q^4
-	q5 = new(big.Int).Mul(q5, q)  // q^5
-	betaPrm = common.GetRandomPositiveInt(q5)
-	cBetaPrm, cRand, err := pkA.EncryptAndReturnRandomness(betaPrm)
-	if err != nil {
-		return
-	}
-	cB, err = pkA.HomoMult(b, cA)
-	if err != nil {
-		return
-	}
-	cB, err = pkA.HomoAdd(cB, cBetaPrm)
+	return BobRespond(Session, ec, pkA, b, cA, NTildeA, h1A, h2A)
+}
+
+// BobRespond is BobMid for a range proof of Alice that has been verified already, e.g. in a BatchRangeProofAlice
+func BobRespond(
+	Session []byte,
+	ec elliptic.Curve,
+	pkA *paillier.PublicKey,
+	b, cA, NTildeA, h1A, h2A *big.Int,
+) (beta, cB, betaPrm *big.Int, piB *ProofBob, err error) {
+	beta, cB, betaPrm, cRand, err := bobCipher(ec, pkA, b, cA)
 	if err != nil {
 		return
 	}
-	beta = common.ModInt(q).Sub(zero, betaPrm)
 	piB, err = ProveBob(Session, ec, pkA, NTildeA, h1A, h2A, cA, cB, b, betaPrm, cRand)
 	return
 }
@@ -74,12 +70,34 @@ func BobMidWC(
 		err = errors.New("RangeProofAlice.Verify() returned false")
 		return
 	}
+	return BobRespondWC(Session, ec, pkA, b, cA, NTildeA, h1A, h2A, B)
+}
+
+// BobRespondWC is BobMidWC for a range proof of Alice that has been verified already, e.g. in a BatchRangeProofAlice
+func BobRespondWC(
+	Session []byte,
+	ec elliptic.Curve,
+	pkA *paillier.PublicKey,
+	b, cA, NTildeA, h1A, h2A *big.Int,
+	B *crypto.ECPoint,
+) (beta, cB, betaPrm *big.Int, piB *ProofBobWC, err error) {
+	beta, cB, betaPrm, cRand, err := bobCipher(ec, pkA, b, cA)
+	if err != nil {
+		return
+	}
+	piB, err = ProveBobWC(Session, ec, pkA, NTildeA, h1A, h2A, cA, cB, b, betaPrm, cRand, B)
+	return
+}
+
+// bobCipher computes Bob's ciphertext cB = b*cA + Enc(beta') and his share beta = -beta'
+func bobCipher(ec elliptic.Curve, pkA *paillier.PublicKey, b, cA *big.Int) (beta, cB, betaPrm, cRand *big.Int, err error) {
 	q := ec.Params().N
 	q5 := new(big.Int).Mul(q, q)  // q^2
 	q5 = new(big.Int).Mul(q5, q5) // q^4
 	q5 = new(big.Int).Mul(q5, q)  // q^5
 	betaPrm = common.GetRandomPositiveInt(q5)
-	cBetaPrm, cRand, err := pkA.EncryptAndReturnRandomness(betaPrm)
+	var cBetaPrm *big.Int
+	cBetaPrm, cRand, err = pkA.EncryptAndReturnRandomness(betaPrm)
 	if err != nil {
 		return
 	}
@@ -92,7 +110,6 @@ func BobMidWC(
 		return
 	}
 	beta = common.ModInt(q).Sub(zero, betaPrm)
-	piB, err = ProveBobWC(Session, ec, pkA, NTildeA, h1A, h2A, cA, cB, b, betaPrm, cRand, B)
 	return
 }
 
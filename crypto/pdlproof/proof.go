@@ -0,0 +1,199 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package pdlproof
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
+)
+
+const (
+	ProofPDLBytesParts = 8
+)
+
+type (
+	// ProofPDL proves that the Paillier ciphertext c = Gamma^x * r^N mod N^2 and the EC point X = R^x hide the same
+	// x, up to the slack of q^3 (the PDL with slack proof of GG20, Fig. 6)
+	ProofPDL struct {
+		Z      *big.Int
+		U1     *crypto.ECPoint
+		U2, U3 *big.Int
+		S1, S2 *big.Int
+		S3     *big.Int
+	}
+)
+
+var (
+	one = big.NewInt(1)
+)
+
+// NewProof implements the prover of the PDL with slack proof for X = R^x and c = Enc_pk(x; r), for a verifier with
+// the parameters NTilde, h1 and h2
+func NewProof(Session []byte, pk *paillier.PublicKey, c *big.Int, R, X *crypto.ECPoint, NTilde, h1, h2, x, r *big.Int) (*ProofPDL, error) {
+	if pk == nil || c == nil || R == nil || X == nil || NTilde == nil || h1 == nil || h2 == nil || x == nil || r == nil {
+		return nil, errors.New("ProvePDL constructor received nil value(s)")
+	}
+
+	q := R.Curve().Params().N
+	q3 := new(big.Int).Mul(q, q)
+	q3 = new(big.Int).Mul(q, q3)
+	qNTilde := new(big.Int).Mul(q, NTilde)
+	q3NTilde := new(big.Int).Mul(q3, NTilde)
+
+	alpha := common.GetRandomPositiveInt(q3)
+	beta := common.GetRandomPositiveRelativelyPrimeInt(pk.N)
+	rho := common.GetRandomPositiveInt(qNTilde)
+	gamma := common.GetRandomPositiveInt(q3NTilde)
+
+	modNTilde := common.ModInt(NTilde)
+	z := modNTilde.Mul(modNTilde.Exp(h1, x), modNTilde.Exp(h2, rho))
+
+	u1 := R.ScalarMult(new(big.Int).Mod(alpha, q))
+
+	modNSquared := common.ModInt(pk.NSquare())
+	u2 := modNSquared.Mul(modNSquared.Exp(pk.Gamma(), alpha), modNSquared.Exp(beta, pk.N))
+
+	u3 := modNTilde.Mul(modNTilde.Exp(h1, alpha), modNTilde.Exp(h2, gamma))
+
+	e := challenge(Session, pk, c, R, X, NTilde, h1, h2, z, u1, u2, u3)
+
+	// s1 = e * x + alpha
+	s1 := new(big.Int).Mul(e, x)
+	s1 = new(big.Int).Add(s1, alpha)
+
+	// s2 = r^e * beta mod N
+	modN := common.ModInt(pk.N)
+	s2 := modN.Mul(modN.Exp(r, e), beta)
+
+	// s3 = e * rho + gamma
+	s3 := new(big.Int).Mul(e, rho)
+	s3 = new(big.Int).Add(s3, gamma)
+
+	return &ProofPDL{Z: z, U1: u1, U2: u2, U3: u3, S1: s1, S2: s2, S3: s3}, nil
+}
+
+func NewProofFromBytes(bzs [][]byte, R *crypto.ECPoint) (*ProofPDL, error) {
+	if !common.NonEmptyMultiBytes(bzs, ProofPDLBytesParts) {
+		return nil, fmt.Errorf("expected %d byte parts to construct ProofPDL", ProofPDLBytesParts)
+	}
+	if R == nil {
+		return nil, errors.New("ProofPDL needs the curve of R")
+	}
+	u1, err := crypto.NewECPoint(R.Curve(), new(big.Int).SetBytes(bzs[1]), new(big.Int).SetBytes(bzs[2]))
+	if err != nil {
+		return nil, err
+	}
+	return &ProofPDL{
+		Z:  new(big.Int).SetBytes(bzs[0]),
+		U1: u1,
+		U2: new(big.Int).SetBytes(bzs[3]),
+		U3: new(big.Int).SetBytes(bzs[4]),
+		S1: new(big.Int).SetBytes(bzs[5]),
+		S2: new(big.Int).SetBytes(bzs[6]),
+		S3: new(big.Int).SetBytes(bzs[7]),
+	}, nil
+}
+
+// Verify checks that X = R^x and that `c` encrypts x under `pk`; NTilde, h1 and h2 are the verifier's
+func (pf *ProofPDL) Verify(Session []byte, pk *paillier.PublicKey, c *big.Int, R, X *crypto.ECPoint, NTilde, h1, h2 *big.Int) bool {
+	if pf == nil || !pf.ValidateBasic() || pk == nil || c == nil || R == nil || X == nil || NTilde == nil || h1 == nil || h2 == nil {
+		return false
+	}
+	if NTilde.Sign() != 1 || pk.N == nil || pk.N.Sign() != 1 {
+		return false
+	}
+
+	q := R.Curve().Params().N
+	q3 := new(big.Int).Mul(q, q)
+	q3 = new(big.Int).Mul(q, q3)
+
+	if !common.IsInInterval(pf.Z, NTilde) || !common.IsInInterval(pf.U3, NTilde) {
+		return false
+	}
+	if !common.IsInInterval(pf.U2, pk.NSquare()) || !common.IsInInterval(c, pk.NSquare()) {
+		return false
+	}
+	if !common.IsInInterval(pf.S2, pk.N) {
+		return false
+	}
+	if new(big.Int).GCD(nil, nil, pf.Z, NTilde).Cmp(one) != 0 ||
+		new(big.Int).GCD(nil, nil, pf.U3, NTilde).Cmp(one) != 0 ||
+		new(big.Int).GCD(nil, nil, pf.U2, pk.NSquare()).Cmp(one) != 0 ||
+		new(big.Int).GCD(nil, nil, c, pk.NSquare()).Cmp(one) != 0 ||
+		new(big.Int).GCD(nil, nil, pf.S2, pk.N).Cmp(one) != 0 {
+		return false
+	}
+	// the range check with slack
+	if pf.S1.Cmp(q3) == 1 {
+		return false
+	}
+
+	e := challenge(Session, pk, c, R, X, NTilde, h1, h2, pf.Z, pf.U1, pf.U2, pf.U3)
+	minusE := new(big.Int).Neg(e)
+
+	{ // u1 = R^s1 * X^-e
+		RS1 := R.ScalarMult(new(big.Int).Mod(pf.S1, q))
+		XMinusE := X.ScalarMult(new(big.Int).Mod(minusE, q))
+		products, err := RS1.Add(XMinusE)
+		if err != nil || !products.Equals(pf.U1) {
+			return false
+		}
+	}
+
+	{ // u2 = Gamma^s1 * s2^N * c^-e mod N^2
+		modNSquared := common.ModInt(pk.NSquare())
+		products := modNSquared.Mul(modNSquared.Exp(pk.Gamma(), pf.S1), modNSquared.Exp(pf.S2, pk.N))
+		products = modNSquared.Mul(products, modNSquared.Exp(c, minusE))
+		if pf.U2.Cmp(products) != 0 {
+			return false
+		}
+	}
+
+	{ // u3 = h1^s1 * h2^s3 * z^-e mod NTilde
+		modNTilde := common.ModInt(NTilde)
+		products := modNTilde.Mul(modNTilde.Exp(h1, pf.S1), modNTilde.Exp(h2, pf.S3))
+		products = modNTilde.Mul(products, modNTilde.Exp(pf.Z, minusE))
+		if pf.U3.Cmp(products) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (pf *ProofPDL) ValidateBasic() bool {
+	return pf.Z != nil &&
+		pf.U1 != nil &&
+		pf.U2 != nil &&
+		pf.U3 != nil &&
+		pf.S1 != nil &&
+		pf.S2 != nil &&
+		pf.S3 != nil
+}
+
+func (pf *ProofPDL) Bytes() [ProofPDLBytesParts][]byte {
+	return [...][]byte{
+		pf.Z.Bytes(),
+		pf.U1.X().Bytes(),
+		pf.U1.Y().Bytes(),
+		pf.U2.Bytes(),
+		pf.U3.Bytes(),
+		pf.S1.Bytes(),
+		pf.S2.Bytes(),
+		pf.S3.Bytes(),
+	}
+}
+
+func challenge(Session []byte, pk *paillier.PublicKey, c *big.Int, R, X *crypto.ECPoint, NTilde, h1, h2, z *big.Int, u1 *crypto.ECPoint, u2, u3 *big.Int) *big.Int {
+	q := R.Curve().Params().N
+	eHash := common.SHA512_256i_TAGGED(Session, pk.N, c, R.X(), R.Y(), X.X(), X.Y(), NTilde, h1, h2, z, u1.X(), u1.Y(), u2, u3)
+	return common.RejectionSample(q, eHash)
+}
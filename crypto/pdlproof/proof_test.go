@@ -0,0 +1,65 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package pdlproof_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	. "github.com/bnb-chain/tss-lib/v2/crypto/pdlproof"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+var (
+	Session = []byte("session")
+)
+
+func TestPDL(test *testing.T) {
+	ec := tss.EC()
+	q := ec.Params().N
+	// the Paillier key of the prover and the NTilde of the verifier are taken from the keygen fixtures to save time
+	keys, _, err := keygen.LoadKeygenTestFixtures(2)
+	assert.NoError(test, err, "should load keygen fixtures")
+	pk := &keys[0].PaillierSK.PublicKey
+	NTilde, h1, h2 := keys[1].NTildei, keys[1].H1i, keys[1].H2i
+
+	x := common.GetRandomPositiveInt(q)
+	c, r, err := pk.EncryptAndReturnRandomness(x)
+	assert.NoError(test, err)
+	R := crypto.ScalarBaseMult(ec, common.GetRandomPositiveInt(q))
+	X := R.ScalarMult(x)
+
+	proof, err := NewProof(Session, pk, c, R, X, NTilde, h1, h2, x, r)
+	assert.NoError(test, err)
+	assert.True(test, proof.Verify(Session, pk, c, R, X, NTilde, h1, h2), "proof must verify")
+
+	bzs := proof.Bytes()
+	proof2, err := NewProofFromBytes(bzs[:], R)
+	assert.NoError(test, err)
+	assert.True(test, proof2.Verify(Session, pk, c, R, X, NTilde, h1, h2), "proof must verify after a round trip to bytes")
+
+	assert.False(test, proof.Verify([]byte("another session"), pk, c, R, X, NTilde, h1, h2))
+
+	// X does not hide the encrypted value
+	wrongX := R.ScalarMult(new(big.Int).Add(x, big.NewInt(1)))
+	assert.False(test, proof.Verify(Session, pk, c, R, wrongX, NTilde, h1, h2), "a proof for another X must fail")
+	proof3, err := NewProof(Session, pk, c, R, wrongX, NTilde, h1, h2, x, r)
+	assert.NoError(test, err)
+	assert.False(test, proof3.Verify(Session, pk, c, R, wrongX, NTilde, h1, h2), "a proof of a false statement must fail")
+
+	// c does not encrypt x
+	c2, r2, err := pk.EncryptAndReturnRandomness(new(big.Int).Add(x, big.NewInt(1)))
+	assert.NoError(test, err)
+	proof4, err := NewProof(Session, pk, c2, R, X, NTilde, h1, h2, x, r2)
+	assert.NoError(test, err)
+	assert.False(test, proof4.Verify(Session, pk, c2, R, X, NTilde, h1, h2), "a proof of a false statement must fail")
+}
@@ -0,0 +1,143 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package ristretto255 encodes and decodes the elements of the ristretto255 group of RFC 9496, which sr25519 keys and
+// signatures use. Ristretto255 is the prime-order subgroup of edwards25519, so an element is represented by a point
+// of tss.Edwards(), e.g. the EDDSAPub of an EdDSA key. The encoding runs on the constant-time field arithmetic of
+// filippo.io/edwards25519; the group operations of sr25519 are left to github.com/gtank/ristretto255.
+package ristretto255
+
+import (
+	"crypto/subtle"
+	"errors"
+	"math/big"
+
+	"filippo.io/edwards25519/field"
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+)
+
+// EncodedLength is the length of an encoded element
+const EncodedLength = 32
+
+var (
+	// p = 2^255 - 19
+	p = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(19))
+
+	one = new(field.Element).One()
+	// d = -121665/121666
+	d = new(field.Element).Multiply(
+		new(field.Element).Negate(feFromUint32(121665)),
+		new(field.Element).Invert(feFromUint32(121666)))
+	// sqrt(-1) = 2^((p-1)/4)
+	sqrtM1 = mustFieldElement(new(big.Int).Exp(big.NewInt(2), new(big.Int).Rsh(new(big.Int).Sub(p, big.NewInt(1)), 2), p))
+	// 1/sqrt(a-d), with a = -1
+	invSqrtAMinusD, _ = new(field.Element).SqrtRatio(one, new(field.Element).Subtract(new(field.Element).Negate(one), d))
+)
+
+// Encode returns the 32-byte encoding of the element represented by `point`, a point of tss.Edwards(). The points
+// that differ by a point of order 4 have the same encoding.
+func Encode(point *crypto.ECPoint) ([]byte, error) {
+	if point == nil || !point.ValidateBasic() {
+		return nil, errors.New("invalid point")
+	}
+	if point.Curve().Params().P.Cmp(p) != 0 {
+		return nil, errors.New("the point is not on edwards25519")
+	}
+	x0, err := fieldElement(point.X())
+	if err != nil {
+		return nil, err
+	}
+	y0, err := fieldElement(point.Y())
+	if err != nil {
+		return nil, err
+	}
+	// RFC 9496, section 4.3.2, with z0 = 1 and t0 = x0*y0
+	t0 := new(field.Element).Multiply(x0, y0)
+	u1 := new(field.Element).Multiply(new(field.Element).Add(one, y0), new(field.Element).Subtract(one, y0))
+	u2 := new(field.Element).Multiply(x0, y0)
+	invSqrt, _ := new(field.Element).SqrtRatio(one, new(field.Element).Multiply(u1, new(field.Element).Square(u2)))
+	den1 := new(field.Element).Multiply(invSqrt, u1)
+	den2 := new(field.Element).Multiply(invSqrt, u2)
+	zInv := new(field.Element).Multiply(new(field.Element).Multiply(den1, den2), t0)
+	ix0 := new(field.Element).Multiply(x0, sqrtM1)
+	iy0 := new(field.Element).Multiply(y0, sqrtM1)
+	enchantedDenominator := new(field.Element).Multiply(den1, invSqrtAMinusD)
+
+	rotate := new(field.Element).Multiply(t0, zInv).IsNegative()
+	x := new(field.Element).Select(iy0, x0, rotate)
+	y := new(field.Element).Select(ix0, y0, rotate)
+	denInv := new(field.Element).Select(enchantedDenominator, den2, rotate)
+	y.Select(new(field.Element).Negate(y), y, new(field.Element).Multiply(x, zInv).IsNegative())
+	s := new(field.Element).Absolute(new(field.Element).Multiply(denInv, new(field.Element).Subtract(one, y)))
+	return s.Bytes(), nil
+}
+
+// Decode returns a point of tss.Edwards() that represents the element encoded in `bz`. It rejects encodings that are
+// not canonical.
+func Decode(bz []byte) (*crypto.ECPoint, error) {
+	if len(bz) != EncodedLength {
+		return nil, errors.New("invalid ristretto255 encoding length")
+	}
+	// RFC 9496, section 4.3.1
+	s, err := new(field.Element).SetBytes(bz)
+	if err != nil || subtle.ConstantTimeCompare(s.Bytes(), bz) != 1 || s.IsNegative() == 1 {
+		return nil, errors.New("non-canonical ristretto255 encoding")
+	}
+	ss := new(field.Element).Square(s)
+	u1 := new(field.Element).Subtract(one, ss)
+	u2 := new(field.Element).Add(one, ss)
+	u2Sqr := new(field.Element).Square(u2)
+	v := new(field.Element).Multiply(d, new(field.Element).Square(u1))
+	v.Subtract(new(field.Element).Negate(v), u2Sqr)
+	invSqrt, wasSquare := new(field.Element).SqrtRatio(one, new(field.Element).Multiply(v, u2Sqr))
+	denX := new(field.Element).Multiply(invSqrt, u2)
+	denY := new(field.Element).Multiply(new(field.Element).Multiply(invSqrt, denX), v)
+	x := new(field.Element).Multiply(new(field.Element).Add(s, s), denX)
+	x.Absolute(x)
+	y := new(field.Element).Multiply(u1, denY)
+	t := new(field.Element).Multiply(x, y)
+	if wasSquare == 0 || t.IsNegative() == 1 || y.Equal(new(field.Element).Zero()) == 1 {
+		return nil, errors.New("invalid ristretto255 encoding")
+	}
+	return crypto.NewECPoint(edwards.Edwards(), bigInt(x), bigInt(y))
+}
+
+// fieldElement converts a coordinate of a point of tss.Edwards() to a field element
+func fieldElement(a *big.Int) (*field.Element, error) {
+	if a.Sign() < 0 || a.Cmp(p) >= 0 {
+		return nil, errors.New("the coordinate is not a field element")
+	}
+	bz := make([]byte, EncodedLength)
+	a.FillBytes(bz)
+	reverse(bz)
+	return new(field.Element).SetBytes(bz)
+}
+
+func mustFieldElement(a *big.Int) *field.Element {
+	fe, err := fieldElement(a)
+	if err != nil {
+		panic(err)
+	}
+	return fe
+}
+
+func feFromUint32(a uint32) *field.Element {
+	return new(field.Element).Mult32(one, a)
+}
+
+func bigInt(fe *field.Element) *big.Int {
+	bz := fe.Bytes()
+	reverse(bz)
+	return new(big.Int).SetBytes(bz)
+}
+
+func reverse(bz []byte) {
+	for i, j := 0, len(bz)-1; i < j; i, j = i+1, j-1 {
+		bz[i], bz[j] = bz[j], bz[i]
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package ristretto255_test
+
+import (
+	"crypto/sha512"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	gtank "github.com/gtank/ristretto255"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	. "github.com/bnb-chain/tss-lib/v2/crypto/ristretto255"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func TestEncodeMultiplesOfBasepoint(t *testing.T) {
+	// RFC 9496, appendix A.1
+	vectors := []string{
+		"e2f2ae0a6abc4e71a884a961c500515f58e30b6aa582dd8db6a65945e08d2d76",
+		"6a493210f7499cd17fecb510ae0cea23a110e8d5b901f8acadd3095c73a3b919",
+		"94741f5d5d52755ece4f23f044ee27d5d1ea1e2bd196b462166b16152a9d0259",
+		"da80862773358b466ffadfe0b3293ab3d9fd53c5ea6c955358f568322daf6a57",
+		"e882b131016b52c1d3337080187cf768423efccbb517bb495ab812c4160ff44e",
+	}
+	for i, vector := range vectors {
+		point := crypto.ScalarBaseMult(tss.Edwards(), big.NewInt(int64(i+1)))
+		encoded, err := Encode(point)
+		assert.NoError(t, err)
+		assert.Equal(t, vector, hex.EncodeToString(encoded), "%d*B", i+1)
+
+		decoded, err := Decode(encoded)
+		assert.NoError(t, err)
+		reEncoded, err := Encode(decoded)
+		assert.NoError(t, err)
+		assert.Equal(t, encoded, reEncoded)
+	}
+}
+
+func TestEncodeIgnoresTorsion(t *testing.T) {
+	ec := tss.Edwards()
+	for i := 0; i < 10; i++ {
+		point := crypto.ScalarBaseMult(ec, common.GetRandomPositiveInt(ec.Params().N))
+		encoded, err := Encode(point)
+		assert.NoError(t, err)
+		// (x, y) + (0, -1), a point of order 2, and the decoded representative are the same element
+		torsion, err := point.Add(crypto.NewECPointNoCurveCheck(ec, big.NewInt(0), new(big.Int).Sub(ec.Params().P, big.NewInt(1))))
+		assert.NoError(t, err)
+		encodedTorsion, err := Encode(torsion)
+		assert.NoError(t, err)
+		assert.Equal(t, encoded, encodedTorsion)
+		decoded, err := Decode(encoded)
+		assert.NoError(t, err)
+		assert.True(t, decoded.ScalarMult(big.NewInt(4)).Equals(point.ScalarMult(big.NewInt(4))))
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	// RFC 9496, appendix A.2
+	for _, vector := range []string{
+		// non-canonical field encodings
+		"00ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+		"f3ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+		"edffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+		// negative field elements
+		"0100000000000000000000000000000000000000000000000000000000000000",
+		"01ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+		// non-square x^2
+		"26948d35ca62e643e26a83177332e6b6afeb9d08e4268b650f1f5bbd8d81d371",
+		"4eac077a713c57b4f4397629a4145982c661f48044dd3f96427d40b147d9742f",
+	} {
+		bz, _ := hex.DecodeString(vector)
+		_, err := Decode(bz)
+		assert.Error(t, err, vector)
+	}
+	_, err := Decode(make([]byte, 31))
+	assert.Error(t, err)
+}
+
+func TestHashToGroup(t *testing.T) {
+	// RFC 9496, appendix A.3: the elements are those of the SHA-512 hashes of the inputs. The map itself is the one of
+	// github.com/gtank/ristretto255; the encodings must decode to points of tss.Edwards() and encode back the same.
+	inputs := []string{
+		"Ristretto is traditionally a short shot of espresso coffee",
+		"made with the normal amount of ground coffee but extracted with",
+		"about half the amount of water in the same amount of time",
+		"by using a finer grind.",
+		"This produces a concentrated shot of coffee per volume.",
+		"Just pulling a normal shot short will produce a weaker shot",
+		"and is not a Ristretto as some believe.",
+	}
+	elements := []string{
+		"3066f82a1a747d45120d1740f14358531a8f04bbffe6a819f86dfe50f44a0a46",
+		"f26e5b6f7d362d2d2a94c5d0e7602cb4773c95a2e5c31a64f133189fa76ed61b",
+		"006ccd2a9e6867e6a2c5cea83d3302cc9de128dd2a9a57dd8ee7b9d7ffe02826",
+		"f8f0c87cf237953c5890aec3998169005dae3eca1fbb04548c635953c817f92a",
+		"ae81e7dedf20a497e10c304a765c1767a42d6e06029758d2d7e8ef7cc4c41179",
+		"e2705652ff9f5e44d3e841bf1c251cf7dddb77d140870d1ab2ed64f1a9ce8628",
+		"80bd07262511cdde4863f8a7434cef696750681cb9510eea557088f76d9e5065",
+	}
+	for i, input := range inputs {
+		hash := sha512.Sum512([]byte(input))
+		element := gtank.NewElement().FromUniformBytes(hash[:])
+		assert.Equal(t, elements[i], hex.EncodeToString(element.Encode(nil)), input)
+
+		bz, _ := hex.DecodeString(elements[i])
+		point, err := Decode(bz)
+		if !assert.NoError(t, err, input) {
+			continue
+		}
+		encoded, err := Encode(point)
+		assert.NoError(t, err)
+		assert.Equal(t, bz, encoded, input)
+	}
+}
+
+func TestEncodeMatchesGtank(t *testing.T) {
+	ec := tss.Edwards()
+	for i := 0; i < 20; i++ {
+		k := common.GetRandomPositiveInt(ec.Params().N)
+		kBytes := make([]byte, 32)
+		k.FillBytes(kBytes)
+		for i, j := 0, len(kBytes)-1; i < j; i, j = i+1, j-1 {
+			kBytes[i], kBytes[j] = kBytes[j], kBytes[i]
+		}
+		scalar := gtank.NewScalar()
+		if !assert.NoError(t, scalar.Decode(kBytes)) {
+			continue
+		}
+		expected := gtank.NewElement().ScalarBaseMult(scalar).Encode(nil)
+
+		encoded, err := Encode(crypto.ScalarBaseMult(ec, k))
+		assert.NoError(t, err)
+		assert.Equal(t, expected, encoded)
+		// the decoded representative may differ from k*B by a point of order 4, so compare the multiples by 4
+		decoded, err := Decode(expected)
+		assert.NoError(t, err)
+		assert.True(t, decoded.ScalarMult(big.NewInt(4)).Equals(crypto.ScalarBaseMult(ec, new(big.Int).Lsh(k, 2))))
+	}
+}
@@ -24,6 +24,11 @@ type (
 		Alpha *crypto.ECPoint
 		T, U  *big.Int
 	}
+
+	ZKSTProof struct {
+		Alpha, Beta *crypto.ECPoint
+		T, U        *big.Int
+	}
 )
 
 // NewZKProof constructs a new Schnorr ZK proof of knowledge of the discrete logarithm (GG18Spec Fig. 16)
@@ -134,3 +139,77 @@ func (pf *ZKVProof) Verify(Session []byte, V, R *crypto.ECPoint) bool {
 func (pf *ZKVProof) ValidateBasic() bool {
 	return pf.Alpha != nil && pf.T != nil && pf.U != nil && pf.Alpha.ValidateBasic()
 }
+
+// NewZKSTProof constructs a new ZK proof of knowledge of sigma_i, l_i such that S_i = R^sigma_i and
+// T_i = g^sigma_i h^l_i (GG20 Fig. 6, the proof of consistency of S_i and T_i)
+func NewZKSTProof(Session []byte, S, T, R, h *crypto.ECPoint, sigma, l *big.Int) (*ZKSTProof, error) {
+	if S == nil || T == nil || R == nil || h == nil || sigma == nil || l == nil ||
+		!S.ValidateBasic() || !T.ValidateBasic() || !R.ValidateBasic() || !h.ValidateBasic() {
+		return nil, errors.New("ZKSTProof constructor received nil value(s)")
+	}
+	ec := S.Curve()
+	ecParams := ec.Params()
+	q := ecParams.N
+	g := crypto.NewECPointNoCurveCheck(ec, ecParams.Gx, ecParams.Gy)
+
+	a, b := common.GetRandomPositiveInt(q), common.GetRandomPositiveInt(q)
+	alpha := R.ScalarMult(a)
+	aG := crypto.ScalarBaseMult(ec, a)
+	bH := h.ScalarMult(b)
+	beta, err := aG.Add(bH)
+	if err != nil {
+		return nil, err
+	}
+
+	var c *big.Int
+	{
+		cHash := common.SHA512_256i_TAGGED(Session, S.X(), S.Y(), T.X(), T.Y(), R.X(), R.Y(), h.X(), h.Y(), g.X(), g.Y(),
+			alpha.X(), alpha.Y(), beta.X(), beta.Y())
+		c = common.RejectionSample(q, cHash)
+	}
+	modQ := common.ModInt(q)
+	t := modQ.Add(a, new(big.Int).Mul(c, sigma))
+	u := modQ.Add(b, new(big.Int).Mul(c, l))
+
+	return &ZKSTProof{Alpha: alpha, Beta: beta, T: t, U: u}, nil
+}
+
+func (pf *ZKSTProof) Verify(Session []byte, S, T, R, h *crypto.ECPoint) bool {
+	if pf == nil || !pf.ValidateBasic() || S == nil || T == nil || R == nil || h == nil {
+		return false
+	}
+	ec := S.Curve()
+	ecParams := ec.Params()
+	q := ecParams.N
+	g := crypto.NewECPointNoCurveCheck(ec, ecParams.Gx, ecParams.Gy)
+
+	var c *big.Int
+	{
+		cHash := common.SHA512_256i_TAGGED(Session, S.X(), S.Y(), T.X(), T.Y(), R.X(), R.Y(), h.X(), h.Y(), g.X(), g.Y(),
+			pf.Alpha.X(), pf.Alpha.Y(), pf.Beta.X(), pf.Beta.Y())
+		c = common.RejectionSample(q, cHash)
+	}
+
+	// R^t = alpha * S^c
+	tR := R.ScalarMult(pf.T)
+	aSc, err := pf.Alpha.Add(S.ScalarMult(c))
+	if err != nil || !tR.Equals(aSc) {
+		return false
+	}
+
+	// g^t * h^u = beta * T^c
+	tGuH, err := crypto.ScalarBaseMult(ec, pf.T).Add(h.ScalarMult(pf.U))
+	if err != nil {
+		return false
+	}
+	bTc, err := pf.Beta.Add(T.ScalarMult(c))
+	if err != nil {
+		return false
+	}
+	return tGuH.Equals(bTc)
+}
+
+func (pf *ZKSTProof) ValidateBasic() bool {
+	return pf.Alpha != nil && pf.Beta != nil && pf.T != nil && pf.U != nil &&
+		pf.Alpha.ValidateBasic() && pf.Beta.ValidateBasic()
+}
@@ -104,3 +104,36 @@ func TestSchnorrVProofVerifyBadS(t *testing.T) {
 
 	assert.False(t, res, "verify result must be false")
 }
+
+func TestSchnorrSTProofVerify(t *testing.T) {
+	q := tss.EC().Params().N
+	k := common.GetRandomPositiveInt(q)
+	sigma := common.GetRandomPositiveInt(q)
+	l := common.GetRandomPositiveInt(q)
+	R := crypto.ScalarBaseMult(tss.EC(), k)
+	h := crypto.ScalarBaseMult(tss.EC(), common.GetRandomPositiveInt(q))
+	S := R.ScalarMult(sigma)
+	T, _ := crypto.ScalarBaseMult(tss.EC(), sigma).Add(h.ScalarMult(l))
+
+	proof, _ := NewZKSTProof(Session, S, T, R, h, sigma, l)
+	res := proof.Verify(Session, S, T, R, h)
+
+	assert.True(t, res, "verify result must be true")
+}
+
+func TestSchnorrSTProofVerifyBadS(t *testing.T) {
+	q := tss.EC().Params().N
+	k := common.GetRandomPositiveInt(q)
+	sigma := common.GetRandomPositiveInt(q)
+	sigma2 := common.GetRandomPositiveInt(q)
+	l := common.GetRandomPositiveInt(q)
+	R := crypto.ScalarBaseMult(tss.EC(), k)
+	h := crypto.ScalarBaseMult(tss.EC(), common.GetRandomPositiveInt(q))
+	S := R.ScalarMult(sigma2)
+	T, _ := crypto.ScalarBaseMult(tss.EC(), sigma).Add(h.ScalarMult(l))
+
+	proof, _ := NewZKSTProof(Session, S, T, R, h, sigma2, l)
+	res := proof.Verify(Session, S, T, R, h)
+
+	assert.False(t, res, "verify result must be false")
+}
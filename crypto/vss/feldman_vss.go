@@ -144,6 +144,126 @@ func (shares Shares) ReConstruct(ec elliptic.Curve) (secret *big.Int, err error)
 	return secret, nil
 }
 
+// LagrangeCoefficient returns the coefficient of the share of indexes[i] when the polynomial is interpolated at `x` from
+// the shares of `indexes`. With x = 0 it is the coefficient used to reconstruct the secret; with the index of another
+// party it gives the share of that party.
+func LagrangeCoefficient(ec elliptic.Curve, indexes []*big.Int, i int, x *big.Int) (*big.Int, error) {
+	if i < 0 || len(indexes) <= i {
+		return nil, errors.New("vss index out of range")
+	}
+	if _, err := CheckIndexes(ec, indexes); err != nil {
+		return nil, err
+	}
+	modN := common.ModInt(ec.Params().N)
+	coef := one
+	for j, kj := range indexes {
+		if j == i {
+			continue
+		}
+		num := modN.Sub(x, kj)
+		den := modN.Sub(indexes[i], kj)
+		coef = modN.Mul(coef, modN.Mul(num, modN.ModInverse(den)))
+	}
+	return coef, nil
+}
+
+// InterpolatePoint returns f(x)*G, where f is the polynomial of degree len(indexes)-1 with f(indexes[j])*G = points[j],
+// e.g. the public key when `points` are the BigXj of the parties and x = 0.
+func InterpolatePoint(ec elliptic.Curve, indexes []*big.Int, points []*crypto.ECPoint, x *big.Int) (*crypto.ECPoint, error) {
+	if len(indexes) == 0 || len(indexes) != len(points) {
+		return nil, errors.New("the number of indexes and points must be equal and non-zero")
+	}
+	var result *crypto.ECPoint
+	for j, point := range points {
+		if point == nil {
+			return nil, errors.New("a point is missing")
+		}
+		lambda, err := LagrangeCoefficient(ec, indexes, j, x)
+		if err != nil {
+			return nil, err
+		}
+		term := point.ScalarMult(lambda)
+		if result == nil {
+			result = term
+		} else if result, err = result.Add(term); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// CreateZeroSharing creates shares of zero, which are added to the shares of an existing secret to refresh them.
+// The constant term of the polynomial is zero and its commitment is the point at infinity, so it is left out and
+// the returned Vs holds only v1..vt.
+func CreateZeroSharing(ec elliptic.Curve, threshold int, indexes []*big.Int) (Vs, Shares, error) {
+	if indexes == nil {
+		return nil, nil, errors.New("vss indexes == nil")
+	}
+	if threshold < 1 {
+		return nil, nil, errors.New("vss threshold < 1")
+	}
+
+	ids, err := CheckIndexes(ec, indexes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	num := len(indexes)
+	if num < threshold {
+		return nil, nil, ErrNumSharesBelowThreshold
+	}
+
+	poly := samplePolynomial(ec, threshold, zero)
+	v := make(Vs, threshold)
+	for i := range v {
+		v[i] = crypto.ScalarBaseMult(ec, poly[i+1])
+	}
+
+	shares := make(Shares, num)
+	for i := 0; i < num; i++ {
+		share := evaluatePolynomial(ec, threshold, poly, ids[i])
+		shares[i] = &Share{Threshold: threshold, ID: ids[i], Share: share}
+	}
+	return v, shares, nil
+}
+
+// ZeroSharingCommitment returns share*G for the share of `id` in a sharing of zero with the commitments v1..vt.
+func ZeroSharingCommitment(ec elliptic.Curve, vs Vs, id *big.Int) (*crypto.ECPoint, error) {
+	if len(vs) == 0 {
+		return nil, errors.New("vss commitments are empty")
+	}
+	var err error
+	modQ := common.ModInt(ec.Params().N)
+	t := one
+	var v *crypto.ECPoint
+	for j := 1; j <= len(vs); j++ {
+		// t = id^j
+		t = modQ.Mul(t, id)
+		vjt := vs[j-1].SetCurve(ec).ScalarMult(t)
+		if v == nil {
+			v = vjt
+			continue
+		}
+		if v, err = v.Add(vjt); err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
+
+// VerifyZeroShare verifies a share created by CreateZeroSharing against the commitments v1..vt.
+func (share *Share) VerifyZeroShare(ec elliptic.Curve, threshold int, vs Vs) bool {
+	if share.Threshold != threshold || len(vs) != threshold {
+		return false
+	}
+	v, err := ZeroSharingCommitment(ec, vs, share.ID)
+	if err != nil {
+		return false
+	}
+	sigmaGi := crypto.ScalarBaseMult(ec, share.Share)
+	return sigmaGi.Equals(v)
+}
+
 func samplePolynomial(ec elliptic.Curve, threshold int, secret *big.Int) []*big.Int {
 	q := ec.Params().N
 	v := make([]*big.Int, threshold+1)
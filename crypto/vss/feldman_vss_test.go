@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
 	. "github.com/bnb-chain/tss-lib/v2/crypto/vss"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 )
@@ -114,3 +115,109 @@ func TestReconstruct(t *testing.T) {
 	assert.NoError(t, err4)
 	assert.NotZero(t, secret4)
 }
+
+func TestZeroSharing(t *testing.T) {
+	num, threshold := 5, 3
+
+	secret := common.GetRandomPositiveInt(tss.EC().Params().N)
+
+	ids := make([]*big.Int, 0)
+	for i := 0; i < num; i++ {
+		ids = append(ids, common.GetRandomPositiveInt(tss.EC().Params().N))
+	}
+
+	vs, shares, err := Create(tss.EC(), threshold, secret, ids)
+	assert.NoError(t, err)
+	zeroVs, zeroShares, err := CreateZeroSharing(tss.EC(), threshold, ids)
+	assert.NoError(t, err)
+	assert.Equal(t, threshold, len(zeroVs))
+
+	refreshed := make(Shares, num)
+	for i := 0; i < num; i++ {
+		assert.True(t, zeroShares[i].VerifyZeroShare(tss.EC(), threshold, zeroVs))
+		refreshed[i] = &Share{
+			Threshold: threshold,
+			ID:        ids[i],
+			Share:     new(big.Int).Mod(new(big.Int).Add(shares[i].Share, zeroShares[i].Share), tss.EC().Params().N),
+		}
+		assert.NotEqual(t, shares[i].Share, refreshed[i].Share)
+	}
+	// the refreshed shares still reconstruct the secret
+	secret2, err := refreshed[:threshold+1].ReConstruct(tss.EC())
+	assert.NoError(t, err)
+	assert.Equal(t, secret, secret2)
+
+	// a share of another secret fails verification
+	assert.False(t, shares[0].VerifyZeroShare(tss.EC(), threshold, zeroVs))
+	assert.False(t, zeroShares[0].VerifyZeroShare(tss.EC(), threshold, vs))
+}
+
+func TestLagrangeCoefficient(t *testing.T) {
+	num, threshold := 5, 3
+	ec := tss.EC()
+	modN := common.ModInt(ec.Params().N)
+
+	secret := common.GetRandomPositiveInt(ec.Params().N)
+
+	ids := make([]*big.Int, 0)
+	for i := 0; i < num; i++ {
+		ids = append(ids, common.GetRandomPositiveInt(ec.Params().N))
+	}
+
+	_, shares, err := Create(ec, threshold, secret, ids)
+	assert.NoError(t, err)
+
+	// the shares of the first threshold+1 parties give the secret and the share of the last party
+	helpers := ids[:threshold+1]
+	for _, x := range []*big.Int{big.NewInt(0), ids[num-1]} {
+		sum := big.NewInt(0)
+		for i := range helpers {
+			coef, err := LagrangeCoefficient(ec, helpers, i, x)
+			assert.NoError(t, err)
+			sum = modN.Add(sum, modN.Mul(coef, shares[i].Share))
+		}
+		if x.Sign() == 0 {
+			assert.Equal(t, secret, sum)
+		} else {
+			assert.Equal(t, shares[num-1].Share, sum)
+		}
+	}
+
+	_, err = LagrangeCoefficient(ec, append(append([]*big.Int{}, helpers...), helpers[0]), 0, big.NewInt(0))
+	assert.Error(t, err)
+	_, err = LagrangeCoefficient(ec, helpers, len(helpers), big.NewInt(0))
+	assert.Error(t, err)
+}
+
+func TestInterpolatePoint(t *testing.T) {
+	num, threshold := 5, 3
+	ec := tss.EC()
+
+	secret := common.GetRandomPositiveInt(ec.Params().N)
+
+	ids := make([]*big.Int, 0)
+	for i := 0; i < num; i++ {
+		ids = append(ids, common.GetRandomPositiveInt(ec.Params().N))
+	}
+
+	vs, shares, err := Create(ec, threshold, secret, ids)
+	assert.NoError(t, err)
+
+	points := make([]*crypto.ECPoint, num)
+	for i, share := range shares {
+		points[i] = crypto.ScalarBaseMult(ec, share.Share)
+	}
+	// any threshold+1 points, or all of them, interpolate to the commitment of the secret
+	for _, k := range []int{threshold + 1, num} {
+		pub, err := InterpolatePoint(ec, ids[num-k:], points[num-k:], big.NewInt(0))
+		assert.NoError(t, err)
+		assert.True(t, pub.Equals(vs[0]))
+	}
+	// fewer points do not
+	pub, err := InterpolatePoint(ec, ids[:threshold], points[:threshold], big.NewInt(0))
+	assert.NoError(t, err)
+	assert.False(t, pub.Equals(vs[0]))
+
+	_, err = InterpolatePoint(ec, ids, points[1:], big.NewInt(0))
+	assert.Error(t, err)
+}
@@ -7,6 +7,7 @@
 package keygen
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math/big"
@@ -53,6 +54,9 @@ type (
 		ssidNonce     *big.Int
 		shares        vss.Shares
 		deCommitPolyG cmt.HashDeCommitment
+
+		chainCodeCmts     []cmt.HashCommitment
+		deCommitChainCode cmt.HashDeCommitment
 	}
 )
 
@@ -90,6 +94,7 @@ func NewLocalParty(
 	p.temp.kgRound3Messages = make([]tss.ParsedMessage, partyCount)
 	// temp data init
 	p.temp.KGCs = make([]cmt.HashCommitment, partyCount)
+	p.temp.chainCodeCmts = make([]cmt.HashCommitment, partyCount)
 	return p
 }
 
@@ -101,6 +106,10 @@ func (p *LocalParty) Start() *tss.Error {
 	return tss.BaseStart(p, TaskName)
 }
 
+func (p *LocalParty) StartWithContext(ctx context.Context, errCh chan<- *tss.Error) *tss.Error {
+	return tss.BaseStartWithContext(ctx, p, TaskName, errCh)
+}
+
 func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
 	return tss.BaseUpdate(p, msg, TaskName)
 }
@@ -8,11 +8,13 @@ package keygen
 
 import (
 	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rand"
 	"encoding/json"
 	"fmt"
 	"math/big"
 	"os"
+	"path/filepath"
 	"runtime"
 	"sync/atomic"
 	"testing"
@@ -152,7 +154,7 @@ func TestBadMessageCulprits(t *testing.T) {
 		assert.FailNow(t, err.Error())
 	}
 
-	badMsg, _ := NewKGRound1Message(pIDs[1], zero, &paillier.PublicKey{N: zero}, zero, zero, zero, new(dlnproof.Proof), new(dlnproof.Proof))
+	badMsg, _ := NewKGRound1Message(pIDs[1], zero, zero, &paillier.PublicKey{N: zero}, zero, zero, zero, new(dlnproof.Proof), new(dlnproof.Proof))
 	ok, err2 := lp.Update(badMsg)
 	t.Log(err2)
 	assert.False(t, ok)
@@ -167,11 +169,22 @@ func TestBadMessageCulprits(t *testing.T) {
 }
 
 func TestE2EConcurrentAndSaveFixtures(t *testing.T) {
-	setUp("info")
+	testE2EConcurrentAndSaveFixtures(t, tss.S256())
+}
+
+func TestE2EConcurrentAndSaveFixturesP256(t *testing.T) {
+	testE2EConcurrentAndSaveFixtures(t, tss.P256())
+}
 
-	// tss.SetCurve(elliptic.P256())
+func TestE2EConcurrentAndSaveFixturesP384(t *testing.T) {
+	testE2EConcurrentAndSaveFixtures(t, tss.P384())
+}
+
+func testE2EConcurrentAndSaveFixtures(t *testing.T, ec elliptic.Curve) {
+	setUp("info")
 
 	threshold := testThreshold
+	// the pre-params of the secp256k1 fixtures do not depend on the curve, so they are used for every curve
 	fixtures, pIDs, err := LoadKeygenTestFixtures(testParticipants)
 	if err != nil {
 		common.Logger.Info("No test fixtures were found, so the safe primes will be generated from scratch. This may take a while...")
@@ -192,7 +205,7 @@ func TestE2EConcurrentAndSaveFixtures(t *testing.T) {
 	// init the parties
 	for i := 0; i < len(pIDs); i++ {
 		var P *LocalParty
-		params := tss.NewParameters(tss.S256(), p2pCtx, pIDs[i], len(pIDs), threshold)
+		params := tss.NewParameters(ec, p2pCtx, pIDs[i], len(pIDs), threshold)
 		// do not use in untrusted setting
 		params.SetNoProofMod()
 		// do not use in untrusted setting
@@ -243,7 +256,7 @@ keygen:
 			// .. here comes a workaround to recover this party's index (it was removed from save data)
 			index, err := save.OriginalIndex()
 			assert.NoErrorf(t, err, "should not be an error getting a party's index from save data")
-			tryWriteTestFixtureFile(t, index, *save)
+			tryWriteTestFixtureFile(t, ec, index, *save)
 
 			atomic.AddInt32(&ended, 1)
 			if atomic.LoadInt32(&ended) == int32(len(pIDs)) {
@@ -263,17 +276,17 @@ keygen:
 						}
 						pShares = append(pShares, shareStruct)
 					}
-					uj, err := pShares[:threshold+1].ReConstruct(tss.S256())
+					uj, err := pShares[:threshold+1].ReConstruct(ec)
 					assert.NoError(t, err, "vss.ReConstruct should not throw error")
 
 					// uG test: u*G[j] == V[0]
 					assert.Equal(t, uj, Pj.temp.ui)
-					uG := crypto.ScalarBaseMult(tss.EC(), uj)
+					uG := crypto.ScalarBaseMult(ec, uj)
 					assert.True(t, uG.Equals(Pj.temp.vs[0]), "ensure u*G[j] == V_0")
 
 					// xj tests: BigXj == xj*G
 					xj := Pj.data.Xi
-					gXj := crypto.ScalarBaseMult(tss.EC(), xj)
+					gXj := crypto.ScalarBaseMult(ec, xj)
 					BigXj := Pj.data.BigXj[j]
 					assert.True(t, BigXj.Equals(gXj), "ensure BigX_j == g^x_j")
 
@@ -281,10 +294,10 @@ keygen:
 					{
 						badShares := pShares[:threshold]
 						badShares[len(badShares)-1].Share.Set(big.NewInt(0))
-						uj, err := pShares[:threshold].ReConstruct(tss.S256())
+						uj, err := pShares[:threshold].ReConstruct(ec)
 						assert.NoError(t, err)
 						assert.NotEqual(t, parties[j].temp.ui, uj)
-						BigXjX, BigXjY := tss.EC().ScalarBaseMult(uj.Bytes())
+						BigXjX, BigXjY := ec.ScalarBaseMult(uj.Bytes())
 						assert.NotEqual(t, BigXjX, Pj.temp.vs[0].X())
 						assert.NotEqual(t, BigXjY, Pj.temp.vs[0].Y())
 					}
@@ -292,9 +305,10 @@ keygen:
 				}
 
 				// build ecdsa key pair
+				u.Mod(u, ec.Params().N)
 				pkX, pkY := save.ECDSAPub.X(), save.ECDSAPub.Y()
 				pk := ecdsa.PublicKey{
-					Curve: tss.EC(),
+					Curve: ec,
 					X:     pkX,
 					Y:     pkY,
 				}
@@ -307,7 +321,7 @@ keygen:
 
 				// public key tests
 				assert.NotZero(t, u, "u should not be zero")
-				ourPkX, ourPkY := tss.EC().ScalarBaseMult(u.Bytes())
+				ourPkX, ourPkY := ec.ScalarBaseMult(u.Bytes())
 				assert.Equal(t, pkX, ourPkX, "pkX should match expected pk derived from u")
 				assert.Equal(t, pkY, ourPkY, "pkY should match expected pk derived from u")
 				t.Log("Public key tests done.")
@@ -319,6 +333,12 @@ keygen:
 				}
 				t.Log("Public key distribution test done.")
 
+				// make sure everyone has the same chain code
+				assert.Len(t, save.ChainCode, ChainCodeLength)
+				for _, Pj := range parties {
+					assert.Equal(t, save.ChainCode, Pj.data.ChainCode)
+				}
+
 				// test sign/verify
 				data := make([]byte, 32)
 				for i := range data {
@@ -338,8 +358,104 @@ keygen:
 	}
 }
 
-func tryWriteTestFixtureFile(t *testing.T, index int, data LocalPartySaveData) {
-	fixtureFileName := makeTestFixtureFilePath(index)
+func TestE2ESnapshotAndRestore(t *testing.T) {
+	setUp("info")
+
+	threshold := testThreshold
+	fixtures, pIDs, err := LoadKeygenTestFixtures(testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	p2pCtx := tss.NewPeerContext(pIDs)
+
+	params := make([]*tss.Parameters, 0, len(pIDs))
+	parties := make([]tss.Party, 0, len(pIDs))
+
+	// messages are routed synchronously below, so the channels must be able to hold everything that is produced
+	errCh := make(chan *tss.Error, len(pIDs)*len(pIDs)*4)
+	outCh := make(chan tss.Message, len(pIDs)*len(pIDs)*4)
+	endCh := make(chan *LocalPartySaveData, len(pIDs))
+
+	key := make([]byte, tss.SnapshotKeyLength)
+	_, err = rand.Read(key)
+	assert.NoError(t, err)
+
+	for i := 0; i < len(pIDs); i++ {
+		params = append(params, tss.NewParameters(tss.S256(), p2pCtx, pIDs[i], len(pIDs), threshold))
+		// do not use in untrusted setting
+		params[i].SetNoProofMod()
+		// do not use in untrusted setting
+		params[i].SetNoProofFac()
+		P := NewLocalParty(params[i], outCh, endCh, fixtures[i].LocalPreParams)
+		parties = append(parties, P)
+		if err := P.Start(); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+	}
+
+	// snapshot and restore every party regularly, so that the state of each round goes through a snapshot
+	routed := 0
+	saves := make([]*LocalPartySaveData, 0, len(pIDs))
+	for len(saves) < len(pIDs) {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case msg := <-outCh:
+			if dest := msg.GetTo(); dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index != msg.GetFrom().Index {
+						test.SharedPartyUpdater(P, msg, errCh)
+					}
+				}
+			} else {
+				test.SharedPartyUpdater(parties[dest[0].Index], msg, errCh)
+			}
+			if routed++; routed%3 != 0 {
+				continue
+			}
+			for i, P := range parties {
+				if !P.Running() {
+					continue // finished
+				}
+				snapshot, err := P.Snapshot(key)
+				if err != nil {
+					assert.FailNow(t, err.Error())
+				}
+				badKey := append([]byte{key[0] + 1}, key[1:]...)
+				_, err = RestoreLocalParty(params[i], snapshot, badKey, outCh, endCh)
+				assert.Error(t, err, "restoring with the wrong key should fail")
+
+				restored, err := RestoreLocalParty(params[i], snapshot, key, outCh, endCh)
+				if err != nil {
+					assert.FailNow(t, err.Error())
+				}
+				assert.Equal(t, P.WaitingFor(), restored.WaitingFor())
+				assert.Error(t, restored.Start(), "a restored party should not start again")
+				parties[i] = restored
+			}
+		case save := <-endCh:
+			saves = append(saves, save)
+		}
+	}
+
+	// make sure everyone has the same ECDSA public key, and that t+1 shares combine to it
+	shares := make(vss.Shares, 0, threshold+1)
+	for _, save := range saves {
+		assert.True(t, save.ECDSAPub.Equals(saves[0].ECDSAPub))
+		if len(shares) <= threshold {
+			shares = append(shares, &vss.Share{Threshold: threshold, ID: save.ShareID, Share: save.Xi})
+		}
+	}
+	u, err := shares.ReConstruct(tss.S256())
+	assert.NoError(t, err)
+	assert.True(t, crypto.ScalarBaseMult(tss.S256(), u).Equals(saves[0].ECDSAPub))
+}
+
+func tryWriteTestFixtureFile(t *testing.T, ec elliptic.Curve, index int, data LocalPartySaveData) {
+	fixtureFileName := makeTestFixtureFilePath(ec, index)
+	if err := os.MkdirAll(filepath.Dir(fixtureFileName), 0700); err != nil {
+		t.Fatalf("unable to create the fixture directory for %s", fixtureFileName)
+	}
 
 	// fixture file does not already exist?
 	// if it does, we won't re-create it here
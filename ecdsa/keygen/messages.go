@@ -37,6 +37,7 @@ var (
 func NewKGRound1Message(
 	from *tss.PartyID,
 	ct cmt.HashCommitment,
+	chainCodeCt cmt.HashCommitment,
 	paillierPK *paillier.PublicKey,
 	nTildeI, h1I, h2I *big.Int,
 	dlnProof1, dlnProof2 *dlnproof.Proof,
@@ -61,6 +62,8 @@ func NewKGRound1Message(
 		H2:         h2I.Bytes(),
 		Dlnproof_1: dlnProof1Bz,
 		Dlnproof_2: dlnProof2Bz,
+
+		ChainCodeCommitment: chainCodeCt.Bytes(),
 	}
 	msg := tss.NewMessageWrapper(meta, content)
 	return tss.NewMessage(meta, content, msg), nil
@@ -69,6 +72,7 @@ func NewKGRound1Message(
 func (m *KGRound1Message) ValidateBasic() bool {
 	return m != nil &&
 		common.NonEmptyBytes(m.GetCommitment()) &&
+		common.NonEmptyBytes(m.GetChainCodeCommitment()) &&
 		common.NonEmptyBytes(m.GetPaillierN()) &&
 		common.NonEmptyBytes(m.GetNTilde()) &&
 		common.NonEmptyBytes(m.GetH1()) &&
@@ -82,6 +86,10 @@ func (m *KGRound1Message) UnmarshalCommitment() *big.Int {
 	return new(big.Int).SetBytes(m.GetCommitment())
 }
 
+func (m *KGRound1Message) UnmarshalChainCodeCommitment() *big.Int {
+	return new(big.Int).SetBytes(m.GetChainCodeCommitment())
+}
+
 func (m *KGRound1Message) UnmarshalPaillierPK() *paillier.PublicKey {
 	return &paillier.PublicKey{N: new(big.Int).SetBytes(m.GetPaillierN())}
 }
@@ -147,6 +155,7 @@ func (m *KGRound2Message1) UnmarshalFacProof() (*facproof.ProofFac, error) {
 func NewKGRound2Message2(
 	from *tss.PartyID,
 	deCommitment cmt.HashDeCommitment,
+	chainCodeDeCommitment cmt.HashDeCommitment,
 	proof *modproof.ProofMod,
 ) tss.ParsedMessage {
 	meta := tss.MessageRouting{
@@ -156,8 +165,9 @@ func NewKGRound2Message2(
 	dcBzs := common.BigIntsToBytes(deCommitment)
 	proofBzs := proof.Bytes()
 	content := &KGRound2Message2{
-		DeCommitment: dcBzs,
-		ModProof:     proofBzs[:],
+		DeCommitment:          dcBzs,
+		ModProof:              proofBzs[:],
+		ChainCodeDeCommitment: common.BigIntsToBytes(chainCodeDeCommitment),
 	}
 	msg := tss.NewMessageWrapper(meta, content)
 	return tss.NewMessage(meta, content, msg)
@@ -165,7 +175,8 @@ func NewKGRound2Message2(
 
 func (m *KGRound2Message2) ValidateBasic() bool {
 	return m != nil &&
-		common.NonEmptyMultiBytes(m.GetDeCommitment())
+		common.NonEmptyMultiBytes(m.GetDeCommitment()) &&
+		common.NonEmptyMultiBytes(m.GetChainCodeDeCommitment(), 2)
 	// This is commented for backward compatibility, which msg has no proof
 	// && common.NonEmptyMultiBytes(m.GetModProof(), modproof.ProofModBytesParts)
 }
@@ -175,6 +186,10 @@ func (m *KGRound2Message2) UnmarshalDeCommitment() []*big.Int {
 	return cmt.NewHashDeCommitmentFromBytes(deComBzs)
 }
 
+func (m *KGRound2Message2) UnmarshalChainCodeDeCommitment() []*big.Int {
+	return cmt.NewHashDeCommitmentFromBytes(m.GetChainCodeDeCommitment())
+}
+
 func (m *KGRound2Message2) UnmarshalModProof() (*modproof.ProofMod, error) {
 	return modproof.NewProofFromBytes(m.GetModProof())
 }
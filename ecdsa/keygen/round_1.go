@@ -63,6 +63,9 @@ func (round *round1) Start() *tss.Error {
 	}
 	cmt := cmts.NewHashCommitment(pGFlat...)
 
+	// commit to this party's contribution to the chain code, which is revealed once all the commitments are received
+	chainCodeCmt := cmts.NewHashCommitment(common.MustGetRandomInt(ChainCodeLength * 8))
+
 	// 4. generate Paillier public key E_i, private key and proof
 	// 5-7. generate safe primes for ZKPs used later on
 	// 9-11. compute ntilde, h1, h2 (uses safe primes)
@@ -114,11 +117,13 @@ func (round *round1) Start() *tss.Error {
 	round.save.PaillierSK = preParams.PaillierSK
 	round.save.PaillierPKs[i] = &preParams.PaillierSK.PublicKey
 	round.temp.deCommitPolyG = cmt.D
+	round.temp.chainCodeCmts[i] = chainCodeCmt.C
+	round.temp.deCommitChainCode = chainCodeCmt.D
 
 	// BROADCAST commitments, paillier pk + proof; round 1 message
 	{
 		msg, err := NewKGRound1Message(
-			round.PartyID(), cmt.C, &preParams.PaillierSK.PublicKey, preParams.NTildei, preParams.H1i, preParams.H2i, dlnProof1, dlnProof2)
+			round.PartyID(), cmt.C, chainCodeCmt.C, &preParams.PaillierSK.PublicKey, preParams.NTildei, preParams.H1i, preParams.H2i, dlnProof1, dlnProof2)
 		if err != nil {
 			return round.WrapError(err, Pi)
 		}
@@ -109,6 +109,7 @@ func (round *round2) Start() *tss.Error {
 		round.save.NTildej[j] = NTildej
 		round.save.H1j[j], round.save.H2j[j] = H1j, H2j
 		round.temp.KGCs[j] = KGC
+		round.temp.chainCodeCmts[j] = r1msg.UnmarshalChainCodeCommitment()
 	}
 
 	// 5. p2p send share ij to Pj
@@ -146,7 +147,7 @@ func (round *round2) Start() *tss.Error {
 			return round.WrapError(err, round.PartyID())
 		}
 	}
-	r2msg2 := NewKGRound2Message2(round.PartyID(), round.temp.deCommitPolyG, modProof)
+	r2msg2 := NewKGRound2Message2(round.PartyID(), round.temp.deCommitPolyG, round.temp.deCommitChainCode, modProof)
 	round.temp.kgRound2Message2s[i] = r2msg2
 	round.out <- r2msg2
 
@@ -7,15 +7,23 @@
 package keygen
 
 import (
+	"crypto/elliptic"
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"math/big"
 
+	"github.com/hashicorp/go-multierror"
+
 	"github.com/bnb-chain/tss-lib/v2/crypto"
 	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 )
 
+// UnknownThreshold can be passed to LocalPartySaveData.Validate when the threshold of the keygen is not known
+const UnknownThreshold = -1
+
 type (
 	LocalPreParams struct {
 		PaillierSK *paillier.PrivateKey // ski
@@ -47,6 +55,9 @@ type (
 
 		// used for test assertions (may be discarded)
 		ECDSAPub *crypto.ECPoint // y
+
+		// chain code for HD key derivation, generated jointly by the parties
+		ChainCode []byte
 	}
 )
 
@@ -86,6 +97,7 @@ func BuildLocalSaveDataSubset(sourceData LocalPartySaveData, sortedIDs tss.Sorte
 	newData.LocalPreParams = sourceData.LocalPreParams
 	newData.LocalSecrets = sourceData.LocalSecrets
 	newData.ECDSAPub = sourceData.ECDSAPub
+	newData.ChainCode = sourceData.ChainCode
 	for j, id := range sortedIDs {
 		savedIdx, ok := keysToIndices[hex.EncodeToString(id.Key)]
 		if !ok {
@@ -100,3 +112,133 @@ func BuildLocalSaveDataSubset(sourceData LocalPartySaveData, sortedIDs tss.Sorte
 	}
 	return newData
 }
+
+// Validate checks that the save data is internally consistent, e.g. after loading it and before every signing session:
+// the party keys Ks are distinct and non-zero, there is one BigXj, Paillier key, NTilde, h1 and h2 per party and they
+// are well-formed, Xi matches the BigXj of this party, and the BigXj interpolate to ECDSAPub on the curve `ec`.
+// Unless `threshold` is UnknownThreshold, it also checks that every threshold+1 of the BigXj interpolate to
+// ECDSAPub. The returned error lists every problem that was found.
+// Save data adjusted for a child key with signing.UpdatePublicKeyAndAdjustBigXj does not pass, as Xi is not adjusted.
+func (save LocalPartySaveData) Validate(ec elliptic.Curve, threshold int) error {
+	if ec == nil {
+		return errors.New("the curve is missing")
+	}
+	partyCount := len(save.Ks)
+	if partyCount == 0 {
+		return errors.New("the save data has no party keys")
+	}
+	var errs error
+	fail := func(format string, args ...interface{}) {
+		errs = multierror.Append(errs, fmt.Errorf(format, args...))
+	}
+	if len(save.BigXj) != partyCount || len(save.PaillierPKs) != partyCount ||
+		len(save.NTildej) != partyCount || len(save.H1j) != partyCount || len(save.H2j) != partyCount {
+		return fmt.Errorf("the save data has %d party keys, %d BigXj, %d Paillier keys and %d/%d/%d NTilde/h1/h2",
+			partyCount, len(save.BigXj), len(save.PaillierPKs), len(save.NTildej), len(save.H1j), len(save.H2j))
+	}
+
+	// the shares of the key
+	ksOK, pointsOK := true, true
+	for j, kj := range save.Ks {
+		if kj == nil {
+			fail("the party key %d is missing", j)
+			ksOK = false
+		}
+	}
+	if ksOK {
+		if _, err := vss.CheckIndexes(ec, save.Ks); err != nil {
+			fail("the party keys are invalid: %v", err)
+			ksOK = false
+		}
+	}
+	if !isPointOnCurve(ec, save.ECDSAPub) {
+		fail("ECDSAPub is missing or not on the curve")
+		pointsOK = false
+	}
+	for j, bigXj := range save.BigXj {
+		if !isPointOnCurve(ec, bigXj) {
+			fail("BigXj[%d] is missing or not on the curve", j)
+			pointsOK = false
+		}
+	}
+	i := -1
+	if save.ShareID != nil && ksOK {
+		for j, kj := range save.Ks {
+			if kj.Cmp(save.ShareID) == 0 {
+				i = j
+			}
+		}
+	}
+	if i < 0 {
+		fail("ShareID is missing or not one of the party keys")
+	}
+	if save.Xi == nil || save.Xi.Sign() <= 0 || ec.Params().N.Cmp(save.Xi) <= 0 {
+		fail("Xi is missing or out of range")
+	} else if 0 <= i && pointsOK && !crypto.ScalarBaseMult(ec, save.Xi).Equals(save.BigXj[i]) {
+		fail("Xi does not match BigXj[%d]", i)
+	}
+	if ksOK && pointsOK {
+		if pub, err := vss.InterpolatePoint(ec, save.Ks, save.BigXj, big.NewInt(0)); err != nil || !pub.Equals(save.ECDSAPub) {
+			fail("the BigXj do not interpolate to ECDSAPub")
+		}
+		if t := threshold; t != UnknownThreshold {
+			if t < 0 || partyCount <= t {
+				fail("the threshold %d is invalid for %d parties", t, partyCount)
+			} else {
+				for j := t + 1; j < partyCount; j++ {
+					bigXj, err := vss.InterpolatePoint(ec, save.Ks[:t+1], save.BigXj[:t+1], save.Ks[j])
+					if err != nil || !bigXj.Equals(save.BigXj[j]) {
+						fail("BigXj[%d] is not on the polynomial of degree %d of the other BigXj", j, t)
+					}
+				}
+			}
+		}
+	}
+
+	// the Paillier keys and NTilde, h1, h2 for the range proofs
+	h1H2Map := make(map[string]struct{}, 2*partyCount)
+	for j := range save.Ks {
+		if pk := save.PaillierPKs[j]; pk == nil || pk.N == nil || pk.N.BitLen() != paillierBitsLen {
+			fail("the Paillier key %d is missing or does not have %d bits", j, paillierBitsLen)
+		}
+		NTildej, H1j, H2j := save.NTildej[j], save.H1j[j], save.H2j[j]
+		if NTildej == nil || NTildej.BitLen() != paillierBitsLen {
+			fail("NTildej[%d] is missing or does not have %d bits", j, paillierBitsLen)
+			continue
+		}
+		if !isRingPedersenParam(H1j, NTildej) || !isRingPedersenParam(H2j, NTildej) || H1j.Cmp(H2j) == 0 {
+			fail("h1j and h2j of party %d are invalid", j)
+			continue
+		}
+		for _, h := range []*big.Int{H1j, H2j} {
+			hHex := hex.EncodeToString(h.Bytes())
+			if _, found := h1H2Map[hHex]; found {
+				fail("h1j or h2j of party %d was already used by another party", j)
+			}
+			h1H2Map[hHex] = struct{}{}
+		}
+	}
+	if 0 <= i {
+		if save.PaillierSK == nil || save.PaillierPKs[i] == nil || save.PaillierSK.N.Cmp(save.PaillierPKs[i].N) != 0 {
+			fail("the Paillier private key does not match the Paillier key %d", i)
+		}
+		if save.NTildei == nil || save.H1i == nil || save.H2i == nil ||
+			save.NTildei.Cmp(save.NTildej[i]) != 0 || save.H1i.Cmp(save.H1j[i]) != 0 || save.H2i.Cmp(save.H2j[i]) != 0 {
+			fail("NTildei, h1i and h2i do not match the ones of party %d", i)
+		}
+	}
+	if len(save.ChainCode) != 0 && len(save.ChainCode) != ChainCodeLength {
+		fail("the chain code must be %d bytes long", ChainCodeLength)
+	}
+	return errs
+}
+
+func isPointOnCurve(ec elliptic.Curve, point *crypto.ECPoint) bool {
+	return point != nil && point.X() != nil && point.Y() != nil && ec.IsOnCurve(point.X(), point.Y())
+}
+
+// isRingPedersenParam reports whether h is in [2, NTilde) and coprime to NTilde
+func isRingPedersenParam(h, NTilde *big.Int) bool {
+	return h != nil && 0 < h.Cmp(big.NewInt(1)) && h.Cmp(NTilde) < 0 &&
+		new(big.Int).GCD(nil, nil, h, NTilde).Cmp(big.NewInt(1)) == 0
+}
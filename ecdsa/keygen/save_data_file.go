@@ -0,0 +1,178 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	// SaveDataVersion is the version of the save data format written by Marshal(). Files of older versions are
+	// migrated by UnmarshalSaveData.
+	SaveDataVersion = 1
+
+	saveDataProtocol = "ecdsa"
+)
+
+// Marshal serializes the save data into a versioned tss.SaveDataFile that records the curve, the `threshold` of the
+// keygen and the party keys, and carries a checksum. Load it with UnmarshalSaveData.
+func (save LocalPartySaveData) Marshal(threshold int) ([]byte, error) {
+	if save.ECDSAPub == nil {
+		return nil, errors.New("the save data has no public key")
+	}
+	bigXjFlat, err := crypto.FlattenECPoints(save.BigXj)
+	if err != nil {
+		return nil, err
+	}
+	paillierNs := make([]*big.Int, len(save.PaillierPKs))
+	for j, pk := range save.PaillierPKs {
+		if pk != nil {
+			paillierNs[j] = pk.N
+		}
+	}
+	data := &SaveData{
+		NtildeI:     intToBytes(save.NTildei),
+		H1I:         intToBytes(save.H1i),
+		H2I:         intToBytes(save.H2i),
+		Alpha:       intToBytes(save.Alpha),
+		Beta:        intToBytes(save.Beta),
+		P:           intToBytes(save.P),
+		Q:           intToBytes(save.Q),
+		Xi:          intToBytes(save.Xi),
+		ShareId:     intToBytes(save.ShareID),
+		NtildeJ:     intsToBytes(save.NTildej),
+		H1J:         intsToBytes(save.H1j),
+		H2J:         intsToBytes(save.H2j),
+		BigXj:       intsToBytes(bigXjFlat),
+		PaillierPks: intsToBytes(paillierNs),
+		EcdsaPub:    intsToBytes([]*big.Int{save.ECDSAPub.X(), save.ECDSAPub.Y()}),
+		ChainCode:   save.ChainCode,
+	}
+	if sk := save.PaillierSK; sk != nil {
+		data.PaillierN = intToBytes(sk.N)
+		data.PaillierLambdaN = intToBytes(sk.LambdaN)
+		data.PaillierPhiN = intToBytes(sk.PhiN)
+		data.PaillierP = intToBytes(sk.P)
+		data.PaillierQ = intToBytes(sk.Q)
+	}
+	dataBz, err := proto.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return tss.MarshalSaveDataFile(SaveDataVersion, saveDataProtocol, save.ECDSAPub.Curve(), threshold, save.Ks, dataBz)
+}
+
+// UnmarshalSaveData loads save data written by Marshal() and returns it with the threshold of the keygen.
+// It fails when the file is corrupted, belongs to another protocol or was written by a newer version of tss-lib.
+func UnmarshalSaveData(bz []byte) (LocalPartySaveData, int, error) {
+	file, err := tss.UnmarshalSaveDataFile(bz, saveDataProtocol)
+	if err != nil {
+		return LocalPartySaveData{}, 0, err
+	}
+	// migrations of older versions go here
+	switch file.GetVersion() {
+	case SaveDataVersion:
+	default:
+		return LocalPartySaveData{}, 0, fmt.Errorf("the save data version %d is not supported; it might have been written by a newer version of tss-lib", file.GetVersion())
+	}
+	data := new(SaveData)
+	if err = proto.Unmarshal(file.GetData(), data); err != nil {
+		return LocalPartySaveData{}, 0, fmt.Errorf("the save data could not be parsed: %v", err)
+	}
+	ks := file.UnmarshalKs()
+	if len(data.GetNtildeJ()) != len(ks) || len(data.GetH1J()) != len(ks) || len(data.GetH2J()) != len(ks) ||
+		len(data.GetBigXj()) != 2*len(ks) || len(data.GetPaillierPks()) != len(ks) || len(data.GetEcdsaPub()) != 2 {
+		return LocalPartySaveData{}, 0, errors.New("the save data does not match the number of parties")
+	}
+
+	ec := file.EC()
+	save := NewLocalPartySaveData(len(ks))
+	save.Ks = ks
+	save.NTildei = bytesToInt(data.GetNtildeI())
+	save.H1i, save.H2i = bytesToInt(data.GetH1I()), bytesToInt(data.GetH2I())
+	save.Alpha, save.Beta = bytesToInt(data.GetAlpha()), bytesToInt(data.GetBeta())
+	save.P, save.Q = bytesToInt(data.GetP()), bytesToInt(data.GetQ())
+	save.Xi, save.ShareID = bytesToInt(data.GetXi()), bytesToInt(data.GetShareId())
+	if n := bytesToInt(data.GetPaillierN()); n != nil {
+		save.PaillierSK = &paillier.PrivateKey{
+			PublicKey: paillier.PublicKey{N: n},
+			LambdaN:   bytesToInt(data.GetPaillierLambdaN()),
+			PhiN:      bytesToInt(data.GetPaillierPhiN()),
+			P:         bytesToInt(data.GetPaillierP()),
+			Q:         bytesToInt(data.GetPaillierQ()),
+		}
+	}
+	save.NTildej = bytesToInts(data.GetNtildeJ())
+	save.H1j, save.H2j = bytesToInts(data.GetH1J()), bytesToInts(data.GetH2J())
+	for j, n := range bytesToInts(data.GetPaillierPks()) {
+		if n != nil {
+			save.PaillierPKs[j] = &paillier.PublicKey{N: n}
+		}
+	}
+	if save.BigXj, err = crypto.UnFlattenECPoints(ec, bytesToInts(data.GetBigXj())); err != nil {
+		return LocalPartySaveData{}, 0, err
+	}
+	pub := bytesToInts(data.GetEcdsaPub())
+	if save.ECDSAPub, err = crypto.NewECPoint(ec, pub[0], pub[1]); err != nil {
+		return LocalPartySaveData{}, 0, err
+	}
+	if len(data.GetChainCode()) != 0 {
+		save.ChainCode = data.GetChainCode()
+	}
+	return save, int(file.GetThreshold()), nil
+}
+
+// MigrateJSONSaveData converts save data that was stored as JSON by earlier versions of tss-lib, such as the test
+// fixtures, into the format written by Marshal(). The JSON format does not record the `threshold` of the keygen.
+func MigrateJSONSaveData(jsonBz []byte, threshold int) ([]byte, error) {
+	var save LocalPartySaveData
+	if err := json.Unmarshal(jsonBz, &save); err != nil {
+		return nil, fmt.Errorf("the JSON save data could not be parsed: %v", err)
+	}
+	return save.Marshal(threshold)
+}
+
+// ----- //
+
+func intToBytes(x *big.Int) []byte {
+	if x == nil {
+		return nil
+	}
+	return x.Bytes()
+}
+
+func intsToBytes(xs []*big.Int) [][]byte {
+	bzs := make([][]byte, len(xs))
+	for i, x := range xs {
+		bzs[i] = intToBytes(x)
+	}
+	return bzs
+}
+
+func bytesToInt(bz []byte) *big.Int {
+	if len(bz) == 0 {
+		return nil
+	}
+	return new(big.Int).SetBytes(bz)
+}
+
+func bytesToInts(bzs [][]byte) []*big.Int {
+	xs := make([]*big.Int, len(bzs))
+	for i, bz := range bzs {
+		xs[i] = bytesToInt(bz)
+	}
+	return xs
+}
@@ -7,6 +7,7 @@
 package keygen
 
 import (
+	"crypto/elliptic"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -29,18 +30,23 @@ const (
 	TestThreshold    = test.TestParticipants / 2
 )
 const (
-	testFixtureDirFormat  = "%s/../../test/_ecdsa_fixtures"
+	testFixtureDirFormat  = "%s/../../test/_ecdsa_fixtures%s"
 	testFixtureFileFormat = "keygen_data_%d.json"
 )
 
 func LoadKeygenTestFixtures(qty int, optionalStart ...int) ([]LocalPartySaveData, tss.SortedPartyIDs, error) {
+	return LoadKeygenTestFixturesForCurve(tss.S256(), qty, optionalStart...)
+}
+
+// LoadKeygenTestFixturesForCurve loads the fixtures of the keys on `ec`, e.g. tss.P256()
+func LoadKeygenTestFixturesForCurve(ec elliptic.Curve, qty int, optionalStart ...int) ([]LocalPartySaveData, tss.SortedPartyIDs, error) {
 	keys := make([]LocalPartySaveData, 0, qty)
 	start := 0
 	if 0 < len(optionalStart) {
 		start = optionalStart[0]
 	}
 	for i := start; i < qty; i++ {
-		fixtureFilePath := makeTestFixtureFilePath(i)
+		fixtureFilePath := makeTestFixtureFilePath(ec, i)
 		bz, err := ioutil.ReadFile(fixtureFilePath)
 		if err != nil {
 			return nil, nil, errors.Wrapf(err,
@@ -54,9 +60,9 @@ func LoadKeygenTestFixtures(qty int, optionalStart ...int) ([]LocalPartySaveData
 				i, fixtureFilePath)
 		}
 		for _, kbxj := range key.BigXj {
-			kbxj.SetCurve(tss.S256())
+			kbxj.SetCurve(ec)
 		}
-		key.ECDSAPub.SetCurve(tss.S256())
+		key.ECDSAPub.SetCurve(ec)
 		keys = append(keys, key)
 	}
 	partyIDs := make(tss.UnSortedPartyIDs, len(keys))
@@ -69,6 +75,11 @@ func LoadKeygenTestFixtures(qty int, optionalStart ...int) ([]LocalPartySaveData
 }
 
 func LoadKeygenTestFixturesRandomSet(qty, fixtureCount int) ([]LocalPartySaveData, tss.SortedPartyIDs, error) {
+	return LoadKeygenTestFixturesRandomSetForCurve(tss.S256(), qty, fixtureCount)
+}
+
+// LoadKeygenTestFixturesRandomSetForCurve loads a random set of the fixtures of the keys on `ec`, e.g. tss.P256()
+func LoadKeygenTestFixturesRandomSetForCurve(ec elliptic.Curve, qty, fixtureCount int) ([]LocalPartySaveData, tss.SortedPartyIDs, error) {
 	keys := make([]LocalPartySaveData, 0, qty)
 	plucked := make(map[int]interface{}, qty)
 	for i := 0; len(plucked) < qty; i = (i + 1) % fixtureCount {
@@ -78,7 +89,7 @@ func LoadKeygenTestFixturesRandomSet(qty, fixtureCount int) ([]LocalPartySaveDat
 		}
 	}
 	for i := range plucked {
-		fixtureFilePath := makeTestFixtureFilePath(i)
+		fixtureFilePath := makeTestFixtureFilePath(ec, i)
 		bz, err := ioutil.ReadFile(fixtureFilePath)
 		if err != nil {
 			return nil, nil, errors.Wrapf(err,
@@ -92,9 +103,9 @@ func LoadKeygenTestFixturesRandomSet(qty, fixtureCount int) ([]LocalPartySaveDat
 				i, fixtureFilePath)
 		}
 		for _, kbxj := range key.BigXj {
-			kbxj.SetCurve(tss.S256())
+			kbxj.SetCurve(ec)
 		}
-		key.ECDSAPub.SetCurve(tss.S256())
+		key.ECDSAPub.SetCurve(ec)
 		keys = append(keys, key)
 	}
 	partyIDs := make(tss.UnSortedPartyIDs, len(keys))
@@ -120,9 +131,14 @@ func LoadNTildeH1H2FromTestFixture(idx int) (NTildei, h1i, h2i *big.Int, err err
 	return
 }
 
-func makeTestFixtureFilePath(partyIndex int) string {
+// the fixtures of secp256k1 are in test/_ecdsa_fixtures and those of other curves in test/_ecdsa_fixtures_<curve name>
+func makeTestFixtureFilePath(ec elliptic.Curve, partyIndex int) string {
 	_, callerFileName, _, _ := runtime.Caller(0)
 	srcDirName := filepath.Dir(callerFileName)
-	fixtureDirName := fmt.Sprintf(testFixtureDirFormat, srcDirName)
+	suffix := ""
+	if name, ok := tss.GetCurveName(ec); ok && name != tss.Secp256k1 {
+		suffix = "_" + string(name)
+	}
+	fixtureDirName := fmt.Sprintf(testFixtureDirFormat, srcDirName, suffix)
 	return fmt.Sprintf("%s/"+testFixtureFileFormat, fixtureDirName, partyIndex)
 }
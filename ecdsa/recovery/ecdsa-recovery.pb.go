@@ -0,0 +1,546 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v3.20.3
+// source: protob/ecdsa-recovery.proto
+
+package recovery
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Represents a P2P message sent by a helper to each other helper during Round 1 of the ECDSA TSS share recovery protocol.
+type RCRound1Message1 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Piece []byte `protobuf:"bytes,1,opt,name=piece,proto3" json:"piece,omitempty"`
+}
+
+func (x *RCRound1Message1) Reset() {
+	*x = RCRound1Message1{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_recovery_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RCRound1Message1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RCRound1Message1) ProtoMessage() {}
+
+func (x *RCRound1Message1) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_recovery_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RCRound1Message1.ProtoReflect.Descriptor instead.
+func (*RCRound1Message1) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_recovery_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RCRound1Message1) GetPiece() []byte {
+	if x != nil {
+		return x.Piece
+	}
+	return nil
+}
+
+// Represents a BROADCAST message sent by each helper during Round 1 of the ECDSA TSS share recovery protocol.
+// It carries the commitments to the pieces and the public key data for the recovering party.
+type RCRound1Message2 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PieceCommitments [][]byte `protobuf:"bytes,1,rep,name=piece_commitments,json=pieceCommitments,proto3" json:"piece_commitments,omitempty"`
+	Ks               [][]byte `protobuf:"bytes,2,rep,name=ks,proto3" json:"ks,omitempty"`
+	BigXj            [][]byte `protobuf:"bytes,3,rep,name=big_xj,json=bigXj,proto3" json:"big_xj,omitempty"`
+	EcdsaPub         [][]byte `protobuf:"bytes,4,rep,name=ecdsa_pub,json=ecdsaPub,proto3" json:"ecdsa_pub,omitempty"`
+	ChainCode        []byte   `protobuf:"bytes,5,opt,name=chain_code,json=chainCode,proto3" json:"chain_code,omitempty"`
+	NtildeJ          [][]byte `protobuf:"bytes,6,rep,name=ntilde_j,json=ntildeJ,proto3" json:"ntilde_j,omitempty"`
+	H1J              [][]byte `protobuf:"bytes,7,rep,name=h1_j,json=h1J,proto3" json:"h1_j,omitempty"`
+	H2J              [][]byte `protobuf:"bytes,8,rep,name=h2_j,json=h2J,proto3" json:"h2_j,omitempty"`
+	PaillierN        [][]byte `protobuf:"bytes,9,rep,name=paillier_n,json=paillierN,proto3" json:"paillier_n,omitempty"`
+}
+
+func (x *RCRound1Message2) Reset() {
+	*x = RCRound1Message2{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_recovery_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RCRound1Message2) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RCRound1Message2) ProtoMessage() {}
+
+func (x *RCRound1Message2) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_recovery_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RCRound1Message2.ProtoReflect.Descriptor instead.
+func (*RCRound1Message2) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_recovery_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RCRound1Message2) GetPieceCommitments() [][]byte {
+	if x != nil {
+		return x.PieceCommitments
+	}
+	return nil
+}
+
+func (x *RCRound1Message2) GetKs() [][]byte {
+	if x != nil {
+		return x.Ks
+	}
+	return nil
+}
+
+func (x *RCRound1Message2) GetBigXj() [][]byte {
+	if x != nil {
+		return x.BigXj
+	}
+	return nil
+}
+
+func (x *RCRound1Message2) GetEcdsaPub() [][]byte {
+	if x != nil {
+		return x.EcdsaPub
+	}
+	return nil
+}
+
+func (x *RCRound1Message2) GetChainCode() []byte {
+	if x != nil {
+		return x.ChainCode
+	}
+	return nil
+}
+
+func (x *RCRound1Message2) GetNtildeJ() [][]byte {
+	if x != nil {
+		return x.NtildeJ
+	}
+	return nil
+}
+
+func (x *RCRound1Message2) GetH1J() [][]byte {
+	if x != nil {
+		return x.H1J
+	}
+	return nil
+}
+
+func (x *RCRound1Message2) GetH2J() [][]byte {
+	if x != nil {
+		return x.H2J
+	}
+	return nil
+}
+
+func (x *RCRound1Message2) GetPaillierN() [][]byte {
+	if x != nil {
+		return x.PaillierN
+	}
+	return nil
+}
+
+// Represents a P2P message sent by each helper to the recovering party during Round 2 of the ECDSA TSS share recovery protocol.
+type RCRound2Message struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PieceSum []byte `protobuf:"bytes,1,opt,name=piece_sum,json=pieceSum,proto3" json:"piece_sum,omitempty"`
+}
+
+func (x *RCRound2Message) Reset() {
+	*x = RCRound2Message{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_recovery_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RCRound2Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RCRound2Message) ProtoMessage() {}
+
+func (x *RCRound2Message) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_recovery_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RCRound2Message.ProtoReflect.Descriptor instead.
+func (*RCRound2Message) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_recovery_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RCRound2Message) GetPieceSum() []byte {
+	if x != nil {
+		return x.PieceSum
+	}
+	return nil
+}
+
+// Represents a BROADCAST message sent by the recovering party during Round 2 of the ECDSA TSS share recovery protocol.
+// It carries the new Paillier key and NTilde, h1, h2 of the recovering party with the proofs that they are well formed.
+type RCPreParamsMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PaillierN  []byte   `protobuf:"bytes,1,opt,name=paillier_n,json=paillierN,proto3" json:"paillier_n,omitempty"`
+	NTilde     []byte   `protobuf:"bytes,2,opt,name=n_tilde,json=nTilde,proto3" json:"n_tilde,omitempty"`
+	H1         []byte   `protobuf:"bytes,3,opt,name=h1,proto3" json:"h1,omitempty"`
+	H2         []byte   `protobuf:"bytes,4,opt,name=h2,proto3" json:"h2,omitempty"`
+	Dlnproof_1 [][]byte `protobuf:"bytes,5,rep,name=dlnproof_1,json=dlnproof1,proto3" json:"dlnproof_1,omitempty"`
+	Dlnproof_2 [][]byte `protobuf:"bytes,6,rep,name=dlnproof_2,json=dlnproof2,proto3" json:"dlnproof_2,omitempty"`
+	ModProof   [][]byte `protobuf:"bytes,7,rep,name=mod_proof,json=modProof,proto3" json:"mod_proof,omitempty"`
+}
+
+func (x *RCPreParamsMessage) Reset() {
+	*x = RCPreParamsMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_recovery_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RCPreParamsMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RCPreParamsMessage) ProtoMessage() {}
+
+func (x *RCPreParamsMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_recovery_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RCPreParamsMessage.ProtoReflect.Descriptor instead.
+func (*RCPreParamsMessage) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_recovery_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RCPreParamsMessage) GetPaillierN() []byte {
+	if x != nil {
+		return x.PaillierN
+	}
+	return nil
+}
+
+func (x *RCPreParamsMessage) GetNTilde() []byte {
+	if x != nil {
+		return x.NTilde
+	}
+	return nil
+}
+
+func (x *RCPreParamsMessage) GetH1() []byte {
+	if x != nil {
+		return x.H1
+	}
+	return nil
+}
+
+func (x *RCPreParamsMessage) GetH2() []byte {
+	if x != nil {
+		return x.H2
+	}
+	return nil
+}
+
+func (x *RCPreParamsMessage) GetDlnproof_1() [][]byte {
+	if x != nil {
+		return x.Dlnproof_1
+	}
+	return nil
+}
+
+func (x *RCPreParamsMessage) GetDlnproof_2() [][]byte {
+	if x != nil {
+		return x.Dlnproof_2
+	}
+	return nil
+}
+
+func (x *RCPreParamsMessage) GetModProof() [][]byte {
+	if x != nil {
+		return x.ModProof
+	}
+	return nil
+}
+
+// Represents a P2P message sent by the recovering party to each helper during Round 2 of the ECDSA TSS share recovery
+// protocol. It proves that the new Paillier modulus has no small factors, using the NTilde, h1, h2 of the helper.
+type RCFacProofMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FacProof [][]byte `protobuf:"bytes,1,rep,name=fac_proof,json=facProof,proto3" json:"fac_proof,omitempty"`
+}
+
+func (x *RCFacProofMessage) Reset() {
+	*x = RCFacProofMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_recovery_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RCFacProofMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RCFacProofMessage) ProtoMessage() {}
+
+func (x *RCFacProofMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_recovery_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RCFacProofMessage.ProtoReflect.Descriptor instead.
+func (*RCFacProofMessage) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_recovery_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RCFacProofMessage) GetFacProof() [][]byte {
+	if x != nil {
+		return x.FacProof
+	}
+	return nil
+}
+
+var File_protob_ecdsa_recovery_proto protoreflect.FileDescriptor
+
+var file_protob_ecdsa_recovery_proto_rawDesc = []byte{
+	0x0a, 0x1b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x2f, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2d, 0x72,
+	0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x1d, 0x62,
+	0x69, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x74, 0x73, 0x73, 0x6c, 0x69, 0x62, 0x2e, 0x65, 0x63,
+	0x64, 0x73, 0x61, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x22, 0x28, 0x0a, 0x10,
+	0x52, 0x43, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x31, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x31,
+	0x12, 0x14, 0x0a, 0x05, 0x70, 0x69, 0x65, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x05, 0x70, 0x69, 0x65, 0x63, 0x65, 0x22, 0x82, 0x02, 0x0a, 0x10, 0x52, 0x43, 0x52, 0x6f, 0x75,
+	0x6e, 0x64, 0x31, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x32, 0x12, 0x2b, 0x0a, 0x11, 0x70,
+	0x69, 0x65, 0x63, 0x65, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x10, 0x70, 0x69, 0x65, 0x63, 0x65, 0x43, 0x6f, 0x6d,
+	0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x6b, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0c, 0x52, 0x02, 0x6b, 0x73, 0x12, 0x15, 0x0a, 0x06, 0x62, 0x69, 0x67, 0x5f,
+	0x78, 0x6a, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x05, 0x62, 0x69, 0x67, 0x58, 0x6a, 0x12,
+	0x1b, 0x0a, 0x09, 0x65, 0x63, 0x64, 0x73, 0x61, 0x5f, 0x70, 0x75, 0x62, 0x18, 0x04, 0x20, 0x03,
+	0x28, 0x0c, 0x52, 0x08, 0x65, 0x63, 0x64, 0x73, 0x61, 0x50, 0x75, 0x62, 0x12, 0x1d, 0x0a, 0x0a,
+	0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x09, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6e,
+	0x74, 0x69, 0x6c, 0x64, 0x65, 0x5f, 0x6a, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x07, 0x6e,
+	0x74, 0x69, 0x6c, 0x64, 0x65, 0x4a, 0x12, 0x11, 0x0a, 0x04, 0x68, 0x31, 0x5f, 0x6a, 0x18, 0x07,
+	0x20, 0x03, 0x28, 0x0c, 0x52, 0x03, 0x68, 0x31, 0x4a, 0x12, 0x11, 0x0a, 0x04, 0x68, 0x32, 0x5f,
+	0x6a, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x03, 0x68, 0x32, 0x4a, 0x12, 0x1d, 0x0a, 0x0a,
+	0x70, 0x61, 0x69, 0x6c, 0x6c, 0x69, 0x65, 0x72, 0x5f, 0x6e, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0c,
+	0x52, 0x09, 0x70, 0x61, 0x69, 0x6c, 0x6c, 0x69, 0x65, 0x72, 0x4e, 0x22, 0x2e, 0x0a, 0x0f, 0x52,
+	0x43, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x32, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1b,
+	0x0a, 0x09, 0x70, 0x69, 0x65, 0x63, 0x65, 0x5f, 0x73, 0x75, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x08, 0x70, 0x69, 0x65, 0x63, 0x65, 0x53, 0x75, 0x6d, 0x22, 0xc7, 0x01, 0x0a, 0x12,
+	0x52, 0x43, 0x50, 0x72, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x69, 0x6c, 0x6c, 0x69, 0x65, 0x72, 0x5f, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x70, 0x61, 0x69, 0x6c, 0x6c, 0x69, 0x65, 0x72,
+	0x4e, 0x12, 0x17, 0x0a, 0x07, 0x6e, 0x5f, 0x74, 0x69, 0x6c, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x06, 0x6e, 0x54, 0x69, 0x6c, 0x64, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x68, 0x31,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x68, 0x31, 0x12, 0x0e, 0x0a, 0x02, 0x68, 0x32,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x68, 0x32, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x6c,
+	0x6e, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x31, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x09,
+	0x64, 0x6c, 0x6e, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x31, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x6c, 0x6e,
+	0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x32, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x09, 0x64,
+	0x6c, 0x6e, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x32, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x6f, 0x64, 0x5f,
+	0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x08, 0x6d, 0x6f, 0x64,
+	0x50, 0x72, 0x6f, 0x6f, 0x66, 0x22, 0x30, 0x0a, 0x11, 0x52, 0x43, 0x46, 0x61, 0x63, 0x50, 0x72,
+	0x6f, 0x6f, 0x66, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x61,
+	0x63, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x08, 0x66,
+	0x61, 0x63, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x42, 0x10, 0x5a, 0x0e, 0x65, 0x63, 0x64, 0x73, 0x61,
+	0x2f, 0x72, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_protob_ecdsa_recovery_proto_rawDescOnce sync.Once
+	file_protob_ecdsa_recovery_proto_rawDescData = file_protob_ecdsa_recovery_proto_rawDesc
+)
+
+func file_protob_ecdsa_recovery_proto_rawDescGZIP() []byte {
+	file_protob_ecdsa_recovery_proto_rawDescOnce.Do(func() {
+		file_protob_ecdsa_recovery_proto_rawDescData = protoimpl.X.CompressGZIP(file_protob_ecdsa_recovery_proto_rawDescData)
+	})
+	return file_protob_ecdsa_recovery_proto_rawDescData
+}
+
+var file_protob_ecdsa_recovery_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_protob_ecdsa_recovery_proto_goTypes = []interface{}{
+	(*RCRound1Message1)(nil),   // 0: binance.tsslib.ecdsa.recovery.RCRound1Message1
+	(*RCRound1Message2)(nil),   // 1: binance.tsslib.ecdsa.recovery.RCRound1Message2
+	(*RCRound2Message)(nil),    // 2: binance.tsslib.ecdsa.recovery.RCRound2Message
+	(*RCPreParamsMessage)(nil), // 3: binance.tsslib.ecdsa.recovery.RCPreParamsMessage
+	(*RCFacProofMessage)(nil),  // 4: binance.tsslib.ecdsa.recovery.RCFacProofMessage
+}
+var file_protob_ecdsa_recovery_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_protob_ecdsa_recovery_proto_init() }
+func file_protob_ecdsa_recovery_proto_init() {
+	if File_protob_ecdsa_recovery_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_protob_ecdsa_recovery_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RCRound1Message1); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_recovery_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RCRound1Message2); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_recovery_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RCRound2Message); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_recovery_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RCPreParamsMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_recovery_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RCFacProofMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_protob_ecdsa_recovery_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_protob_ecdsa_recovery_proto_goTypes,
+		DependencyIndexes: file_protob_ecdsa_recovery_proto_depIdxs,
+		MessageInfos:      file_protob_ecdsa_recovery_proto_msgTypes,
+	}.Build()
+	File_protob_ecdsa_recovery_proto = out.File
+	file_protob_ecdsa_recovery_proto_rawDesc = nil
+	file_protob_ecdsa_recovery_proto_goTypes = nil
+	file_protob_ecdsa_recovery_proto_depIdxs = nil
+}
@@ -0,0 +1,190 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package recovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// Implements Party
+// Implements Stringer
+var _ tss.Party = (*LocalParty)(nil)
+var _ fmt.Stringer = (*LocalParty)(nil)
+
+type (
+	LocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		key  keygen.LocalPartySaveData
+		lost *tss.PartyID
+		temp localTempData
+		data keygen.LocalPartySaveData
+
+		// outbound messaging
+		out chan<- tss.Message
+		end chan<- *keygen.LocalPartySaveData
+	}
+
+	localMessageStore struct {
+		rcRound1Message1s,
+		rcRound1Message2s,
+		rcRound2Messages,
+		rcPreParamsMessages,
+		rcFacProofMessages []tss.ParsedMessage
+	}
+
+	localTempData struct {
+		localMessageStore
+	}
+)
+
+// NewHelperParty creates a party that helps the party `lost` to recover the share it has lost. `params` must contain
+// the lost party and at least threshold+1 other parties of the keygen, which each run a helper party with their `key`.
+// The helpers only learn random pieces of the lost share. A helper verifies the new Paillier key and NTilde, h1, h2 of
+// the lost party and sends its `key`, updated with them, to `end` when it is done.
+// Exported, used in `tss` client
+func NewHelperParty(
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	lost *tss.PartyID,
+	out chan<- tss.Message,
+	end chan<- *keygen.LocalPartySaveData,
+) tss.Party {
+	p := newLocalParty(params, lost, out, end)
+	p.key = key
+	return p
+}
+
+// NewRecoveringParty creates the party that recovers its lost share with the help of the other parties in `params`.
+// The recovered save data, with the share Xi at the original ShareID, is sent to `end`.
+// The lost Paillier key, NTilde, h1 and h2 of this party cannot be recovered, so the save data holds new ones, taken
+// from `optionalPreParams` or generated like in keygen. They are sent to the helpers with the proofs that they are well
+// formed, and the save data output by the helpers holds them, so the recovered party can sign with the helpers.
+// A party of the keygen that did not help still holds the old values; run refresh.NewLocalParty with it, the recovered
+// key and `recovered.LocalPreParams` before signing with it.
+// Exported, used in `tss` client
+func NewRecoveringParty(
+	params *tss.Parameters,
+	out chan<- tss.Message,
+	end chan<- *keygen.LocalPartySaveData,
+	optionalPreParams ...keygen.LocalPreParams,
+) tss.Party {
+	p := newLocalParty(params, params.PartyID(), out, end)
+	// when `optionalPreParams` is provided we'll use the pre-computed primes instead of generating them from scratch
+	if 0 < len(optionalPreParams) {
+		if 1 < len(optionalPreParams) {
+			panic(errors.New("recovery.NewRecoveringParty expected 0 or 1 item in `optionalPreParams`"))
+		}
+		if !optionalPreParams[0].ValidateWithProof() {
+			panic(errors.New("`optionalPreParams` failed to validate; it might have been generated with an older version of tss-lib"))
+		}
+		p.data.LocalPreParams = optionalPreParams[0]
+	}
+	return p
+}
+
+func newLocalParty(
+	params *tss.Parameters,
+	lost *tss.PartyID,
+	out chan<- tss.Message,
+	end chan<- *keygen.LocalPartySaveData,
+) *LocalParty {
+	partyCount := params.PartyCount()
+	p := &LocalParty{
+		BaseParty: new(tss.BaseParty),
+		params:    params,
+		lost:      lost,
+		temp:      localTempData{},
+		out:       out,
+		end:       end,
+	}
+	// msgs init
+	p.temp.rcRound1Message1s = make([]tss.ParsedMessage, partyCount)
+	p.temp.rcRound1Message2s = make([]tss.ParsedMessage, partyCount)
+	p.temp.rcRound2Messages = make([]tss.ParsedMessage, partyCount)
+	p.temp.rcPreParamsMessages = make([]tss.ParsedMessage, partyCount)
+	p.temp.rcFacProofMessages = make([]tss.ParsedMessage, partyCount)
+	return p
+}
+
+func (p *LocalParty) FirstRound() tss.Round {
+	return newRound1(p.params, &p.key, &p.data, &p.temp, p.lost, p.out, p.end)
+}
+
+func (p *LocalParty) Start() *tss.Error {
+	return tss.BaseStart(p, TaskName)
+}
+
+func (p *LocalParty) StartWithContext(ctx context.Context, errCh chan<- *tss.Error) *tss.Error {
+	return tss.BaseStartWithContext(ctx, p, TaskName, errCh)
+}
+
+func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(p, msg, TaskName)
+}
+
+func (p *LocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *LocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if ok, err := p.BaseParty.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	// check that the message's "from index" will fit into the array
+	if maxFromIdx := p.params.PartyCount() - 1; maxFromIdx < msg.GetFrom().Index {
+		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+			p.params.PartyCount(), msg.GetFrom().Index), msg.GetFrom())
+	}
+	return true, nil
+}
+
+func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	fromPIdx := msg.GetFrom().Index
+
+	// switch/case is necessary to store any messages beyond current round
+	// this does not handle message replays. we expect the caller to apply replay and spoofing protection.
+	switch msg.Content().(type) {
+	case *RCRound1Message1:
+		p.temp.rcRound1Message1s[fromPIdx] = msg
+	case *RCRound1Message2:
+		p.temp.rcRound1Message2s[fromPIdx] = msg
+	case *RCRound2Message:
+		p.temp.rcRound2Messages[fromPIdx] = msg
+	case *RCPreParamsMessage:
+		p.temp.rcPreParamsMessages[fromPIdx] = msg
+	case *RCFacProofMessage:
+		p.temp.rcFacProofMessages[fromPIdx] = msg
+	default: // unrecognised message, just ignore!
+		common.Logger.Warningf("unrecognised message ignored: %v", msg)
+		return false, nil
+	}
+	return true, nil
+}
+
+func (p *LocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *LocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
@@ -0,0 +1,212 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package recovery
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/signing"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	testThreshold = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+
+	// the party at index 0 lost its share and threshold+1 helpers recover it
+	keys, pIDs, err := keygen.LoadKeygenTestFixtures(testThreshold + 2)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	lost := pIDs[0]
+	// the lost party takes the pre-params of an unused fixture as its new Paillier key and NTilde, with new h1, h2 as the
+	// helpers already know the ones of the fixture
+	extra, _, err := keygen.LoadKeygenTestFixtures(testThreshold+3, testThreshold+2)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	preParams := newH1H2(extra[0].LocalPreParams)
+
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]*LocalParty, 0, len(pIDs))
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan *keygen.LocalPartySaveData, len(pIDs))
+
+	for i := 0; i < len(pIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, pIDs[i], len(pIDs), testThreshold)
+		var P *LocalParty
+		if i == lost.Index {
+			P = NewRecoveringParty(params, outCh, endCh, preParams).(*LocalParty)
+		} else {
+			P = NewHelperParty(params, keys[i], lost, outCh, endCh).(*LocalParty)
+		}
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	var recovered *keygen.LocalPartySaveData
+	saves := make([]keygen.LocalPartySaveData, len(pIDs))
+	for ended := 0; ended < len(pIDs); {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case msg := <-outCh:
+			if dest := msg.GetTo(); dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index != msg.GetFrom().Index {
+						go test.SharedPartyUpdater(P, msg, errCh)
+					}
+				}
+			} else {
+				go test.SharedPartyUpdater(parties[dest[0].Index], msg, errCh)
+			}
+		case save := <-endCh:
+			if save.ShareID.Cmp(lost.KeyInt()) == 0 {
+				recovered = save
+			}
+			saves[pIDs.FindByKey(save.ShareID).Index] = *save
+			ended++
+		}
+	}
+
+	if !assert.NotNil(t, recovered, "the lost party should recover its share") {
+		return
+	}
+	assert.Equal(t, keys[0].Xi, recovered.Xi, "the recovered share should be the lost one")
+	assert.Equal(t, keys[0].ShareID, recovered.ShareID)
+	assert.Equal(t, keys[0].Ks, recovered.Ks)
+	assert.Equal(t, keys[0].ChainCode, recovered.ChainCode)
+	assert.True(t, recovered.ECDSAPub.Equals(keys[0].ECDSAPub))
+	for j, bigXj := range recovered.BigXj {
+		assert.True(t, bigXj.Equals(keys[0].BigXj[j]))
+	}
+	assert.True(t, crypto.ScalarBaseMult(tss.S256(), recovered.Xi).Equals(keys[0].BigXj[0]))
+
+	// the lost Paillier key, NTilde, h1 and h2 are replaced with the new ones
+	assert.Equal(t, preParams.PaillierSK.N, recovered.PaillierSK.N)
+	assert.Equal(t, preParams.PaillierSK.N, recovered.PaillierPKs[0].N)
+	assert.Equal(t, preParams.NTildei, recovered.NTildej[0])
+	assert.Equal(t, preParams.H1i, recovered.H1j[0])
+	assert.Equal(t, preParams.H2i, recovered.H2j[0])
+	for j := 1; j < len(keys); j++ {
+		assert.Equal(t, keys[0].PaillierPKs[j].N, recovered.PaillierPKs[j].N)
+		assert.Equal(t, keys[0].NTildej[j], recovered.NTildej[j])
+	}
+
+	// the helpers replace them too, and keep their own keys otherwise
+	for i := 1; i < len(saves); i++ {
+		assert.Equal(t, keys[i].Xi, saves[i].Xi)
+		assert.Equal(t, preParams.PaillierSK.N, saves[i].PaillierPKs[0].N)
+		assert.Equal(t, preParams.NTildei, saves[i].NTildej[0])
+		assert.Equal(t, preParams.H1i, saves[i].H1j[0])
+		assert.Equal(t, preParams.H2i, saves[i].H2j[0])
+		assert.NotEqual(t, keys[i].NTildej[0], saves[i].NTildej[0])
+		assert.Equal(t, keys[i].NTildej[1:], saves[i].NTildej[1:])
+	}
+
+	// the recovered party can sign with the helpers
+	msg := big.NewInt(42)
+	data := runSigning(t, saves, pIDs, msg)
+	pk := ecdsa.PublicKey{
+		Curve: tss.EC(),
+		X:     keys[0].ECDSAPub.X(),
+		Y:     keys[0].ECDSAPub.Y(),
+	}
+	ok := ecdsa.Verify(&pk, msg.Bytes(), new(big.Int).SetBytes(data.R), new(big.Int).SetBytes(data.S))
+	assert.True(t, ok, "ecdsa verify must pass")
+}
+
+func TestTooFewHelpers(t *testing.T) {
+	setUp("info")
+
+	keys, pIDs, err := keygen.LoadKeygenTestFixtures(testThreshold + 1)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	params := tss.NewParameters(tss.S256(), tss.NewPeerContext(pIDs), pIDs[1], len(pIDs), testThreshold)
+	P := NewHelperParty(params, keys[1], pIDs[0], make(chan tss.Message, len(pIDs)), nil)
+	if err := P.Start(); assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "helpers are needed")
+	}
+}
+
+// newH1H2 replaces h1 and h2 of `preParams` with new ones for the same NTilde, like keygen.GeneratePreParams makes them
+func newH1H2(preParams keygen.LocalPreParams) keygen.LocalPreParams {
+	modNTilde := common.ModInt(preParams.NTildei)
+	modPQ := common.ModInt(new(big.Int).Mul(preParams.P, preParams.Q))
+	f1 := common.GetRandomPositiveRelativelyPrimeInt(preParams.NTildei)
+	preParams.Alpha = common.GetRandomPositiveRelativelyPrimeInt(preParams.NTildei)
+	preParams.Beta = modPQ.ModInverse(preParams.Alpha)
+	preParams.H1i = modNTilde.Mul(f1, f1)
+	preParams.H2i = modNTilde.Exp(preParams.H1i, preParams.Alpha)
+	return preParams
+}
+
+func runSigning(t *testing.T, keys []keygen.LocalPartySaveData, signPIDs tss.SortedPartyIDs, msg *big.Int) *common.SignatureData {
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]tss.Party, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		P := signing.NewLocalParty(msg, params, keys[i], outCh, endCh)
+		parties = append(parties, P)
+		go func(P tss.Party) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	var data *common.SignatureData
+	for ended := 0; ended < len(signPIDs); {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case msg := <-outCh:
+			if dest := msg.GetTo(); dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index != msg.GetFrom().Index {
+						go test.SharedPartyUpdater(P, msg, errCh)
+					}
+				}
+			} else {
+				go test.SharedPartyUpdater(parties[dest[0].Index], msg, errCh)
+			}
+		case data = <-endCh:
+			ended++
+		}
+	}
+	return data
+}
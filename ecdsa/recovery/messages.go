@@ -0,0 +1,312 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package recovery
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"errors"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/dlnproof"
+	"github.com/bnb-chain/tss-lib/v2/crypto/facproof"
+	"github.com/bnb-chain/tss-lib/v2/crypto/modproof"
+	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into ecdsa-recovery.pb.go
+// The following messages are registered on the Protocol Buffers "wire"
+
+var (
+	// Ensure that recovery messages implement ValidateBasic
+	_ = []tss.MessageContent{
+		(*RCRound1Message1)(nil),
+		(*RCRound1Message2)(nil),
+		(*RCRound2Message)(nil),
+		(*RCPreParamsMessage)(nil),
+		(*RCFacProofMessage)(nil),
+	}
+)
+
+// ----- //
+
+func NewRCRound1Message1(
+	to, from *tss.PartyID,
+	piece *big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	content := &RCRound1Message1{
+		Piece: piece.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *RCRound1Message1) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetPiece())
+}
+
+func (m *RCRound1Message1) UnmarshalPiece() *big.Int {
+	return new(big.Int).SetBytes(m.GetPiece())
+}
+
+// ----- //
+
+func NewRCRound1Message2(
+	from *tss.PartyID,
+	pieceCommitments []*crypto.ECPoint,
+	key *keygen.LocalPartySaveData,
+) (tss.ParsedMessage, error) {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	paillierNs := make([]*big.Int, len(key.PaillierPKs))
+	for j, pk := range key.PaillierPKs {
+		if pk == nil {
+			return nil, errors.New("the Paillier public keys are incomplete")
+		}
+		paillierNs[j] = pk.N
+	}
+	pieceCmtsFlat, err := crypto.FlattenECPoints(pieceCommitments)
+	if err != nil {
+		return nil, err
+	}
+	bigXjFlat, err := crypto.FlattenECPoints(key.BigXj)
+	if err != nil {
+		return nil, err
+	}
+	content := &RCRound1Message2{
+		PieceCommitments: common.BigIntsToBytes(pieceCmtsFlat),
+		Ks:               common.BigIntsToBytes(key.Ks),
+		BigXj:            common.BigIntsToBytes(bigXjFlat),
+		EcdsaPub:         common.BigIntsToBytes([]*big.Int{key.ECDSAPub.X(), key.ECDSAPub.Y()}),
+		ChainCode:        key.ChainCode,
+		NtildeJ:          common.BigIntsToBytes(key.NTildej),
+		H1J:              common.BigIntsToBytes(key.H1j),
+		H2J:              common.BigIntsToBytes(key.H2j),
+		PaillierN:        common.BigIntsToBytes(paillierNs),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg), nil
+}
+
+func (m *RCRound1Message2) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyMultiBytes(m.GetPieceCommitments()) &&
+		len(m.GetPieceCommitments())%2 == 0 &&
+		common.NonEmptyMultiBytes(m.GetKs()) &&
+		common.NonEmptyMultiBytes(m.GetBigXj(), 2*len(m.GetKs())) &&
+		common.NonEmptyMultiBytes(m.GetEcdsaPub(), 2) &&
+		common.NonEmptyMultiBytes(m.GetNtildeJ(), len(m.GetKs())) &&
+		common.NonEmptyMultiBytes(m.GetH1J(), len(m.GetKs())) &&
+		common.NonEmptyMultiBytes(m.GetH2J(), len(m.GetKs())) &&
+		common.NonEmptyMultiBytes(m.GetPaillierN(), len(m.GetKs()))
+}
+
+func (m *RCRound1Message2) UnmarshalPieceCommitments(ec elliptic.Curve) ([]*crypto.ECPoint, error) {
+	points, err := crypto.UnFlattenECPoints(ec, common.MultiBytesToBigInts(m.GetPieceCommitments()))
+	if err != nil {
+		return nil, err
+	}
+	return points, nil
+}
+
+// UnmarshalPublicData returns the public key data of the sender's save data
+func (m *RCRound1Message2) UnmarshalPublicData(ec elliptic.Curve) (keygen.LocalPartySaveData, error) {
+	ks := common.MultiBytesToBigInts(m.GetKs())
+	data := keygen.NewLocalPartySaveData(len(ks))
+	data.Ks = ks
+	bigXj, err := crypto.UnFlattenECPoints(ec, common.MultiBytesToBigInts(m.GetBigXj()))
+	if err != nil {
+		return data, err
+	}
+	data.BigXj = bigXj
+	pub := common.MultiBytesToBigInts(m.GetEcdsaPub())
+	if data.ECDSAPub, err = crypto.NewECPoint(ec, pub[0], pub[1]); err != nil {
+		return data, err
+	}
+	if len(m.GetChainCode()) != 0 && len(m.GetChainCode()) != keygen.ChainCodeLength {
+		return data, errors.New("the chain code has an invalid length")
+	}
+	data.ChainCode = m.GetChainCode()
+	data.NTildej = common.MultiBytesToBigInts(m.GetNtildeJ())
+	data.H1j = common.MultiBytesToBigInts(m.GetH1J())
+	data.H2j = common.MultiBytesToBigInts(m.GetH2J())
+	for j, n := range common.MultiBytesToBigInts(m.GetPaillierN()) {
+		data.PaillierPKs[j] = &paillier.PublicKey{N: n}
+	}
+	return data, nil
+}
+
+// samePublicData reports whether two helpers sent the same public key data
+func (m *RCRound1Message2) samePublicData(other *RCRound1Message2) bool {
+	return equalMultiBytes(m.GetKs(), other.GetKs()) &&
+		equalMultiBytes(m.GetBigXj(), other.GetBigXj()) &&
+		equalMultiBytes(m.GetEcdsaPub(), other.GetEcdsaPub()) &&
+		bytes.Equal(m.GetChainCode(), other.GetChainCode()) &&
+		equalMultiBytes(m.GetNtildeJ(), other.GetNtildeJ()) &&
+		equalMultiBytes(m.GetH1J(), other.GetH1J()) &&
+		equalMultiBytes(m.GetH2J(), other.GetH2J()) &&
+		equalMultiBytes(m.GetPaillierN(), other.GetPaillierN())
+}
+
+// ----- //
+
+func NewRCRound2Message(
+	to, from *tss.PartyID,
+	pieceSum *big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	content := &RCRound2Message{
+		PieceSum: pieceSum.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *RCRound2Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetPieceSum())
+}
+
+func (m *RCRound2Message) UnmarshalPieceSum() *big.Int {
+	return new(big.Int).SetBytes(m.GetPieceSum())
+}
+
+// ----- //
+
+// NewRCPreParamsMessage creates the message with the new Paillier key and NTilde, h1, h2 of the recovering party. The
+// mod proof may be nil when it is disabled in the parameters.
+func NewRCPreParamsMessage(
+	from *tss.PartyID,
+	paillierPK *paillier.PublicKey,
+	nTilde, h1, h2 *big.Int,
+	dlnProof1, dlnProof2 *dlnproof.Proof,
+	modProof *modproof.ProofMod,
+) (tss.ParsedMessage, error) {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	dlnProof1Bz, err := dlnProof1.Serialize()
+	if err != nil {
+		return nil, err
+	}
+	dlnProof2Bz, err := dlnProof2.Serialize()
+	if err != nil {
+		return nil, err
+	}
+	content := &RCPreParamsMessage{
+		PaillierN:  paillierPK.N.Bytes(),
+		NTilde:     nTilde.Bytes(),
+		H1:         h1.Bytes(),
+		H2:         h2.Bytes(),
+		Dlnproof_1: dlnProof1Bz,
+		Dlnproof_2: dlnProof2Bz,
+	}
+	if modProof != nil {
+		proofBzs := modProof.Bytes()
+		content.ModProof = proofBzs[:]
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg), nil
+}
+
+func (m *RCPreParamsMessage) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetPaillierN()) &&
+		common.NonEmptyBytes(m.GetNTilde()) &&
+		common.NonEmptyBytes(m.GetH1()) &&
+		common.NonEmptyBytes(m.GetH2()) &&
+		// expected len of dln proof = sizeof(int64) + len(alpha) + len(t)
+		common.NonEmptyMultiBytes(m.GetDlnproof_1(), 2+(dlnproof.Iterations*2)) &&
+		common.NonEmptyMultiBytes(m.GetDlnproof_2(), 2+(dlnproof.Iterations*2))
+}
+
+func (m *RCPreParamsMessage) UnmarshalPaillierPK() *paillier.PublicKey {
+	return &paillier.PublicKey{N: new(big.Int).SetBytes(m.GetPaillierN())}
+}
+
+func (m *RCPreParamsMessage) UnmarshalNTilde() *big.Int {
+	return new(big.Int).SetBytes(m.GetNTilde())
+}
+
+func (m *RCPreParamsMessage) UnmarshalH1() *big.Int {
+	return new(big.Int).SetBytes(m.GetH1())
+}
+
+func (m *RCPreParamsMessage) UnmarshalH2() *big.Int {
+	return new(big.Int).SetBytes(m.GetH2())
+}
+
+func (m *RCPreParamsMessage) UnmarshalDLNProof1() (*dlnproof.Proof, error) {
+	return dlnproof.UnmarshalDLNProof(m.GetDlnproof_1())
+}
+
+func (m *RCPreParamsMessage) UnmarshalDLNProof2() (*dlnproof.Proof, error) {
+	return dlnproof.UnmarshalDLNProof(m.GetDlnproof_2())
+}
+
+func (m *RCPreParamsMessage) UnmarshalModProof() (*modproof.ProofMod, error) {
+	return modproof.NewProofFromBytes(m.GetModProof())
+}
+
+// ----- //
+
+// NewRCFacProofMessage creates the message with the fac proof of the recovering party for the helper `to`. The proof
+// may be nil when it is disabled in the parameters.
+func NewRCFacProofMessage(
+	to, from *tss.PartyID,
+	proof *facproof.ProofFac,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	content := &RCFacProofMessage{}
+	if proof != nil {
+		proofBzs := proof.Bytes()
+		content.FacProof = proofBzs[:]
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *RCFacProofMessage) ValidateBasic() bool {
+	return m != nil
+}
+
+func (m *RCFacProofMessage) UnmarshalFacProof() (*facproof.ProofFac, error) {
+	return facproof.NewProofFromBytes(m.GetFacProof())
+}
+
+func equalMultiBytes(a, b [][]byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !bytes.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
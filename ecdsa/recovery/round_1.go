@@ -0,0 +1,186 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package recovery
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// round 1 represents round 1 of the share recovery: every helper splits its contribution to the lost share into random
+// pieces, sends one piece to each helper and broadcasts commitments to the pieces
+func newRound1(params *tss.Parameters, key, save *keygen.LocalPartySaveData, temp *localTempData, lost *tss.PartyID, out chan<- tss.Message, end chan<- *keygen.LocalPartySaveData) tss.Round {
+	return &round1{
+		&base{params, key, save, temp, lost, out, end, make([]bool, len(params.Parties().IDs())), false, 1}}
+}
+
+func (round *round1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	Pi := round.PartyID()
+	i := Pi.Index
+
+	if err := round.prepare(); err != nil {
+		return round.WrapError(err, Pi)
+	}
+	if round.isLost() {
+		// the lost party only receives in this round; it prepares its new Paillier key and NTilde, h1, h2 meanwhile
+		if !round.save.LocalPreParams.ValidateWithProof() {
+			preParams, err := keygen.GeneratePreParams(round.SafePrimeGenTimeout(), round.Concurrency())
+			if err != nil {
+				return round.WrapError(errors.New("pre-params generation failed"), Pi)
+			}
+			round.save.LocalPreParams = *preParams
+		}
+		return nil
+	}
+
+	// 1. compute the contribution lambda_i * x_i of this helper to the lost share x_r = f(k_r)
+	ec := round.Params().EC()
+	modQ := common.ModInt(ec.Params().N)
+	helpers := round.helpers()
+	lambda, err := vss.LagrangeCoefficient(ec, helpers.Keys(), helperPosition(helpers, Pi), round.lost.KeyInt())
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+	contribution := modQ.Mul(lambda, round.key.Xi)
+
+	// 2. split the contribution into random pieces, one for each helper, and commit to them
+	pieces := make([]*big.Int, len(helpers))
+	pieceCmts := make([]*crypto.ECPoint, len(helpers))
+	sum := big.NewInt(0)
+	for k := range pieces {
+		if k < len(pieces)-1 {
+			pieces[k] = common.GetRandomPositiveInt(ec.Params().N)
+			sum = modQ.Add(sum, pieces[k])
+		} else {
+			pieces[k] = modQ.Sub(contribution, sum)
+		}
+		pieceCmts[k] = crypto.ScalarBaseMult(ec, pieces[k])
+	}
+
+	// 3. p2p send piece ij to helper Pj
+	for k, Pj := range helpers {
+		r1msg1 := NewRCRound1Message1(Pj, Pi, pieces[k])
+		// do not send to this Pj, but store for round 2
+		if Pj.Index == i {
+			round.temp.rcRound1Message1s[i] = r1msg1
+			continue
+		}
+		round.out <- r1msg1
+	}
+
+	// 4. BROADCAST the commitments to the pieces and the public key data
+	r1msg2, err := NewRCRound1Message2(Pi, pieceCmts, round.key)
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+	round.temp.rcRound1Message2s[i] = r1msg2
+	round.out <- r1msg2
+	return nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*RCRound1Message1); ok {
+		return !msg.IsBroadcast()
+	}
+	if _, ok := msg.Content().(*RCRound1Message2); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.rcRound1Message2s {
+		if round.ok[j] {
+			continue
+		}
+		// the lost party does not send anything
+		if j == round.lost.Index {
+			round.ok[j] = true
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		// the lost party does not receive pieces
+		if !round.isLost() {
+			msg1 := round.temp.rcRound1Message1s[j]
+			if msg1 == nil || !round.CanAccept(msg1) {
+				ret = false
+				continue
+			}
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &round2{round}
+}
+
+// prepare checks the parties and, for a helper, that its key belongs to them
+func (round *round1) prepare() error {
+	lost := round.Parties().IDs().FindByKey(round.lost.KeyInt())
+	if lost == nil {
+		return fmt.Errorf("the lost party %s is not one of the parties", round.lost)
+	}
+	round.lost = lost
+	if len(round.helpers()) < round.Threshold()+1 {
+		return fmt.Errorf("at least %d helpers are needed to recover a share", round.Threshold()+1)
+	}
+	if round.isLost() {
+		return nil
+	}
+
+	key := round.key
+	if key.ECDSAPub == nil || key.Xi == nil || key.ShareID == nil {
+		return errors.New("the key of the helper is incomplete")
+	}
+	if key.ShareID.Cmp(round.PartyID().KeyInt()) != 0 {
+		return errors.New("the key does not belong to this party")
+	}
+	if len(key.BigXj) != len(key.Ks) {
+		return errors.New("the key of the helper is incomplete")
+	}
+	ks := make(map[string]struct{}, len(key.Ks))
+	for _, kj := range key.Ks {
+		ks[hex.EncodeToString(kj.Bytes())] = struct{}{}
+	}
+	for _, Pj := range round.Parties().IDs() {
+		if _, ok := ks[hex.EncodeToString(Pj.Key)]; !ok {
+			return fmt.Errorf("the party %s did not take part in the keygen", Pj)
+		}
+	}
+	return nil
+}
+
+func helperPosition(helpers tss.SortedPartyIDs, Pj *tss.PartyID) int {
+	for k, helper := range helpers {
+		if helper.Index == Pj.Index {
+			return k
+		}
+	}
+	return -1
+}
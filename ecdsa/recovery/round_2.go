@@ -0,0 +1,235 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package recovery
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/dlnproof"
+	"github.com/bnb-chain/tss-lib/v2/crypto/facproof"
+	"github.com/bnb-chain/tss-lib/v2/crypto/modproof"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func (round *round2) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	Pi := round.PartyID()
+	ec := round.Params().EC()
+	helpers := round.helpers()
+
+	// 5. check that all helpers sent the same public key data; a helper compares it with its own key
+	pub, culprits, err := round.publicData()
+	if err != nil {
+		return round.WrapError(err, culprits...)
+	}
+
+	// 6. check that the pieces of every helper Pj add up to its contribution lambda_j * X_j
+	for k, Pj := range helpers {
+		pieceCmts, err := round.pieceCommitments(Pj)
+		if err != nil {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		lambda, err := vss.LagrangeCoefficient(ec, helpers.Keys(), k, round.lost.KeyInt())
+		if err != nil {
+			return round.WrapError(err)
+		}
+		sum, err := sumPoints(pieceCmts)
+		if err != nil || !sum.Equals(pub.BigXj[keyIndex(pub.Ks, Pj.KeyInt())].ScalarMult(lambda)) {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		if round.isLost() {
+			continue
+		}
+		// 7. a helper checks the piece that it received from Pj against its commitment
+		r1msg1 := round.temp.rcRound1Message1s[Pj.Index].Content().(*RCRound1Message1)
+		if !crypto.ScalarBaseMult(ec, r1msg1.UnmarshalPiece()).Equals(pieceCmts[helperPosition(helpers, Pi)]) {
+			culprits = append(culprits, Pj)
+		}
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("the pieces of the lost share failed to verify"), culprits...)
+	}
+	if round.isLost() {
+		return round.sendPreParams(pub)
+	}
+
+	// 8. a helper sends the sum of the pieces that it received to the lost party
+	modQ := common.ModInt(ec.Params().N)
+	pieceSum := big.NewInt(0)
+	for _, Pj := range helpers {
+		r1msg1 := round.temp.rcRound1Message1s[Pj.Index].Content().(*RCRound1Message1)
+		pieceSum = modQ.Add(pieceSum, r1msg1.UnmarshalPiece())
+	}
+	round.out <- NewRCRound2Message(round.lost, Pi, pieceSum)
+	return nil
+}
+
+// sendPreParams sends the new Paillier key and NTilde, h1, h2 of the lost party to the helpers with the dln and mod
+// proofs, and a fac proof for each helper
+func (round *round2) sendPreParams(pub *keygen.LocalPartySaveData) *tss.Error {
+	Pi := round.PartyID()
+	preParams := round.save.LocalPreParams
+	ContextI := common.AppendBigIntToBytesSlice(round.getSSID(pub.ECDSAPub), big.NewInt(int64(Pi.Index)))
+
+	dlnProof1 := dlnproof.NewDLNProof(preParams.H1i, preParams.H2i, preParams.Alpha, preParams.P, preParams.Q, preParams.NTildei)
+	dlnProof2 := dlnproof.NewDLNProof(preParams.H2i, preParams.H1i, preParams.Beta, preParams.P, preParams.Q, preParams.NTildei)
+	var modProof *modproof.ProofMod
+	if !round.Params().NoProofMod() {
+		var err error
+		modProof, err = modproof.NewProof(ContextI, preParams.PaillierSK.N, preParams.PaillierSK.P, preParams.PaillierSK.Q)
+		if err != nil {
+			return round.WrapError(err, Pi)
+		}
+	}
+	r2msg2, err := NewRCPreParamsMessage(Pi, &preParams.PaillierSK.PublicKey, preParams.NTildei, preParams.H1i, preParams.H2i,
+		dlnProof1, dlnProof2, modProof)
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+	round.out <- r2msg2
+
+	for _, Pj := range round.helpers() {
+		var facProof *facproof.ProofFac
+		if !round.Params().NoProofFac() {
+			j := keyIndex(pub.Ks, Pj.KeyInt())
+			facProof, err = facproof.NewProof(ContextI, round.EC(), preParams.PaillierSK.N, pub.NTildej[j],
+				pub.H1j[j], pub.H2j[j], preParams.PaillierSK.P, preParams.PaillierSK.Q)
+			if err != nil {
+				return round.WrapError(err, Pi)
+			}
+		}
+		round.out <- NewRCFacProofMessage(Pj, Pi, facProof)
+	}
+	return nil
+}
+
+func (round *round2) CanAccept(msg tss.ParsedMessage) bool {
+	switch msg.Content().(type) {
+	case *RCRound2Message, *RCFacProofMessage:
+		return !msg.IsBroadcast()
+	case *RCPreParamsMessage:
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round2) Update() (bool, *tss.Error) {
+	ret := true
+	for j := range round.ok {
+		if round.ok[j] {
+			continue
+		}
+		// the lost party receives the sums of the pieces from the helpers, and the helpers receive its new pre-params
+		if (j == round.lost.Index) == round.isLost() {
+			round.ok[j] = true
+			continue
+		}
+		if !round.received(j) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+// received reports whether the messages of this round from the party at index j have arrived
+func (round *round2) received(j int) bool {
+	msgs := []tss.ParsedMessage{round.temp.rcRound2Messages[j]}
+	if !round.isLost() {
+		msgs = []tss.ParsedMessage{round.temp.rcPreParamsMessages[j], round.temp.rcFacProofMessages[j]}
+	}
+	for _, msg := range msgs {
+		if msg == nil || !round.CanAccept(msg) {
+			return false
+		}
+	}
+	return true
+}
+
+func (round *round2) NextRound() tss.Round {
+	round.started = false
+	return &round3{round}
+}
+
+// ----- //
+
+// publicData returns the public key data that all helpers agree on, or the helpers to blame
+func (round *round1) publicData() (*keygen.LocalPartySaveData, []*tss.PartyID, error) {
+	helpers := round.helpers()
+	ref := round.temp.rcRound1Message2s[helpers[0].Index].Content().(*RCRound1Message2)
+	if !round.isLost() {
+		ref = round.temp.rcRound1Message2s[round.PartyID().Index].Content().(*RCRound1Message2)
+	}
+	culprits := make([]*tss.PartyID, 0, len(helpers))
+	for _, Pj := range helpers {
+		r1msg2 := round.temp.rcRound1Message2s[Pj.Index].Content().(*RCRound1Message2)
+		if !ref.samePublicData(r1msg2) {
+			culprits = append(culprits, Pj)
+		}
+	}
+	if len(culprits) > 0 {
+		return nil, culprits, errors.New("the helpers sent different public key data")
+	}
+	pub, err := ref.UnmarshalPublicData(round.Params().EC())
+	if err != nil {
+		return nil, helpers, err
+	}
+	for _, Pj := range round.Parties().IDs() {
+		if keyIndex(pub.Ks, Pj.KeyInt()) < 0 {
+			return nil, helpers, fmt.Errorf("the party %s did not take part in the keygen", Pj)
+		}
+	}
+	return &pub, nil, nil
+}
+
+// pieceCommitments returns the commitments to the pieces that the helper Pj split its contribution into
+func (round *round1) pieceCommitments(Pj *tss.PartyID) ([]*crypto.ECPoint, error) {
+	r1msg2 := round.temp.rcRound1Message2s[Pj.Index].Content().(*RCRound1Message2)
+	pieceCmts, err := r1msg2.UnmarshalPieceCommitments(round.Params().EC())
+	if err != nil {
+		return nil, err
+	}
+	if len(pieceCmts) != len(round.helpers()) {
+		return nil, errors.New("the number of piece commitments does not match the number of helpers")
+	}
+	return pieceCmts, nil
+}
+
+func sumPoints(points []*crypto.ECPoint) (*crypto.ECPoint, error) {
+	sum := points[0]
+	for _, point := range points[1:] {
+		var err error
+		if sum, err = sum.Add(point); err != nil {
+			return nil, err
+		}
+	}
+	return sum, nil
+}
+
+func keyIndex(ks []*big.Int, key *big.Int) int {
+	for j, kj := range ks {
+		if kj.Cmp(key) == 0 {
+			return j
+		}
+	}
+	return -1
+}
@@ -0,0 +1,176 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package recovery
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	paillierBitsLen = 2048
+)
+
+func (round *round3) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 3
+	round.started = true
+	round.resetOK()
+
+	if !round.isLost() {
+		return round.updatePreParams()
+	}
+
+	ec := round.Params().EC()
+	helpers := round.helpers()
+	pub, culprits, err := round.publicData()
+	if err != nil {
+		return round.WrapError(err, culprits...)
+	}
+
+	pieceCmts := make([][]*crypto.ECPoint, len(helpers))
+	for k, Pj := range helpers {
+		if pieceCmts[k], err = round.pieceCommitments(Pj); err != nil {
+			return round.WrapError(err, Pj)
+		}
+	}
+
+	// 9. check the sum of the pieces from every helper Pj against the commitments to the pieces that Pj received
+	modQ := common.ModInt(ec.Params().N)
+	xi := big.NewInt(0)
+	for k, Pj := range helpers {
+		r2msg := round.temp.rcRound2Messages[Pj.Index].Content().(*RCRound2Message)
+		pieceSum := r2msg.UnmarshalPieceSum()
+		column := make([]*crypto.ECPoint, len(helpers))
+		for l := range helpers {
+			column[l] = pieceCmts[l][k]
+		}
+		expected, err := sumPoints(column)
+		if err != nil || !crypto.ScalarBaseMult(ec, pieceSum).Equals(expected) {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		xi = modQ.Add(xi, pieceSum)
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("the sums of the pieces of the lost share failed to verify"), culprits...)
+	}
+
+	// 10. the sum of all pieces is the lost share; check it against its public commitment
+	shareID := round.lost.KeyInt()
+	r := keyIndex(pub.Ks, shareID)
+	if !crypto.ScalarBaseMult(ec, xi).Equals(pub.BigXj[r]) {
+		return round.WrapError(errors.New("the recovered share does not match its public commitment"))
+	}
+
+	// 11. replace the lost Paillier key, NTilde, h1 and h2 of this party with the new ones
+	preParams := round.save.LocalPreParams
+	*round.save = *pub
+	round.save.LocalPreParams = preParams
+	round.save.Xi = xi
+	round.save.ShareID = shareID
+	round.save.NTildej[r] = preParams.NTildei
+	round.save.H1j[r], round.save.H2j[r] = preParams.H1i, preParams.H2i
+	round.save.PaillierPKs[r] = &preParams.PaillierSK.PublicKey
+
+	round.end <- round.save
+	return nil
+}
+
+// updatePreParams verifies the new Paillier key and NTilde, h1, h2 of the lost party, and outputs the key of this helper
+// with them in place of the lost ones
+func (round *round3) updatePreParams() *tss.Error {
+	lost := round.lost
+	r2msg2 := round.temp.rcPreParamsMessages[lost.Index].Content().(*RCPreParamsMessage)
+	paillierPK, NTilde, h1, h2 :=
+		r2msg2.UnmarshalPaillierPK(),
+		r2msg2.UnmarshalNTilde(),
+		r2msg2.UnmarshalH1(),
+		r2msg2.UnmarshalH2()
+
+	// 9. a helper checks the new pre-params and the proofs that they are well formed
+	if paillierPK.N.BitLen() != paillierBitsLen {
+		return round.WrapError(errors.New("got paillier modulus with insufficient bits for this party"), lost)
+	}
+	if h1.Cmp(h2) == 0 {
+		return round.WrapError(errors.New("h1j and h2j were equal for this party"), lost)
+	}
+	if NTilde.BitLen() != paillierBitsLen {
+		return round.WrapError(errors.New("got NTildej with insufficient bits for this party"), lost)
+	}
+	if dlnProof1, err := r2msg2.UnmarshalDLNProof1(); err != nil || !dlnProof1.Verify(h1, h2, NTilde) {
+		return round.WrapError(errors.New("dln proof verification failed"), lost)
+	}
+	if dlnProof2, err := r2msg2.UnmarshalDLNProof2(); err != nil || !dlnProof2.Verify(h2, h1, NTilde) {
+		return round.WrapError(errors.New("dln proof verification failed"), lost)
+	}
+	ContextR := common.AppendBigIntToBytesSlice(round.getSSID(round.key.ECDSAPub), big.NewInt(int64(lost.Index)))
+	if !round.Params().NoProofMod() {
+		modProof, err := r2msg2.UnmarshalModProof()
+		if err != nil || !modProof.Verify(ContextR, paillierPK.N) {
+			return round.WrapError(errors.New("modProof verify failed"), lost)
+		}
+	}
+	if !round.Params().NoProofFac() {
+		r2msg3 := round.temp.rcFacProofMessages[lost.Index].Content().(*RCFacProofMessage)
+		facProof, err := r2msg3.UnmarshalFacProof()
+		if err != nil || !facProof.Verify(ContextR, round.EC(), paillierPK.N, round.key.NTildei, round.key.H1i, round.key.H2i) {
+			return round.WrapError(errors.New("facProof verify failed"), lost)
+		}
+	}
+
+	// ensure uniqueness of h1, h2 among the values of the other parties
+	r := keyIndex(round.key.Ks, lost.KeyInt())
+	h1H2Map := make(map[string]struct{}, len(round.key.Ks)*2)
+	for j := range round.key.Ks {
+		if j == r {
+			continue
+		}
+		h1H2Map[hex.EncodeToString(round.key.H1j[j].Bytes())] = struct{}{}
+		h1H2Map[hex.EncodeToString(round.key.H2j[j].Bytes())] = struct{}{}
+	}
+	for _, h := range []*big.Int{h1, h2} {
+		if _, found := h1H2Map[hex.EncodeToString(h.Bytes())]; found {
+			return round.WrapError(errors.New("this h1j or h2j was already used by another party"), lost)
+		}
+	}
+
+	// 10. replace the Paillier key, NTilde, h1 and h2 of the lost party in a copy of the key of this helper
+	*round.save = *round.key
+	round.save.PaillierPKs = append([]*paillier.PublicKey{}, round.key.PaillierPKs...)
+	round.save.NTildej = append([]*big.Int{}, round.key.NTildej...)
+	round.save.H1j = append([]*big.Int{}, round.key.H1j...)
+	round.save.H2j = append([]*big.Int{}, round.key.H2j...)
+	round.save.PaillierPKs[r] = paillierPK
+	round.save.NTildej[r] = NTilde
+	round.save.H1j[r], round.save.H2j[r] = h1, h2
+
+	round.end <- round.save
+	return nil
+}
+
+func (round *round3) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *round3) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *round3) NextRound() tss.Round {
+	return nil // finished!
+}
@@ -0,0 +1,110 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package recovery
+
+import (
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	TaskName = "ecdsa-recovery"
+)
+
+type (
+	base struct {
+		*tss.Parameters
+		key     *keygen.LocalPartySaveData
+		save    *keygen.LocalPartySaveData
+		temp    *localTempData
+		lost    *tss.PartyID
+		out     chan<- tss.Message
+		end     chan<- *keygen.LocalPartySaveData
+		ok      []bool // `ok` tracks parties which have been verified by Update()
+		started bool
+		number  int
+	}
+	round1 struct {
+		*base
+	}
+	round2 struct {
+		*round1
+	}
+	round3 struct {
+		*round2
+	}
+)
+
+func (round *base) Params() *tss.Parameters {
+	return round.Parameters
+}
+
+func (round *base) RoundNumber() int {
+	return round.number
+}
+
+// CanProceed is inherited by other rounds
+func (round *base) CanProceed() bool {
+	if !round.started {
+		return false
+	}
+	for _, ok := range round.ok {
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// WaitingFor is called by a Party for reporting back to the caller
+func (round *base) WaitingFor() []*tss.PartyID {
+	Ps := round.Parties().IDs()
+	ids := make([]*tss.PartyID, 0, len(round.ok))
+	for j, ok := range round.ok {
+		if ok {
+			continue
+		}
+		ids = append(ids, Ps[j])
+	}
+	return ids
+}
+
+func (round *base) WrapError(err error, culprits ...*tss.PartyID) *tss.Error {
+	return tss.NewError(err, TaskName, round.number, round.PartyID(), culprits...)
+}
+
+// ----- //
+
+// `ok` tracks parties which have been verified by Update()
+func (round *base) resetOK() {
+	for j := range round.ok {
+		round.ok[j] = false
+	}
+}
+
+// isLost reports whether this party is the one recovering its share
+func (round *base) isLost() bool {
+	return round.PartyID().KeyInt().Cmp(round.lost.KeyInt()) == 0
+}
+
+// helpers returns the parties that help to recover the share, which are all the parties except the lost one
+func (round *base) helpers() tss.SortedPartyIDs {
+	return round.Parties().IDs().Exclude(round.lost)
+}
+
+// get ssid from the parties, the recovered key and the lost party
+func (round *base) getSSID(ecdsaPub *crypto.ECPoint) []byte {
+	ssidList := []*big.Int{round.EC().Params().P, round.EC().Params().N, round.EC().Params().Gx, round.EC().Params().Gy} // ec curve
+	ssidList = append(ssidList, round.Parties().IDs().Keys()...)
+	ssidList = append(ssidList, ecdsaPub.X(), ecdsaPub.Y()) // the key of the lost share
+	ssidList = append(ssidList, round.lost.KeyInt())
+	return common.SHA512_256i(ssidList...).Bytes()
+}
@@ -0,0 +1,126 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package recovery
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+type snapshotState struct {
+	Key      keygen.LocalPartySaveData
+	Save     keygen.LocalPartySaveData
+	Lost     []byte
+	OK       []bool
+	Messages [][]*tss.SnapshotMessage
+}
+
+// Snapshot exports the in-flight state of this party, encrypted with a key of tss.SnapshotKeyLength bytes.
+// The snapshot contains secret key material and should be stored with the same care as the key share itself.
+func (p *LocalParty) Snapshot(key []byte) ([]byte, *tss.Error) {
+	return tss.BaseSnapshot(p, TaskName, key, p.snapshotState)
+}
+
+// RestoreLocalParty rebuilds a helper or recovering party from a snapshot taken with Snapshot(). The party continues
+// from the round it was in, so it must not be started again; feed it the messages it has not yet received through
+// Update() or UpdateFromBytes(). `params` must describe the same party and peers as the ones used to construct the
+// original party.
+func RestoreLocalParty(
+	params *tss.Parameters,
+	snapshot, key []byte,
+	out chan<- tss.Message,
+	end chan<- *keygen.LocalPartySaveData,
+) (tss.Party, *tss.Error) {
+	p := newLocalParty(params, params.PartyID(), out, end)
+	if err := tss.BaseRestore(p, TaskName, snapshot, key, p.restoreState); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *LocalParty) messageStores() []*[]tss.ParsedMessage {
+	return []*[]tss.ParsedMessage{
+		&p.temp.rcRound1Message1s,
+		&p.temp.rcRound1Message2s,
+		&p.temp.rcRound2Messages,
+		&p.temp.rcPreParamsMessages,
+		&p.temp.rcFacProofMessages,
+	}
+}
+
+func (p *LocalParty) snapshotState(rnd tss.Round) (interface{}, error) {
+	round, err := roundBase(rnd)
+	if err != nil {
+		return nil, err
+	}
+	state := &snapshotState{
+		Key:  p.key,
+		Save: p.data,
+		Lost: round.lost.Key,
+		OK:   round.ok,
+	}
+	for _, store := range p.messageStores() {
+		msgs, err := tss.NewSnapshotMessages(*store)
+		if err != nil {
+			return nil, err
+		}
+		state.Messages = append(state.Messages, msgs)
+	}
+	return state, nil
+}
+
+func (p *LocalParty) restoreState(stateBz []byte, number int) (tss.Round, error) {
+	state := new(snapshotState)
+	if err := json.Unmarshal(stateBz, state); err != nil {
+		return nil, err
+	}
+	partyCount := p.params.PartyCount()
+	stores := p.messageStores()
+	if len(state.OK) != partyCount || len(state.Messages) != len(stores) {
+		return nil, errors.New("the snapshot does not match the parties in params")
+	}
+	for k, store := range stores {
+		msgs, err := tss.ParseSnapshotMessages(state.Messages[k], partyCount)
+		if err != nil {
+			return nil, err
+		}
+		*store = msgs
+	}
+	p.key = state.Key
+	p.data = state.Save
+	if p.lost = p.params.Parties().IDs().FindByKey(new(big.Int).SetBytes(state.Lost)); p.lost == nil {
+		return nil, errors.New("the lost party of the snapshot is not one of the parties in params")
+	}
+
+	r1 := newRound1(p.params, &p.key, &p.data, &p.temp, p.lost, p.out, p.end).(*round1)
+	copy(r1.ok, state.OK)
+	r1.started = true
+	r1.number = number
+	r2 := &round2{r1}
+	rounds := []tss.Round{r1, r2, &round3{r2}}
+	if number < 1 || len(rounds) < number {
+		return nil, fmt.Errorf("the snapshot has an unexpected round number %d", number)
+	}
+	return rounds[number-1], nil
+}
+
+func roundBase(rnd tss.Round) (*base, error) {
+	switch round := rnd.(type) {
+	case *round1:
+		return round.base, nil
+	case *round2:
+		return round.base, nil
+	case *round3:
+		return round.base, nil
+	}
+	return nil, fmt.Errorf("unexpected round type %T", rnd)
+}
@@ -0,0 +1,269 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/signing"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+
+	keys, pIDs, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+
+	saves := runRefresh(t, keys, pIDs, func(i int, params *tss.Parameters, out chan<- tss.Message, end chan<- *keygen.LocalPartySaveData) tss.Party {
+		return NewLocalParty(params, keys[i], out, end)
+	})
+	assertRefreshed(t, keys, saves)
+	for i, save := range saves {
+		assert.Equal(t, keys[i].PaillierSK, save.PaillierSK, "the paillier key should be kept")
+		assert.Equal(t, keys[i].NTildej, save.NTildej)
+	}
+}
+
+func TestE2EPaillierKeyRotation(t *testing.T) {
+	setUp("info")
+
+	keys, pIDs, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+
+	// the first two parties swap their pre-params, which saves generating new safe primes in the test
+	saves := runRefresh(t, keys, pIDs, func(i int, params *tss.Parameters, out chan<- tss.Message, end chan<- *keygen.LocalPartySaveData) tss.Party {
+		switch i {
+		case 0:
+			return NewLocalParty(params, keys[i], out, end, keys[1].LocalPreParams)
+		case 1:
+			return NewLocalParty(params, keys[i], out, end, keys[0].LocalPreParams)
+		}
+		return NewLocalParty(params, keys[i], out, end)
+	})
+	assertRefreshed(t, keys, saves)
+	assert.Equal(t, keys[1].PaillierSK, saves[0].PaillierSK)
+	assert.Equal(t, keys[0].PaillierSK, saves[1].PaillierSK)
+	for _, save := range saves {
+		assert.Equal(t, keys[1].PaillierSK.N, save.PaillierPKs[0].N)
+		assert.Equal(t, keys[0].PaillierSK.N, save.PaillierPKs[1].N)
+		assert.Equal(t, keys[1].NTildei, save.NTildej[0])
+		assert.Equal(t, keys[0].NTildei, save.NTildej[1])
+		assert.Equal(t, keys[2].NTildei, save.NTildej[2])
+	}
+
+	// the refreshed keys can sign
+	signKeys := make([]keygen.LocalPartySaveData, 0, testThreshold+1)
+	for _, save := range saves[:testThreshold+1] {
+		signKeys = append(signKeys, *save)
+	}
+	signPIDs := pIDs[:testThreshold+1]
+	msg := big.NewInt(42)
+	data := runSigning(t, signKeys, signPIDs, msg)
+	pk := ecdsa.PublicKey{
+		Curve: tss.EC(),
+		X:     keys[0].ECDSAPub.X(),
+		Y:     keys[0].ECDSAPub.Y(),
+	}
+	ok := ecdsa.Verify(&pk, msg.Bytes(), new(big.Int).SetBytes(data.R), new(big.Int).SetBytes(data.S))
+	assert.True(t, ok, "ecdsa verify must pass")
+}
+
+func TestDuplicateH1H2(t *testing.T) {
+	setUp("info")
+
+	keys, pIDs, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+
+	p2pCtx := tss.NewPeerContext(pIDs)
+	outCh := make(chan tss.Message, len(pIDs)*len(pIDs))
+	parties := make([]tss.Party, len(pIDs))
+	for i := range pIDs {
+		params := tss.NewParameters(tss.S256(), p2pCtx, pIDs[i], len(pIDs), testThreshold)
+		if i == 0 {
+			// party 0 "rotates" to the parameters of party 1
+			parties[i] = NewLocalParty(params, keys[i], outCh, nil, keys[1].LocalPreParams)
+		} else {
+			parties[i] = NewLocalParty(params, keys[i], outCh, nil)
+		}
+		if err := parties[i].Start(); !assert.Nil(t, err) {
+			return
+		}
+	}
+	var err2 *tss.Error
+	for k := 0; k < len(pIDs); k++ {
+		msg := <-outCh
+		if msg.GetFrom().Index == 2 {
+			continue
+		}
+		if _, err := parties[2].Update(msg.(tss.ParsedMessage)); err != nil {
+			err2 = err
+		}
+	}
+	if assert.NotNil(t, err2, "round 2 should fail on reused h1 and h2") {
+		assert.Contains(t, err2.Error(), "already used by another party")
+	}
+}
+
+func TestMissingParties(t *testing.T) {
+	setUp("info")
+
+	keys, pIDs, err := keygen.LoadKeygenTestFixtures(testThreshold + 1)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	params := tss.NewParameters(tss.S256(), tss.NewPeerContext(pIDs), pIDs[0], len(pIDs), testThreshold)
+	P := NewLocalParty(params, keys[0], make(chan tss.Message, len(pIDs)), nil)
+	if err := P.Start(); assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "all the")
+	}
+}
+
+func runRefresh(
+	t *testing.T,
+	keys []keygen.LocalPartySaveData,
+	pIDs tss.SortedPartyIDs,
+	newParty func(int, *tss.Parameters, chan<- tss.Message, chan<- *keygen.LocalPartySaveData) tss.Party,
+) []*keygen.LocalPartySaveData {
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]tss.Party, 0, len(pIDs))
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan *keygen.LocalPartySaveData, len(pIDs))
+
+	for i := 0; i < len(pIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, pIDs[i], len(pIDs), testThreshold)
+		P := newParty(i, params, outCh, endCh)
+		parties = append(parties, P)
+		go func(P tss.Party) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	saves := make([]*keygen.LocalPartySaveData, len(pIDs))
+	for ended := 0; ended < len(pIDs); {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case msg := <-outCh:
+			if dest := msg.GetTo(); dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index != msg.GetFrom().Index {
+						go test.SharedPartyUpdater(P, msg, errCh)
+					}
+				}
+			} else {
+				go test.SharedPartyUpdater(parties[dest[0].Index], msg, errCh)
+			}
+		case save := <-endCh:
+			index, err := save.OriginalIndex()
+			assert.NoError(t, err, "should not be an error getting a party's index from save data")
+			saves[index] = save
+			ended++
+		}
+	}
+	return saves
+}
+
+func assertRefreshed(t *testing.T, keys []keygen.LocalPartySaveData, saves []*keygen.LocalPartySaveData) {
+	ec := tss.S256()
+	for i, save := range saves {
+		// the public key stays the same while the shares change
+		assert.True(t, save.ECDSAPub.Equals(keys[i].ECDSAPub))
+		assert.NotEqual(t, keys[i].Xi, save.Xi)
+		assert.Equal(t, keys[i].ShareID, save.ShareID)
+		for j := range saves {
+			assert.True(t, crypto.ScalarBaseMult(ec, saves[j].Xi).Equals(save.BigXj[j]), "BigXj should match the refreshed shares")
+		}
+	}
+
+	// the refreshed shares reconstruct the same secret
+	shares := make(vss.Shares, 0, testThreshold+1)
+	for _, save := range saves[:testThreshold+1] {
+		shares = append(shares, &vss.Share{Threshold: testThreshold, ID: save.ShareID, Share: save.Xi})
+	}
+	u, err := shares.ReConstruct(ec)
+	assert.NoError(t, err)
+	assert.True(t, crypto.ScalarBaseMult(ec, u).Equals(keys[0].ECDSAPub))
+
+	// a mix of old and refreshed shares does not
+	shares[0] = &vss.Share{Threshold: testThreshold, ID: keys[0].ShareID, Share: keys[0].Xi}
+	u, err = shares.ReConstruct(ec)
+	assert.NoError(t, err)
+	assert.False(t, crypto.ScalarBaseMult(ec, u).Equals(keys[0].ECDSAPub))
+}
+
+func runSigning(t *testing.T, keys []keygen.LocalPartySaveData, signPIDs tss.SortedPartyIDs, msg *big.Int) *common.SignatureData {
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]tss.Party, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		P := signing.NewLocalParty(msg, params, keys[i], outCh, endCh)
+		parties = append(parties, P)
+		go func(P tss.Party) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	var data *common.SignatureData
+	for ended := 0; ended < len(signPIDs); {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case msg := <-outCh:
+			if dest := msg.GetTo(); dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index != msg.GetFrom().Index {
+						go test.SharedPartyUpdater(P, msg, errCh)
+					}
+				}
+			} else {
+				go test.SharedPartyUpdater(parties[dest[0].Index], msg, errCh)
+			}
+		case data = <-endCh:
+			ended++
+		}
+	}
+	return data
+}
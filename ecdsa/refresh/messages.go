@@ -0,0 +1,191 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh
+
+import (
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	cmt "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/dlnproof"
+	"github.com/bnb-chain/tss-lib/v2/crypto/facproof"
+	"github.com/bnb-chain/tss-lib/v2/crypto/modproof"
+	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into ecdsa-refresh.pb.go
+// The following messages are registered on the Protocol Buffers "wire"
+
+var (
+	// Ensure that refresh messages implement ValidateBasic
+	_ = []tss.MessageContent{
+		(*RFRound1Message)(nil),
+		(*RFRound2Message1)(nil),
+		(*RFRound2Message2)(nil),
+	}
+)
+
+// ----- //
+
+// NewRFRound1Message creates the round 1 message. The Paillier key, NTilde, h1, h2 and DLN proofs are only sent by a
+// party that rotates them and may be nil otherwise.
+func NewRFRound1Message(
+	from *tss.PartyID,
+	ct cmt.HashCommitment,
+	paillierPK *paillier.PublicKey,
+	nTildeI, h1I, h2I *big.Int,
+	dlnProof1, dlnProof2 *dlnproof.Proof,
+) (tss.ParsedMessage, error) {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &RFRound1Message{
+		Commitment: ct.Bytes(),
+	}
+	if paillierPK != nil {
+		dlnProof1Bz, err := dlnProof1.Serialize()
+		if err != nil {
+			return nil, err
+		}
+		dlnProof2Bz, err := dlnProof2.Serialize()
+		if err != nil {
+			return nil, err
+		}
+		content.PaillierN = paillierPK.N.Bytes()
+		content.NTilde = nTildeI.Bytes()
+		content.H1 = h1I.Bytes()
+		content.H2 = h2I.Bytes()
+		content.Dlnproof_1 = dlnProof1Bz
+		content.Dlnproof_2 = dlnProof2Bz
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg), nil
+}
+
+func (m *RFRound1Message) ValidateBasic() bool {
+	if m == nil || !common.NonEmptyBytes(m.GetCommitment()) {
+		return false
+	}
+	if !m.RotatesPaillierKey() {
+		return true
+	}
+	return common.NonEmptyBytes(m.GetNTilde()) &&
+		common.NonEmptyBytes(m.GetH1()) &&
+		common.NonEmptyBytes(m.GetH2()) &&
+		// expected len of dln proof = sizeof(int64) + len(alpha) + len(t)
+		common.NonEmptyMultiBytes(m.GetDlnproof_1(), 2+(dlnproof.Iterations*2)) &&
+		common.NonEmptyMultiBytes(m.GetDlnproof_2(), 2+(dlnproof.Iterations*2))
+}
+
+// RotatesPaillierKey reports whether the sender replaces its Paillier key, NTilde, h1 and h2 in this refresh
+func (m *RFRound1Message) RotatesPaillierKey() bool {
+	return common.NonEmptyBytes(m.GetPaillierN())
+}
+
+func (m *RFRound1Message) UnmarshalCommitment() *big.Int {
+	return new(big.Int).SetBytes(m.GetCommitment())
+}
+
+func (m *RFRound1Message) UnmarshalPaillierPK() *paillier.PublicKey {
+	return &paillier.PublicKey{N: new(big.Int).SetBytes(m.GetPaillierN())}
+}
+
+func (m *RFRound1Message) UnmarshalNTilde() *big.Int {
+	return new(big.Int).SetBytes(m.GetNTilde())
+}
+
+func (m *RFRound1Message) UnmarshalH1() *big.Int {
+	return new(big.Int).SetBytes(m.GetH1())
+}
+
+func (m *RFRound1Message) UnmarshalH2() *big.Int {
+	return new(big.Int).SetBytes(m.GetH2())
+}
+
+func (m *RFRound1Message) UnmarshalDLNProof1() (*dlnproof.Proof, error) {
+	return dlnproof.UnmarshalDLNProof(m.GetDlnproof_1())
+}
+
+func (m *RFRound1Message) UnmarshalDLNProof2() (*dlnproof.Proof, error) {
+	return dlnproof.UnmarshalDLNProof(m.GetDlnproof_2())
+}
+
+// ----- //
+
+func NewRFRound2Message1(
+	to, from *tss.PartyID,
+	share *vss.Share,
+	proof *facproof.ProofFac,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	content := &RFRound2Message1{
+		Share: share.Share.Bytes(),
+	}
+	if proof != nil {
+		proofBzs := proof.Bytes()
+		content.FacProof = proofBzs[:]
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *RFRound2Message1) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetShare())
+}
+
+func (m *RFRound2Message1) UnmarshalShare() *big.Int {
+	return new(big.Int).SetBytes(m.Share)
+}
+
+func (m *RFRound2Message1) UnmarshalFacProof() (*facproof.ProofFac, error) {
+	return facproof.NewProofFromBytes(m.GetFacProof())
+}
+
+// ----- //
+
+func NewRFRound2Message2(
+	from *tss.PartyID,
+	deCommitment cmt.HashDeCommitment,
+	proof *modproof.ProofMod,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	dcBzs := common.BigIntsToBytes(deCommitment)
+	content := &RFRound2Message2{
+		DeCommitment: dcBzs,
+	}
+	if proof != nil {
+		proofBzs := proof.Bytes()
+		content.ModProof = proofBzs[:]
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *RFRound2Message2) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyMultiBytes(m.GetDeCommitment())
+}
+
+func (m *RFRound2Message2) UnmarshalDeCommitment() []*big.Int {
+	deComBzs := m.GetDeCommitment()
+	return cmt.NewHashDeCommitmentFromBytes(deComBzs)
+}
+
+func (m *RFRound2Message2) UnmarshalModProof() (*modproof.ProofMod, error) {
+	return modproof.NewProofFromBytes(m.GetModProof())
+}
@@ -0,0 +1,175 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto/facproof"
+	"github.com/bnb-chain/tss-lib/v2/crypto/modproof"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	paillierBitsLen = 2048
+)
+
+func (round *round2) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	i := round.PartyID().Index
+
+	// 4. verify the new paillier keys and dln proofs of the rotating parties
+	dlnVerifier := keygen.NewDlnProofVerifier(round.Concurrency())
+	dlnProof1FailCulprits := make([]*tss.PartyID, len(round.temp.rfRound1Messages))
+	dlnProof2FailCulprits := make([]*tss.PartyID, len(round.temp.rfRound1Messages))
+	wg := new(sync.WaitGroup)
+	for j, msg := range round.temp.rfRound1Messages {
+		r1msg := msg.Content().(*RFRound1Message)
+		round.temp.RFCs[j] = r1msg.UnmarshalCommitment()
+		if j == i || !r1msg.RotatesPaillierKey() {
+			continue
+		}
+		H1j, H2j, NTildej, paillierPKj :=
+			r1msg.UnmarshalH1(),
+			r1msg.UnmarshalH2(),
+			r1msg.UnmarshalNTilde(),
+			r1msg.UnmarshalPaillierPK()
+		if paillierPKj.N.BitLen() != paillierBitsLen {
+			return round.WrapError(errors.New("got paillier modulus with insufficient bits for this party"), msg.GetFrom())
+		}
+		if H1j.Cmp(H2j) == 0 {
+			return round.WrapError(errors.New("h1j and h2j were equal for this party"), msg.GetFrom())
+		}
+		if NTildej.BitLen() != paillierBitsLen {
+			return round.WrapError(errors.New("got NTildej with insufficient bits for this party"), msg.GetFrom())
+		}
+
+		wg.Add(2)
+		_j := j
+		_msg := msg
+
+		dlnVerifier.VerifyDLNProof1(r1msg, H1j, H2j, NTildej, func(isValid bool) {
+			if !isValid {
+				dlnProof1FailCulprits[_j] = _msg.GetFrom()
+			}
+			wg.Done()
+		})
+		dlnVerifier.VerifyDLNProof2(r1msg, H2j, H1j, NTildej, func(isValid bool) {
+			if !isValid {
+				dlnProof2FailCulprits[_j] = _msg.GetFrom()
+			}
+			wg.Done()
+		})
+
+		// save the new paillier pk, NTilde_j, h1_j, h2_j
+		round.save.PaillierPKs[j] = paillierPKj
+		round.save.NTildej[j] = NTildej
+		round.save.H1j[j], round.save.H2j[j] = H1j, H2j
+	}
+	wg.Wait()
+	for _, culprit := range append(dlnProof1FailCulprits, dlnProof2FailCulprits...) {
+		if culprit != nil {
+			return round.WrapError(errors.New("dln proof verification failed"), culprit)
+		}
+	}
+	// ensure uniqueness of h1j, h2j among the old and new parameters
+	h1H2Map := make(map[string]struct{}, round.PartyCount()*2)
+	for j, Pj := range round.Parties().IDs() {
+		h1JHex, h2JHex := hex.EncodeToString(round.save.H1j[j].Bytes()), hex.EncodeToString(round.save.H2j[j].Bytes())
+		if _, found := h1H2Map[h1JHex]; found {
+			return round.WrapError(errors.New("this h1j was already used by another party"), Pj)
+		}
+		if _, found := h1H2Map[h2JHex]; found {
+			return round.WrapError(errors.New("this h2j was already used by another party"), Pj)
+		}
+		h1H2Map[h1JHex], h1H2Map[h2JHex] = struct{}{}, struct{}{}
+	}
+
+	// 5. p2p send share ij to Pj, with a proof that the new paillier modulus has no small factors when rotating
+	rotating := round.temp.rfRound1Messages[i].Content().(*RFRound1Message).RotatesPaillierKey()
+	shares := round.temp.shares
+	ContextI := append(round.temp.ssid, big.NewInt(int64(i)).Bytes()...)
+	for j, Pj := range round.Parties().IDs() {
+		var facProof *facproof.ProofFac
+		if rotating && j != i && !round.Params().NoProofFac() {
+			var err error
+			facProof, err = facproof.NewProof(ContextI, round.EC(), round.save.PaillierSK.N, round.save.NTildej[j],
+				round.save.H1j[j], round.save.H2j[j], round.save.PaillierSK.P, round.save.PaillierSK.Q)
+			if err != nil {
+				return round.WrapError(err, round.PartyID())
+			}
+		}
+		r2msg1 := NewRFRound2Message1(Pj, round.PartyID(), shares[j], facProof)
+		// do not send to this Pj, but store for round 3
+		if j == i {
+			round.temp.rfRound2Message1s[j] = r2msg1
+			continue
+		}
+		round.out <- r2msg1
+	}
+
+	// 6. BROADCAST de-commitments of the zero sharing poly*G, with a proof of the new paillier modulus when rotating
+	var modProof *modproof.ProofMod
+	if rotating && !round.Params().NoProofMod() {
+		var err error
+		modProof, err = modproof.NewProof(ContextI, round.save.PaillierSK.N,
+			round.save.PaillierSK.P, round.save.PaillierSK.Q)
+		if err != nil {
+			return round.WrapError(err, round.PartyID())
+		}
+	}
+	r2msg2 := NewRFRound2Message2(round.PartyID(), round.temp.deCommitPolyG, modProof)
+	round.temp.rfRound2Message2s[i] = r2msg2
+	round.out <- r2msg2
+
+	return nil
+}
+
+func (round *round2) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*RFRound2Message1); ok {
+		return !msg.IsBroadcast()
+	}
+	if _, ok := msg.Content().(*RFRound2Message2); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round2) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.rfRound2Message1s {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		msg2 := round.temp.rfRound2Message2s[j]
+		if msg2 == nil || !round.CanAccept(msg2) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round2) NextRound() tss.Round {
+	round.started = false
+	return &round3{round}
+}
@@ -0,0 +1,166 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func (round *round3) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 3
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	PIdx := round.PartyID().Index
+
+	// 7-9. verify the de-commitments and the shares of zero of the other parties
+	errs := make([]error, len(Ps))
+	PjVs := make([]vss.Vs, len(Ps))
+	PjVs[PIdx] = round.temp.vs
+	wg := new(sync.WaitGroup)
+	for j := range Ps {
+		if j == PIdx {
+			continue
+		}
+		wg.Add(1)
+		go func(j int) {
+			defer wg.Done()
+			PjVs[j], errs[j] = round.verifyShare(j)
+		}(j)
+	}
+	wg.Wait()
+	culprits := make([]*tss.PartyID, 0, len(Ps)) // who caused the error(s)
+	for j, Pj := range Ps {
+		if errs[j] != nil {
+			culprits = append(culprits, Pj)
+		}
+	}
+	if len(culprits) > 0 {
+		var multiErr error
+		for _, err := range errs {
+			if err != nil {
+				multiErr = multierror.Append(multiErr, err)
+			}
+		}
+		return round.WrapError(multiErr, culprits...)
+	}
+
+	// 10. add the shares of zero to xi
+	modQ := common.ModInt(round.Params().EC().Params().N)
+	xi := new(big.Int).Set(round.save.Xi)
+	for j := range Ps {
+		r2msg1 := round.temp.rfRound2Message1s[j].Content().(*RFRound2Message1)
+		xi = modQ.Add(xi, r2msg1.UnmarshalShare())
+	}
+
+	// 11. sum up the commitments to the shares of zero
+	Vc := make(vss.Vs, round.Threshold())
+	copy(Vc, round.temp.vs)
+	for j, Pj := range Ps {
+		if j == PIdx {
+			continue
+		}
+		for c := range Vc {
+			var err error
+			if Vc[c], err = Vc[c].Add(PjVs[j][c]); err != nil {
+				return round.WrapError(errors.New("adding PjVs[c] to Vc[c] resulted in a point not on the curve"), Pj)
+			}
+		}
+	}
+
+	// 12. add the commitments of the shares of zero to Xj for each Pj
+	bigXj := make([]*crypto.ECPoint, len(Ps))
+	for j, Pj := range Ps {
+		deltaXj, err := vss.ZeroSharingCommitment(round.Params().EC(), Vc, Pj.KeyInt())
+		if err != nil {
+			return round.WrapError(err)
+		}
+		if bigXj[j], err = round.save.BigXj[j].Add(deltaXj); err != nil {
+			return round.WrapError(errors.New("adding the shares of zero to BigXj resulted in a point not on the curve"))
+		}
+	}
+	if !crypto.ScalarBaseMult(round.Params().EC(), xi).Equals(bigXj[PIdx]) {
+		return round.WrapError(errors.New("the refreshed share does not match its public commitment"))
+	}
+
+	// SAVE the refreshed share; the public key is unchanged
+	round.save.Xi = xi
+	round.save.BigXj = bigXj
+
+	round.end <- round.save
+	return nil
+}
+
+func (round *round3) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *round3) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *round3) NextRound() tss.Round {
+	return nil // finished!
+}
+
+func (round *round3) verifyShare(j int) (vss.Vs, error) {
+	r2msg2 := round.temp.rfRound2Message2s[j].Content().(*RFRound2Message2)
+	cmtDeCmt := commitments.HashCommitDecommit{C: round.temp.RFCs[j], D: r2msg2.UnmarshalDeCommitment()}
+	ok, flatPolyGs := cmtDeCmt.DeCommit()
+	if !ok || flatPolyGs == nil {
+		return nil, errors.New("de-commitment verify failed")
+	}
+	PjVs, err := crypto.UnFlattenECPoints(round.Params().EC(), flatPolyGs)
+	if err != nil {
+		return nil, err
+	}
+	r2msg1 := round.temp.rfRound2Message1s[j].Content().(*RFRound2Message1)
+	PjShare := vss.Share{
+		Threshold: round.Threshold(),
+		ID:        round.PartyID().KeyInt(),
+		Share:     r2msg1.UnmarshalShare(),
+	}
+	if ok = PjShare.VerifyZeroShare(round.Params().EC(), round.Threshold(), PjVs); !ok {
+		return nil, errors.New("vss verify failed")
+	}
+
+	// the new paillier modulus of a rotating party must be a Paillier-Blum modulus without small factors
+	if !round.temp.rfRound1Messages[j].Content().(*RFRound1Message).RotatesPaillierKey() {
+		return PjVs, nil
+	}
+	ContextJ := common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(j)))
+	if !round.Params().NoProofMod() {
+		modProof, err := r2msg2.UnmarshalModProof()
+		if err != nil || !modProof.Verify(ContextJ, round.save.PaillierPKs[j].N) {
+			return nil, errors.New("modProof verify failed")
+		}
+	}
+	if !round.Params().NoProofFac() {
+		facProof, err := r2msg1.UnmarshalFacProof()
+		if err != nil || !facProof.Verify(ContextJ, round.EC(), round.save.PaillierPKs[j].N, round.save.NTildei,
+			round.save.H1i, round.save.H2i) {
+			return nil, errors.New("facProof verify failed")
+		}
+	}
+	return PjVs, nil
+}
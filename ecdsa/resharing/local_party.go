@@ -7,6 +7,7 @@
 package resharing
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 
@@ -111,6 +112,10 @@ func (p *LocalParty) Start() *tss.Error {
 	return tss.BaseStart(p, TaskName)
 }
 
+func (p *LocalParty) StartWithContext(ctx context.Context, errCh chan<- *tss.Error) *tss.Error {
+	return tss.BaseStartWithContext(ctx, p, TaskName, errCh)
+}
+
 func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
 	return tss.BaseUpdate(p, msg, TaskName)
 }
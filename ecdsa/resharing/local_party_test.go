@@ -8,6 +8,8 @@ package resharing_test
 
 import (
 	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"fmt"
 	"math/big"
 	"runtime"
@@ -19,6 +21,7 @@ import (
 
 	"github.com/bnb-chain/tss-lib/v2/common"
 	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
 	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
 	. "github.com/bnb-chain/tss-lib/v2/ecdsa/resharing"
 	"github.com/bnb-chain/tss-lib/v2/ecdsa/signing"
@@ -38,16 +41,29 @@ func setUp(level string) {
 }
 
 func TestE2EConcurrent(t *testing.T) {
-	setUp("info")
+	testE2EConcurrent(t, tss.S256())
+}
 
-	// tss.SetCurve(elliptic.P256())
+func TestE2EConcurrentP256(t *testing.T) {
+	testE2EConcurrent(t, tss.P256())
+}
+
+func testE2EConcurrent(t *testing.T, ec elliptic.Curve) {
+	setUp("info")
 
 	threshold, newThreshold := testThreshold, testThreshold
 
 	// PHASE: load keygen fixtures
 	firstPartyIdx, extraParties := 1, 1 // extra can be 0 to N-first
-	oldKeys, oldPIDs, err := keygen.LoadKeygenTestFixtures(testThreshold+1+extraParties+firstPartyIdx, firstPartyIdx)
+	oldKeys, oldPIDs, err := keygen.LoadKeygenTestFixturesForCurve(ec, testThreshold+1+extraParties+firstPartyIdx, firstPartyIdx)
 	assert.NoError(t, err, "should load keygen fixtures")
+	// the fixtures predate the chain code; give the key one to check that it is carried over to the new committee
+	chainCode := make([]byte, keygen.ChainCodeLength)
+	_, err = rand.Read(chainCode)
+	assert.NoError(t, err)
+	for j := range oldKeys {
+		oldKeys[j].ChainCode = chainCode
+	}
 
 	// PHASE: resharing
 	oldP2PCtx := tss.NewPeerContext(oldPIDs)
@@ -72,13 +88,13 @@ func TestE2EConcurrent(t *testing.T) {
 
 	// init the old parties first
 	for j, pID := range oldPIDs {
-		params := tss.NewReSharingParameters(tss.S256(), oldP2PCtx, newP2PCtx, pID, testParticipants, threshold, newPCount, newThreshold)
+		params := tss.NewReSharingParameters(ec, oldP2PCtx, newP2PCtx, pID, testParticipants, threshold, newPCount, newThreshold)
 		P := NewLocalParty(params, oldKeys[j], outCh, endCh).(*LocalParty) // discard old key data
 		oldCommittee = append(oldCommittee, P)
 	}
 	// init the new parties
 	for j, pID := range newPIDs {
-		params := tss.NewReSharingParameters(tss.S256(), oldP2PCtx, newP2PCtx, pID, testParticipants, threshold, newPCount, newThreshold)
+		params := tss.NewReSharingParameters(ec, oldP2PCtx, newP2PCtx, pID, testParticipants, threshold, newPCount, newThreshold)
 		// do not use in untrusted setting
 		params.SetNoProofMod()
 		// do not use in untrusted setting
@@ -154,9 +170,10 @@ func TestE2EConcurrent(t *testing.T) {
 				for j, key := range newKeys {
 					// xj test: BigXj == xj*G
 					xj := key.Xi
-					gXj := crypto.ScalarBaseMult(tss.S256(), xj)
+					gXj := crypto.ScalarBaseMult(ec, xj)
 					BigXj := key.BigXj[j]
 					assert.True(t, BigXj.Equals(gXj), "ensure BigX_j == g^x_j")
+					assert.Equal(t, chainCode, key.ChainCode, "the chain code should be carried over")
 				}
 
 				// more verification of signing is implemented within local_party_test.go of keygen package
@@ -176,7 +193,7 @@ signing:
 	signEndCh := make(chan *common.SignatureData, len(signPIDs))
 
 	for j, signPID := range signPIDs {
-		params := tss.NewParameters(tss.S256(), signP2pCtx, signPID, len(signPIDs), newThreshold)
+		params := tss.NewParameters(ec, signP2pCtx, signPID, len(signPIDs), newThreshold)
 		P := signing.NewLocalParty(big.NewInt(42), params, signKeys[j], signOutCh, signEndCh).(*signing.LocalParty)
 		signParties = append(signParties, P)
 		go func(P *signing.LocalParty) {
@@ -219,7 +236,7 @@ signing:
 				// BEGIN ECDSA verify
 				pkX, pkY := signKeys[0].ECDSAPub.X(), signKeys[0].ECDSAPub.Y()
 				pk := ecdsa.PublicKey{
-					Curve: tss.S256(),
+					Curve: ec,
 					X:     pkX,
 					Y:     pkY,
 				}
@@ -236,3 +253,128 @@ signing:
 		}
 	}
 }
+
+func TestE2ESnapshotAndRestore(t *testing.T) {
+	setUp("info")
+
+	threshold, newThreshold := testThreshold, testThreshold
+
+	// PHASE: load keygen fixtures
+	oldKeys, oldPIDs, err := keygen.LoadKeygenTestFixtures(testThreshold + 1)
+	assert.NoError(t, err, "should load keygen fixtures")
+	// re-use the fixture pre-params for the new parties for speed
+	fixtures, _, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	// PHASE: resharing
+	oldP2PCtx := tss.NewPeerContext(oldPIDs)
+	newPIDs := tss.GenerateTestPartyIDs(testParticipants)
+	newP2PCtx := tss.NewPeerContext(newPIDs)
+	newPCount := len(newPIDs)
+
+	oldParams := make([]*tss.ReSharingParameters, 0, len(oldPIDs))
+	newParams := make([]*tss.ReSharingParameters, 0, newPCount)
+	oldCommittee := make([]tss.Party, 0, len(oldPIDs))
+	newCommittee := make([]tss.Party, 0, newPCount)
+
+	// messages are routed synchronously below, so the channels must be able to hold everything that is produced
+	bothCommitteesPax := len(oldPIDs) + newPCount
+	errCh := make(chan *tss.Error, bothCommitteesPax*bothCommitteesPax*4)
+	outCh := make(chan tss.Message, bothCommitteesPax*bothCommitteesPax*4)
+	endCh := make(chan *keygen.LocalPartySaveData, bothCommitteesPax)
+
+	key := make([]byte, tss.SnapshotKeyLength)
+	_, err = rand.Read(key)
+	assert.NoError(t, err)
+
+	for j, pID := range oldPIDs {
+		params := tss.NewReSharingParameters(tss.S256(), oldP2PCtx, newP2PCtx, pID, testParticipants, threshold, newPCount, newThreshold)
+		oldParams = append(oldParams, params)
+		oldCommittee = append(oldCommittee, NewLocalParty(params, oldKeys[j], outCh, endCh))
+	}
+	for j, pID := range newPIDs {
+		params := tss.NewReSharingParameters(tss.S256(), oldP2PCtx, newP2PCtx, pID, testParticipants, threshold, newPCount, newThreshold)
+		// do not use in untrusted setting
+		params.SetNoProofMod()
+		// do not use in untrusted setting
+		params.SetNoProofFac()
+		newParams = append(newParams, params)
+		save := keygen.NewLocalPartySaveData(newPCount)
+		save.LocalPreParams = fixtures[j].LocalPreParams
+		newCommittee = append(newCommittee, NewLocalParty(params, save, outCh, endCh))
+	}
+	for _, P := range append(newCommittee, oldCommittee...) {
+		if err := P.Start(); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+	}
+
+	restore := func(committee []tss.Party, params []*tss.ReSharingParameters) {
+		for i, P := range committee {
+			if !P.Running() {
+				continue // finished
+			}
+			snapshot, err := P.Snapshot(key)
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			badKey := append([]byte{key[0] + 1}, key[1:]...)
+			_, err = RestoreLocalParty(params[i], snapshot, badKey, outCh, endCh)
+			assert.Error(t, err, "restoring with the wrong key should fail")
+
+			restored, err := RestoreLocalParty(params[i], snapshot, key, outCh, endCh)
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			// the order of WaitingFor is not stable in resharing
+			assert.ElementsMatch(t, P.WaitingFor(), restored.WaitingFor())
+			assert.Error(t, restored.Start(), "a restored party should not start again")
+			committee[i] = restored
+		}
+	}
+
+	// snapshot and restore every party regularly, so that the state of each round goes through a snapshot
+	routed, ended := 0, 0
+	newKeys := make([]keygen.LocalPartySaveData, newPCount)
+	for ended < bothCommitteesPax {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if msg.IsToOldCommittee() || msg.IsToOldAndNewCommittees() {
+				for _, destP := range dest[:len(oldCommittee)] {
+					test.SharedPartyUpdater(oldCommittee[destP.Index], msg, errCh)
+				}
+			}
+			if !msg.IsToOldCommittee() || msg.IsToOldAndNewCommittees() {
+				for _, destP := range dest {
+					test.SharedPartyUpdater(newCommittee[destP.Index], msg, errCh)
+				}
+			}
+			if routed++; routed%3 == 0 {
+				restore(oldCommittee, oldParams)
+				restore(newCommittee, newParams)
+			}
+		case save := <-endCh:
+			if save.Xi != nil {
+				index, err := save.OriginalIndex()
+				assert.NoErrorf(t, err, "should not be an error getting a party's index from save data")
+				newKeys[index] = *save
+			}
+			ended++
+		}
+	}
+
+	// the new shares must still combine to the original public key
+	shares := make(vss.Shares, 0, newThreshold+1)
+	for j, key := range newKeys {
+		assert.True(t, key.BigXj[j].Equals(crypto.ScalarBaseMult(tss.S256(), key.Xi)), "ensure BigX_j == g^x_j")
+		if j <= newThreshold {
+			shares = append(shares, &vss.Share{Threshold: newThreshold, ID: key.ShareID, Share: key.Xi})
+		}
+	}
+	u, err := shares.ReConstruct(tss.S256())
+	assert.NoError(t, err)
+	assert.True(t, crypto.ScalarBaseMult(tss.S256(), u).Equals(oldKeys[0].ECDSAPub))
+}
@@ -0,0 +1,192 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// Implements Party
+// Implements Stringer
+var _ tss.Party = (*BatchParty)(nil)
+var _ fmt.Stringer = (*BatchParty)(nil)
+
+// BatchParty signs several digests in one session. It runs a signing session per digest, and the sessions advance
+// through the rounds together: in every round a party sends one SignBatchMessage to each recipient, which carries the
+// messages of all the sessions, so the number of network rounds does not grow with the number of digests.
+// The range proofs of Alice in the MtA of the sessions are batched as well: a party proves the ranges of its
+// ciphertexts of all the sessions in one mta.BatchRangeProofAlice for each peer and verifies those of each peer
+// together, so the costly exponentiations of the verification are computed once per peer instead of once per digest.
+// The proofs of Bob in the MtA are still computed per session.
+// The sessions use independent nonces; a failure in any of them fails the batch.
+type BatchParty struct {
+	*tss.BaseParty
+	params *tss.Parameters
+
+	sessions []*LocalParty
+	outs     []chan tss.Message
+	ends     []chan *common.SignatureData
+	sigs     []*common.SignatureData
+
+	// outbound messaging
+	out chan<- tss.Message
+	end chan<- *common.SignatureData
+}
+
+// NewBatchParty returns a party that signs every digest in `msgs` with `key`. When the batch is done, one
+// SignatureData per digest is sent to `end`, in the order of `msgs`.
+// Start returns an error if `msgs` is empty or holds a nil digest.
+func NewBatchParty(
+	msgs []*big.Int,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+) tss.Party {
+	return NewBatchPartyWithKDD(msgs, params, key, nil, out, end)
+}
+
+// NewBatchPartyWithKDD returns a batch party with key derivation delta for HD support
+func NewBatchPartyWithKDD(
+	msgs []*big.Int,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	keyDerivationDelta *big.Int,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+) tss.Party {
+	partyCount := len(params.Parties().IDs())
+	p := &BatchParty{
+		BaseParty: new(tss.BaseParty),
+		params:    params,
+		sessions:  make([]*LocalParty, len(msgs)),
+		outs:      make([]chan tss.Message, len(msgs)),
+		ends:      make([]chan *common.SignatureData, len(msgs)),
+		sigs:      make([]*common.SignatureData, len(msgs)),
+		out:       out,
+		end:       end,
+	}
+	for k, msg := range msgs {
+		// a round sends at most one message to each party and one broadcast
+		p.outs[k] = make(chan tss.Message, 2*partyCount)
+		p.ends[k] = make(chan *common.SignatureData, 1)
+		p.sessions[k] = newLocalParty(msg, params, key, keyDerivationDelta, p.outs[k], p.ends[k], nil)
+		p.sessions[k].temp.batchIndex = k
+		p.sessions[k].temp.batched = true
+	}
+	return p
+}
+
+func (p *BatchParty) FirstRound() tss.Round {
+	rounds := make([]tss.Round, len(p.sessions))
+	for k, session := range p.sessions {
+		rounds[k] = session.FirstRound()
+	}
+	return &batchRound{p, rounds, false}
+}
+
+func (p *BatchParty) Start() *tss.Error {
+	return tss.BaseStart(p, TaskName, p.prepare)
+}
+
+func (p *BatchParty) StartWithContext(ctx context.Context, errCh chan<- *tss.Error) *tss.Error {
+	return tss.BaseStartWithContext(ctx, p, TaskName, errCh, p.prepare)
+}
+
+func (p *BatchParty) prepare(round tss.Round) *tss.Error {
+	batch, ok := round.(*batchRound)
+	if !ok {
+		return round.WrapError(errors.New("unable to Start(). party is in an unexpected round"))
+	}
+	if len(batch.rounds) == 0 {
+		return round.WrapError(errors.New("there are no digests to sign"))
+	}
+	for k, session := range p.sessions {
+		if session.temp.m == nil {
+			return round.WrapError(fmt.Errorf("digest %d is nil", k))
+		}
+	}
+	for k, session := range p.sessions {
+		if err := session.prepare(batch.rounds[k]); err != nil {
+			return round.WrapError(fmt.Errorf("digest %d: %v", k, err.Cause()))
+		}
+	}
+	return nil
+}
+
+func (p *BatchParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(p, msg, TaskName)
+}
+
+func (p *BatchParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *BatchParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if ok, err := p.BaseParty.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	// check that the message's "from index" will fit into the array
+	if maxFromIdx := len(p.params.Parties().IDs()) - 1; maxFromIdx < msg.GetFrom().Index {
+		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+			maxFromIdx, msg.GetFrom().Index), msg.GetFrom())
+	}
+	return true, nil
+}
+
+// StoreMessage unpacks a SignBatchMessage and stores the message of each session in it.
+func (p *BatchParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	batch, ok := msg.Content().(*SignBatchMessage)
+	if !ok { // unrecognised message, just ignore!
+		common.Logger.Warningf("unrecognised message ignored: %v", msg)
+		return false, nil
+	}
+	for n, index := range batch.GetIndexes() {
+		if int(index) >= len(p.sessions) {
+			return false, p.WrapError(fmt.Errorf("received a message for digest %d of %d", index, len(p.sessions)), msg.GetFrom())
+		}
+		sessionMsg, err := tss.ParseWireMessage(batch.GetMessages()[n], msg.GetFrom(), msg.IsBroadcast())
+		if err != nil {
+			return false, p.WrapError(err, msg.GetFrom())
+		}
+		if _, isBatch := sessionMsg.Content().(*SignBatchMessage); isBatch || !sessionMsg.ValidateBasic() {
+			return false, p.WrapError(fmt.Errorf("received an invalid message for digest %d", index), msg.GetFrom())
+		}
+		if _, err := p.sessions[index].StoreMessage(sessionMsg); err != nil {
+			return false, p.WrapError(fmt.Errorf("digest %d: %v", index, err.Cause()), err.Culprits()...)
+		}
+	}
+	return true, nil
+}
+
+// Snapshot is not supported by batch parties; sign the digests in a new batch instead.
+func (p *BatchParty) Snapshot(key []byte) ([]byte, *tss.Error) {
+	return nil, p.WrapError(errors.New("could not snapshot. a batch party cannot be snapshotted"))
+}
+
+func (p *BatchParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *BatchParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
@@ -0,0 +1,271 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/pdlproof"
+	"github.com/bnb-chain/tss-lib/v2/crypto/schnorr"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// The presign checks of GG20 (Gennaro, Goldfeder; 2020) run once R is known and before any share of s is revealed.
+// In round 3, before R is known, every party commits to its sigmai with Ti = g^sigmai h^li. Once R is known, every
+// party broadcasts Ri = R^ki and Si = R^sigmai with a proof that Si matches Ti, and sends each of the others a proof
+// that Ri hides the ki that it encrypted for them in round 1. Sum(Ri) = g shows that R was computed from the kj used
+// in the MtA, and Sum(Si) = y shows that the sigmaj are additive shares of k*x; as Sj is bound to Tj, a party cannot
+// pick its Sj after seeing the others to balance the sum. Once both hold, si = m*ki + r*sigmai can be released for any
+// m, and a wrong sj is caught by checking R^sj = Rj^m * Sj^r.
+
+var (
+	errSumRNotG = errors.New("the presign check failed: Sum(Rj) != g; R is not consistent with the MtA")
+	errSumSNotY = errors.New("the presign check failed: Sum(Sj) != y; the shares of k*x are not consistent with the key")
+)
+
+// pedersenH returns the second generator h of the commitments Ti = g^sigmai h^li. It is hashed to the curve, so that
+// nobody knows its discrete logarithm to the base g.
+func pedersenH(ec elliptic.Curve) (*crypto.ECPoint, error) {
+	params := ec.Params()
+	P := params.P
+	// the curve is y^2 = x^3 + a*x + b; find a from the base point
+	a := new(big.Int).Mul(params.Gy, params.Gy)
+	a.Sub(a, new(big.Int).Exp(params.Gx, big.NewInt(3), P))
+	a.Sub(a, params.B)
+	a.Mul(a, new(big.Int).ModInverse(params.Gx, P))
+	a.Mod(a, P)
+	for ctr := int64(0); ; ctr++ {
+		x := new(big.Int).Mod(common.SHA512_256i_TAGGED([]byte("tss-lib ecdsa signing h"), big.NewInt(ctr)), P)
+		rhs := new(big.Int).Exp(x, big.NewInt(3), P)
+		rhs.Add(rhs, new(big.Int).Mul(a, x))
+		rhs.Add(rhs, params.B)
+		rhs.Mod(rhs, P)
+		if y := new(big.Int).ModSqrt(rhs, P); y != nil && ec.IsOnCurve(x, y) {
+			return crypto.NewECPoint(ec, x, y)
+		}
+	}
+}
+
+// commitSigma computes Ti = g^sigmai h^li with a proof of knowledge of sigmai and li
+func (round *base) commitSigma() (*crypto.ECPoint, *schnorr.ZKVProof, *tss.Error) {
+	ec := round.Params().EC()
+	h, err := pedersenH(ec)
+	if err != nil {
+		return nil, nil, round.WrapError(err)
+	}
+	li := common.GetRandomPositiveInt(ec.Params().N)
+	bigTi, err := crypto.ScalarBaseMult(ec, round.temp.sigma).Add(h.ScalarMult(li))
+	if err != nil {
+		return nil, nil, round.WrapError(err)
+	}
+	ContextI := common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(round.PartyID().Index)))
+	proof, err := schnorr.NewZKVProof(ContextI, bigTi, h, li, round.temp.sigma)
+	if err != nil {
+		return nil, nil, round.WrapError(err)
+	}
+	round.temp.bigTis[round.PartyID().Index] = bigTi
+	round.temp.sigmaBlinding = li
+	return bigTi, proof, nil
+}
+
+// verifySigmaCommitments verifies the Tj received from the other parties in round 3
+func (round *base) verifySigmaCommitments() *tss.Error {
+	ec := round.Params().EC()
+	h, err := pedersenH(ec)
+	if err != nil {
+		return round.WrapError(err)
+	}
+	for j, Pj := range round.Parties().IDs() {
+		if j == round.PartyID().Index {
+			continue
+		}
+		r3msg := round.temp.signRound3Messages[j].Content().(*SignRound3Message)
+		bigTj, err := r3msg.UnmarshalBigTi(ec)
+		if err != nil {
+			return round.WrapError(errors.New("failed to unmarshal Tj"), Pj)
+		}
+		proof, err := r3msg.UnmarshalTProof(ec)
+		if err != nil {
+			return round.WrapError(errors.New("failed to unmarshal the Tj proof"), Pj)
+		}
+		ContextJ := common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(j)))
+		if !proof.Verify(ContextJ, bigTj, h) {
+			return round.WrapError(errors.New("failed to prove the knowledge of the opening of Tj"), Pj)
+		}
+		round.temp.bigTis[j] = bigTj
+	}
+	return nil
+}
+
+// startConsistencyCheck broadcasts Ri = R^ki and Si = R^sigmai with a proof that Si matches Ti, and sends every
+// other party a proof that Ri matches cis[j]
+func (round *base) startConsistencyCheck(R *crypto.ECPoint) *tss.Error {
+	ec := round.Params().EC()
+	i := round.PartyID().Index
+	round.ok[i] = true
+
+	bigRi := R.ScalarMult(round.temp.k)
+	bigSi := R.ScalarMult(round.temp.sigma)
+	round.temp.bigRis[i] = bigRi
+	round.temp.bigSis[i] = bigSi
+
+	h, err := pedersenH(ec)
+	if err != nil {
+		return round.WrapError(err)
+	}
+	ContextI := common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(i)))
+	stProof, err := schnorr.NewZKSTProof(ContextI, bigSi, round.temp.bigTis[i], R, h, round.temp.sigma, round.temp.sigmaBlinding)
+	if err != nil {
+		return round.WrapError(err)
+	}
+	// clear temp.sigmaBlinding from memory, lint ignore
+	round.temp.sigmaBlinding = zero
+
+	cMsg := NewSignConsistencyMessage(round.PartyID(), bigRi, bigSi, stProof)
+	round.temp.signConsistencyMessages[i] = cMsg
+	round.out <- cMsg
+	for j, Pj := range round.Parties().IDs() {
+		if j == i {
+			continue
+		}
+		proof, err := pdlproof.NewProof(ContextI, round.key.PaillierPKs[i], round.temp.cis[j], R, bigRi,
+			round.key.NTildej[j], round.key.H1j[j], round.key.H2j[j], round.temp.k, round.temp.cRandomness[j])
+		if err != nil {
+			return round.WrapError(err)
+		}
+		round.out <- NewSignConsistencyProofMessage(Pj, round.PartyID(), proof)
+	}
+	return nil
+}
+
+func (round *base) updateConsistencyCheck() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.signConsistencyMessages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.canAcceptConsistency(msg) {
+			ret = false
+			continue
+		}
+		if pMsg := round.temp.signConsistencyProofMessages[j]; pMsg == nil || !round.canAcceptConsistency(pMsg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *base) canAcceptConsistency(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignConsistencyMessage); ok {
+		return msg.IsBroadcast()
+	}
+	if _, ok := msg.Content().(*SignConsistencyProofMessage); ok {
+		return !msg.IsBroadcast()
+	}
+	return false
+}
+
+// verifyConsistency verifies the Rj and Sj received from the other parties and checks that Sum(Rj) = g and
+// Sum(Sj) = y. A party whose proof does not verify is the culprit; a failed sum check has no culprit.
+func (round *base) verifyConsistency() *tss.Error {
+	ec := round.Params().EC()
+	i := round.PartyID().Index
+	R := round.temp.bigR
+	h, err := pedersenH(ec)
+	if err != nil {
+		return round.WrapError(err)
+	}
+	for j, Pj := range round.Parties().IDs() {
+		if j == i {
+			continue
+		}
+		msg := round.temp.signConsistencyMessages[j].Content().(*SignConsistencyMessage)
+		bigRj, err := msg.UnmarshalBigRi(ec)
+		if err != nil {
+			return round.WrapError(errors.New("failed to unmarshal Rj"), Pj)
+		}
+		bigSj, err := msg.UnmarshalBigSi(ec)
+		if err != nil {
+			return round.WrapError(errors.New("failed to unmarshal Sj"), Pj)
+		}
+		stProof, err := msg.UnmarshalSTProof(ec)
+		if err != nil {
+			return round.WrapError(errors.New("failed to unmarshal the Sj proof"), Pj)
+		}
+		pMsg := round.temp.signConsistencyProofMessages[j].Content().(*SignConsistencyProofMessage)
+		proof, err := pMsg.UnmarshalProofPDL(R)
+		if err != nil {
+			return round.WrapError(errors.New("failed to unmarshal the Rj proof"), Pj)
+		}
+		ContextJ := common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(j)))
+		if !stProof.Verify(ContextJ, bigSj, round.temp.bigTis[j], R, h) {
+			return round.WrapError(errors.New("failed to prove that Sj matches the commitment Tj"), Pj)
+		}
+		cj := round.temp.signRound1Message1s[j].Content().(*SignRound1Message1).UnmarshalC()
+		if !proof.Verify(ContextJ, round.key.PaillierPKs[j], cj, R, bigRj, round.key.NTildej[i], round.key.H1j[i], round.key.H2j[i]) {
+			return round.WrapError(errors.New("failed to prove that Rj matches the encryption of kj"), Pj)
+		}
+		round.temp.bigRis[j] = bigRj
+		round.temp.bigSis[j] = bigSj
+	}
+	if err := checkPresignSums(ec, round.temp.bigRis, round.temp.bigSis, round.key.ECDSAPub); err != nil {
+		return round.WrapError(err)
+	}
+	return nil
+}
+
+// checkPresignSums checks that Sum(Rj) = g and Sum(Sj) = y
+func checkPresignSums(ec elliptic.Curve, bigRs, bigSs []*crypto.ECPoint, y *crypto.ECPoint) error {
+	sumR, err := sumPoints(bigRs)
+	if err != nil || !sumR.Equals(crypto.ScalarBaseMult(ec, big.NewInt(1))) {
+		return errSumRNotG
+	}
+	sumS, err := sumPoints(bigSs)
+	if err != nil || !sumS.Equals(y) {
+		return errSumSNotY
+	}
+	return nil
+}
+
+func sumPoints(points []*crypto.ECPoint) (*crypto.ECPoint, error) {
+	if len(points) == 0 || points[0] == nil {
+		return nil, errors.New("no points to sum")
+	}
+	sum := points[0]
+	for _, point := range points[1:] {
+		if point == nil {
+			return nil, errors.New("a point to sum is nil")
+		}
+		var err error
+		if sum, err = sum.Add(point); err != nil {
+			return nil, err
+		}
+	}
+	return sum, nil
+}
+
+// checkShare reports whether R^sj = Rj^m * Sj^r for the share sj of s revealed by a party that passed the presign checks
+func checkShare(R, bigRj, bigSj *crypto.ECPoint, m, rx, sj *big.Int) bool {
+	N := R.Curve().Params().N
+	if sj == nil || sj.Sign() != 1 || sj.Cmp(N) >= 0 {
+		return false
+	}
+	expected := bigSj.ScalarMult(new(big.Int).Mod(rx, N))
+	if mModN := new(big.Int).Mod(m, N); mModN.Sign() != 0 {
+		var err error
+		if expected, err = bigRj.ScalarMult(mModN).Add(expected); err != nil {
+			return false
+		}
+	}
+	return R.ScalarMult(sj).Equals(expected)
+}
@@ -0,0 +1,91 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/elliptic"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// Hash is the hash function applied to a message by NewLocalPartyFromBytes
+type Hash int
+
+const (
+	// RawDigest signs the message as a digest that was computed by the caller
+	RawDigest Hash = iota
+	SHA256
+	SHA512
+	// Keccak256 is the original Keccak-256 used by Ethereum, not SHA3-256
+	Keccak256
+	// DoubleSHA256 is SHA-256(SHA-256(msg)), used by Bitcoin
+	DoubleSHA256
+)
+
+func (h Hash) String() string {
+	switch h {
+	case RawDigest:
+		return "RawDigest"
+	case SHA256:
+		return "SHA256"
+	case SHA512:
+		return "SHA512"
+	case Keccak256:
+		return "Keccak256"
+	case DoubleSHA256:
+		return "DoubleSHA256"
+	}
+	return fmt.Sprintf("Hash(%d)", int(h))
+}
+
+// Digest returns the digest of `msg`, or `msg` itself for RawDigest
+func (h Hash) Digest(msg []byte) ([]byte, error) {
+	switch h {
+	case RawDigest:
+		if len(msg) == 0 {
+			return nil, errors.New("the digest is empty")
+		}
+		return append([]byte{}, msg...), nil
+	case SHA256:
+		d := sha256.Sum256(msg)
+		return d[:], nil
+	case SHA512:
+		d := sha512.Sum512(msg)
+		return d[:], nil
+	case Keccak256:
+		k := sha3.NewLegacyKeccak256()
+		k.Write(msg)
+		return k.Sum(nil), nil
+	case DoubleSHA256:
+		d := sha256.Sum256(msg)
+		d = sha256.Sum256(d[:])
+		return d[:], nil
+	}
+	return nil, fmt.Errorf("unknown hash %s", h)
+}
+
+// HashToInt converts a digest to the integer that is signed on the curve `ec`, as in FIPS 186-4 section 6.4: a digest
+// longer than the order of the curve is truncated to its leftmost bits. The result is reduced modulo the order, which
+// does not change the signature.
+func HashToInt(ec elliptic.Curve, digest []byte) *big.Int {
+	N := ec.Params().N
+	orderBits := N.BitLen()
+	orderBytes := (orderBits + 7) / 8
+	if len(digest) > orderBytes {
+		digest = digest[:orderBytes]
+	}
+	e := new(big.Int).SetBytes(digest)
+	if excess := len(digest)*8 - orderBits; excess > 0 {
+		e.Rsh(e, uint(excess))
+	}
+	return e.Mod(e, N)
+}
@@ -6,8 +6,8 @@
 
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.27.1
-// 	protoc        v3.14.0
+// 	protoc-gen-go v1.31.0
+// 	protoc        v3.20.3
 // source: protob/ecdsa-signing.proto
 
 package signing
@@ -26,7 +26,6 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-//
 // Represents a P2P message sent to each party during Round 1 of the ECDSA TSS signing protocol.
 type SignRound1Message1 struct {
 	state         protoimpl.MessageState
@@ -83,7 +82,6 @@ func (x *SignRound1Message1) GetRangeProofAlice() [][]byte {
 	return nil
 }
 
-//
 // Represents a BROADCAST message sent to all parties during Round 1 of the ECDSA TSS signing protocol.
 type SignRound1Message2 struct {
 	state         protoimpl.MessageState
@@ -132,7 +130,6 @@ func (x *SignRound1Message2) GetCommitment() []byte {
 	return nil
 }
 
-//
 // Represents a P2P message sent to each party during Round 2 of the ECDSA TSS signing protocol.
 type SignRound2Message struct {
 	state         protoimpl.MessageState
@@ -205,14 +202,20 @@ func (x *SignRound2Message) GetProofBobWc() [][]byte {
 	return nil
 }
 
-//
 // Represents a BROADCAST message sent to all parties during Round 3 of the ECDSA TSS signing protocol.
+// It also carries Ti = g^sigmai h^li, the commitment to sigmai of GG20, with a proof of knowledge of sigmai and li.
 type SignRound3Message struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Theta []byte `protobuf:"bytes,1,opt,name=theta,proto3" json:"theta,omitempty"`
+	Theta        []byte `protobuf:"bytes,1,opt,name=theta,proto3" json:"theta,omitempty"`
+	TIX          []byte `protobuf:"bytes,2,opt,name=t_i_x,json=tIX,proto3" json:"t_i_x,omitempty"`
+	TIY          []byte `protobuf:"bytes,3,opt,name=t_i_y,json=tIY,proto3" json:"t_i_y,omitempty"`
+	TProofAlphaX []byte `protobuf:"bytes,4,opt,name=t_proof_alpha_x,json=tProofAlphaX,proto3" json:"t_proof_alpha_x,omitempty"`
+	TProofAlphaY []byte `protobuf:"bytes,5,opt,name=t_proof_alpha_y,json=tProofAlphaY,proto3" json:"t_proof_alpha_y,omitempty"`
+	TProofT      []byte `protobuf:"bytes,6,opt,name=t_proof_t,json=tProofT,proto3" json:"t_proof_t,omitempty"`
+	TProofU      []byte `protobuf:"bytes,7,opt,name=t_proof_u,json=tProofU,proto3" json:"t_proof_u,omitempty"`
 }
 
 func (x *SignRound3Message) Reset() {
@@ -254,7 +257,48 @@ func (x *SignRound3Message) GetTheta() []byte {
 	return nil
 }
 
-//
+func (x *SignRound3Message) GetTIX() []byte {
+	if x != nil {
+		return x.TIX
+	}
+	return nil
+}
+
+func (x *SignRound3Message) GetTIY() []byte {
+	if x != nil {
+		return x.TIY
+	}
+	return nil
+}
+
+func (x *SignRound3Message) GetTProofAlphaX() []byte {
+	if x != nil {
+		return x.TProofAlphaX
+	}
+	return nil
+}
+
+func (x *SignRound3Message) GetTProofAlphaY() []byte {
+	if x != nil {
+		return x.TProofAlphaY
+	}
+	return nil
+}
+
+func (x *SignRound3Message) GetTProofT() []byte {
+	if x != nil {
+		return x.TProofT
+	}
+	return nil
+}
+
+func (x *SignRound3Message) GetTProofU() []byte {
+	if x != nil {
+		return x.TProofU
+	}
+	return nil
+}
+
 // Represents a BROADCAST message sent to all parties during Round 4 of the ECDSA TSS signing protocol.
 type SignRound4Message struct {
 	state         protoimpl.MessageState
@@ -327,7 +371,176 @@ func (x *SignRound4Message) GetProofT() []byte {
 	return nil
 }
 
-//
+// Represents a BROADCAST message sent to all parties once R is known, before any share of s is revealed (the presign
+// checks of GG20). It carries R^ki and R^sigmai, with a proof that R^sigmai matches the Ti sent in Round 3.
+type SignConsistencyMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BigRIX        []byte `protobuf:"bytes,1,opt,name=big_r_i_x,json=bigRIX,proto3" json:"big_r_i_x,omitempty"`
+	BigRIY        []byte `protobuf:"bytes,2,opt,name=big_r_i_y,json=bigRIY,proto3" json:"big_r_i_y,omitempty"`
+	BigSIX        []byte `protobuf:"bytes,3,opt,name=big_s_i_x,json=bigSIX,proto3" json:"big_s_i_x,omitempty"`
+	BigSIY        []byte `protobuf:"bytes,4,opt,name=big_s_i_y,json=bigSIY,proto3" json:"big_s_i_y,omitempty"`
+	StProofAlphaX []byte `protobuf:"bytes,5,opt,name=st_proof_alpha_x,json=stProofAlphaX,proto3" json:"st_proof_alpha_x,omitempty"`
+	StProofAlphaY []byte `protobuf:"bytes,6,opt,name=st_proof_alpha_y,json=stProofAlphaY,proto3" json:"st_proof_alpha_y,omitempty"`
+	StProofBetaX  []byte `protobuf:"bytes,7,opt,name=st_proof_beta_x,json=stProofBetaX,proto3" json:"st_proof_beta_x,omitempty"`
+	StProofBetaY  []byte `protobuf:"bytes,8,opt,name=st_proof_beta_y,json=stProofBetaY,proto3" json:"st_proof_beta_y,omitempty"`
+	StProofT      []byte `protobuf:"bytes,9,opt,name=st_proof_t,json=stProofT,proto3" json:"st_proof_t,omitempty"`
+	StProofU      []byte `protobuf:"bytes,10,opt,name=st_proof_u,json=stProofU,proto3" json:"st_proof_u,omitempty"`
+}
+
+func (x *SignConsistencyMessage) Reset() {
+	*x = SignConsistencyMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_signing_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignConsistencyMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignConsistencyMessage) ProtoMessage() {}
+
+func (x *SignConsistencyMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_signing_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignConsistencyMessage.ProtoReflect.Descriptor instead.
+func (*SignConsistencyMessage) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_signing_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SignConsistencyMessage) GetBigRIX() []byte {
+	if x != nil {
+		return x.BigRIX
+	}
+	return nil
+}
+
+func (x *SignConsistencyMessage) GetBigRIY() []byte {
+	if x != nil {
+		return x.BigRIY
+	}
+	return nil
+}
+
+func (x *SignConsistencyMessage) GetBigSIX() []byte {
+	if x != nil {
+		return x.BigSIX
+	}
+	return nil
+}
+
+func (x *SignConsistencyMessage) GetBigSIY() []byte {
+	if x != nil {
+		return x.BigSIY
+	}
+	return nil
+}
+
+func (x *SignConsistencyMessage) GetStProofAlphaX() []byte {
+	if x != nil {
+		return x.StProofAlphaX
+	}
+	return nil
+}
+
+func (x *SignConsistencyMessage) GetStProofAlphaY() []byte {
+	if x != nil {
+		return x.StProofAlphaY
+	}
+	return nil
+}
+
+func (x *SignConsistencyMessage) GetStProofBetaX() []byte {
+	if x != nil {
+		return x.StProofBetaX
+	}
+	return nil
+}
+
+func (x *SignConsistencyMessage) GetStProofBetaY() []byte {
+	if x != nil {
+		return x.StProofBetaY
+	}
+	return nil
+}
+
+func (x *SignConsistencyMessage) GetStProofT() []byte {
+	if x != nil {
+		return x.StProofT
+	}
+	return nil
+}
+
+func (x *SignConsistencyMessage) GetStProofU() []byte {
+	if x != nil {
+		return x.StProofU
+	}
+	return nil
+}
+
+// Represents a P2P message sent to each party alongside SignConsistencyMessage, with a proof that R^ki matches the
+// encryption of ki sent to that party in Round 1.
+type SignConsistencyProofMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ProofPdl [][]byte `protobuf:"bytes,1,rep,name=proof_pdl,json=proofPdl,proto3" json:"proof_pdl,omitempty"`
+}
+
+func (x *SignConsistencyProofMessage) Reset() {
+	*x = SignConsistencyProofMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_signing_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignConsistencyProofMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignConsistencyProofMessage) ProtoMessage() {}
+
+func (x *SignConsistencyProofMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_signing_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignConsistencyProofMessage.ProtoReflect.Descriptor instead.
+func (*SignConsistencyProofMessage) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_signing_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SignConsistencyProofMessage) GetProofPdl() [][]byte {
+	if x != nil {
+		return x.ProofPdl
+	}
+	return nil
+}
+
 // Represents a BROADCAST message sent to all parties during Round 5 of the ECDSA TSS signing protocol.
 type SignRound5Message struct {
 	state         protoimpl.MessageState
@@ -340,7 +553,7 @@ type SignRound5Message struct {
 func (x *SignRound5Message) Reset() {
 	*x = SignRound5Message{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protob_ecdsa_signing_proto_msgTypes[5]
+		mi := &file_protob_ecdsa_signing_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -353,7 +566,7 @@ func (x *SignRound5Message) String() string {
 func (*SignRound5Message) ProtoMessage() {}
 
 func (x *SignRound5Message) ProtoReflect() protoreflect.Message {
-	mi := &file_protob_ecdsa_signing_proto_msgTypes[5]
+	mi := &file_protob_ecdsa_signing_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -366,7 +579,7 @@ func (x *SignRound5Message) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SignRound5Message.ProtoReflect.Descriptor instead.
 func (*SignRound5Message) Descriptor() ([]byte, []int) {
-	return file_protob_ecdsa_signing_proto_rawDescGZIP(), []int{5}
+	return file_protob_ecdsa_signing_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *SignRound5Message) GetCommitment() []byte {
@@ -376,7 +589,6 @@ func (x *SignRound5Message) GetCommitment() []byte {
 	return nil
 }
 
-//
 // Represents a BROADCAST message sent to all parties during Round 6 of the ECDSA TSS signing protocol.
 type SignRound6Message struct {
 	state         protoimpl.MessageState
@@ -396,7 +608,7 @@ type SignRound6Message struct {
 func (x *SignRound6Message) Reset() {
 	*x = SignRound6Message{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protob_ecdsa_signing_proto_msgTypes[6]
+		mi := &file_protob_ecdsa_signing_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -409,7 +621,7 @@ func (x *SignRound6Message) String() string {
 func (*SignRound6Message) ProtoMessage() {}
 
 func (x *SignRound6Message) ProtoReflect() protoreflect.Message {
-	mi := &file_protob_ecdsa_signing_proto_msgTypes[6]
+	mi := &file_protob_ecdsa_signing_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -422,7 +634,7 @@ func (x *SignRound6Message) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SignRound6Message.ProtoReflect.Descriptor instead.
 func (*SignRound6Message) Descriptor() ([]byte, []int) {
-	return file_protob_ecdsa_signing_proto_rawDescGZIP(), []int{6}
+	return file_protob_ecdsa_signing_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *SignRound6Message) GetDeCommitment() [][]byte {
@@ -481,7 +693,6 @@ func (x *SignRound6Message) GetVProofU() []byte {
 	return nil
 }
 
-//
 // Represents a BROADCAST message sent to all parties during Round 7 of the ECDSA TSS signing protocol.
 type SignRound7Message struct {
 	state         protoimpl.MessageState
@@ -494,7 +705,7 @@ type SignRound7Message struct {
 func (x *SignRound7Message) Reset() {
 	*x = SignRound7Message{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protob_ecdsa_signing_proto_msgTypes[7]
+		mi := &file_protob_ecdsa_signing_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -507,7 +718,7 @@ func (x *SignRound7Message) String() string {
 func (*SignRound7Message) ProtoMessage() {}
 
 func (x *SignRound7Message) ProtoReflect() protoreflect.Message {
-	mi := &file_protob_ecdsa_signing_proto_msgTypes[7]
+	mi := &file_protob_ecdsa_signing_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -520,7 +731,7 @@ func (x *SignRound7Message) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SignRound7Message.ProtoReflect.Descriptor instead.
 func (*SignRound7Message) Descriptor() ([]byte, []int) {
-	return file_protob_ecdsa_signing_proto_rawDescGZIP(), []int{7}
+	return file_protob_ecdsa_signing_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *SignRound7Message) GetCommitment() []byte {
@@ -530,7 +741,6 @@ func (x *SignRound7Message) GetCommitment() []byte {
 	return nil
 }
 
-//
 // Represents a BROADCAST message sent to all parties during Round 8 of the ECDSA TSS signing protocol.
 type SignRound8Message struct {
 	state         protoimpl.MessageState
@@ -543,7 +753,7 @@ type SignRound8Message struct {
 func (x *SignRound8Message) Reset() {
 	*x = SignRound8Message{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protob_ecdsa_signing_proto_msgTypes[8]
+		mi := &file_protob_ecdsa_signing_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -556,7 +766,7 @@ func (x *SignRound8Message) String() string {
 func (*SignRound8Message) ProtoMessage() {}
 
 func (x *SignRound8Message) ProtoReflect() protoreflect.Message {
-	mi := &file_protob_ecdsa_signing_proto_msgTypes[8]
+	mi := &file_protob_ecdsa_signing_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -569,7 +779,7 @@ func (x *SignRound8Message) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SignRound8Message.ProtoReflect.Descriptor instead.
 func (*SignRound8Message) Descriptor() ([]byte, []int) {
-	return file_protob_ecdsa_signing_proto_rawDescGZIP(), []int{8}
+	return file_protob_ecdsa_signing_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *SignRound8Message) GetDeCommitment() [][]byte {
@@ -579,7 +789,6 @@ func (x *SignRound8Message) GetDeCommitment() [][]byte {
 	return nil
 }
 
-//
 // Represents a BROADCAST message sent to all parties during Round 9 of the ECDSA TSS signing protocol.
 type SignRound9Message struct {
 	state         protoimpl.MessageState
@@ -592,7 +801,7 @@ type SignRound9Message struct {
 func (x *SignRound9Message) Reset() {
 	*x = SignRound9Message{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protob_ecdsa_signing_proto_msgTypes[9]
+		mi := &file_protob_ecdsa_signing_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -605,7 +814,7 @@ func (x *SignRound9Message) String() string {
 func (*SignRound9Message) ProtoMessage() {}
 
 func (x *SignRound9Message) ProtoReflect() protoreflect.Message {
-	mi := &file_protob_ecdsa_signing_proto_msgTypes[9]
+	mi := &file_protob_ecdsa_signing_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -618,7 +827,7 @@ func (x *SignRound9Message) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SignRound9Message.ProtoReflect.Descriptor instead.
 func (*SignRound9Message) Descriptor() ([]byte, []int) {
-	return file_protob_ecdsa_signing_proto_rawDescGZIP(), []int{9}
+	return file_protob_ecdsa_signing_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *SignRound9Message) GetS() []byte {
@@ -628,6 +837,129 @@ func (x *SignRound9Message) GetS() []byte {
 	return nil
 }
 
+// Represents a BROADCAST message sent to all parties during the identification step of the ECDSA TSS signing protocol.
+// It reveals the share of s and the blinding values of rounds 5-7 so that a party whose messages are inconsistent can be
+// identified.
+type SignIdentificationMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	L   []byte `protobuf:"bytes,1,opt,name=l,proto3" json:"l,omitempty"`
+	Rho []byte `protobuf:"bytes,2,opt,name=rho,proto3" json:"rho,omitempty"`
+	S   []byte `protobuf:"bytes,3,opt,name=s,proto3" json:"s,omitempty"`
+}
+
+func (x *SignIdentificationMessage) Reset() {
+	*x = SignIdentificationMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_signing_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignIdentificationMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignIdentificationMessage) ProtoMessage() {}
+
+func (x *SignIdentificationMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_signing_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignIdentificationMessage.ProtoReflect.Descriptor instead.
+func (*SignIdentificationMessage) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_signing_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SignIdentificationMessage) GetL() []byte {
+	if x != nil {
+		return x.L
+	}
+	return nil
+}
+
+func (x *SignIdentificationMessage) GetRho() []byte {
+	if x != nil {
+		return x.Rho
+	}
+	return nil
+}
+
+func (x *SignIdentificationMessage) GetS() []byte {
+	if x != nil {
+		return x.S
+	}
+	return nil
+}
+
+// Carries the messages of all the signing sessions of a batch that a party sends to the same recipient(s) in a round.
+// `indexes` holds the index of the digest of each message in `messages`, which are the wire bytes of the messages.
+type SignBatchMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Indexes  []uint32 `protobuf:"varint,1,rep,packed,name=indexes,proto3" json:"indexes,omitempty"`
+	Messages [][]byte `protobuf:"bytes,2,rep,name=messages,proto3" json:"messages,omitempty"`
+}
+
+func (x *SignBatchMessage) Reset() {
+	*x = SignBatchMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_signing_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignBatchMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignBatchMessage) ProtoMessage() {}
+
+func (x *SignBatchMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_signing_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignBatchMessage.ProtoReflect.Descriptor instead.
+func (*SignBatchMessage) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_signing_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SignBatchMessage) GetIndexes() []uint32 {
+	if x != nil {
+		return x.Indexes
+	}
+	return nil
+}
+
+func (x *SignBatchMessage) GetMessages() [][]byte {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
 var File_protob_ecdsa_signing_proto protoreflect.FileDescriptor
 
 var file_protob_ecdsa_signing_proto_rawDesc = []byte{
@@ -650,51 +982,97 @@ var file_protob_ecdsa_signing_proto_rawDesc = []byte{
 	0x6f, 0x62, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x42,
 	0x6f, 0x62, 0x12, 0x20, 0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x62, 0x6f, 0x62, 0x5f,
 	0x77, 0x63, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x42,
-	0x6f, 0x62, 0x57, 0x63, 0x22, 0x29, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e,
-	0x64, 0x33, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x68, 0x65,
-	0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x74, 0x68, 0x65, 0x74, 0x61, 0x22,
-	0x99, 0x01, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x34, 0x4d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x65, 0x5f, 0x63, 0x6f, 0x6d, 0x6d,
-	0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0c, 0x64, 0x65,
-	0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x70, 0x72,
-	0x6f, 0x6f, 0x66, 0x5f, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x5f, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x41, 0x6c, 0x70, 0x68, 0x61, 0x58, 0x12, 0x22,
-	0x0a, 0x0d, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x5f, 0x79, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x41, 0x6c, 0x70, 0x68,
-	0x61, 0x59, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x74, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x54, 0x22, 0x33, 0x0a, 0x11, 0x53,
-	0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x35, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74,
-	0x22, 0x9f, 0x02, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x36, 0x4d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x65, 0x5f, 0x63, 0x6f, 0x6d,
-	0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0c, 0x64,
-	0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x70,
-	0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x5f, 0x78, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x41, 0x6c, 0x70, 0x68, 0x61, 0x58, 0x12,
-	0x22, 0x0a, 0x0d, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x5f, 0x79,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x41, 0x6c, 0x70,
-	0x68, 0x61, 0x59, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x74, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x54, 0x12, 0x25, 0x0a, 0x0f,
-	0x76, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x5f, 0x78, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x76, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x41, 0x6c, 0x70,
-	0x68, 0x61, 0x58, 0x12, 0x25, 0x0a, 0x0f, 0x76, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x61,
-	0x6c, 0x70, 0x68, 0x61, 0x5f, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x76, 0x50,
-	0x72, 0x6f, 0x6f, 0x66, 0x41, 0x6c, 0x70, 0x68, 0x61, 0x59, 0x12, 0x1a, 0x0a, 0x09, 0x76, 0x5f,
-	0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x76,
-	0x50, 0x72, 0x6f, 0x6f, 0x66, 0x54, 0x12, 0x1a, 0x0a, 0x09, 0x76, 0x5f, 0x70, 0x72, 0x6f, 0x6f,
-	0x66, 0x5f, 0x75, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x76, 0x50, 0x72, 0x6f, 0x6f,
-	0x66, 0x55, 0x22, 0x33, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x37,
-	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69,
-	0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x63, 0x6f, 0x6d,
-	0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x38, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x52,
-	0x6f, 0x75, 0x6e, 0x64, 0x38, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x23, 0x0a, 0x0d,
-	0x64, 0x65, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20,
-	0x03, 0x28, 0x0c, 0x52, 0x0c, 0x64, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e,
-	0x74, 0x22, 0x21, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x39, 0x4d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0c, 0x0a, 0x01, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x01, 0x73, 0x42, 0x0f, 0x5a, 0x0d, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2f, 0x73, 0x69,
-	0x67, 0x6e, 0x69, 0x6e, 0x67, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6f, 0x62, 0x57, 0x63, 0x22, 0xd7, 0x01, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75,
+	0x6e, 0x64, 0x33, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x68,
+	0x65, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x74, 0x68, 0x65, 0x74, 0x61,
+	0x12, 0x12, 0x0a, 0x05, 0x74, 0x5f, 0x69, 0x5f, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x03, 0x74, 0x49, 0x58, 0x12, 0x12, 0x0a, 0x05, 0x74, 0x5f, 0x69, 0x5f, 0x79, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x03, 0x74, 0x49, 0x59, 0x12, 0x25, 0x0a, 0x0f, 0x74, 0x5f, 0x70, 0x72,
+	0x6f, 0x6f, 0x66, 0x5f, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x5f, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x0c, 0x74, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x41, 0x6c, 0x70, 0x68, 0x61, 0x58, 0x12,
+	0x25, 0x0a, 0x0f, 0x74, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x5f, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x74, 0x50, 0x72, 0x6f, 0x6f, 0x66,
+	0x41, 0x6c, 0x70, 0x68, 0x61, 0x59, 0x12, 0x1a, 0x0a, 0x09, 0x74, 0x5f, 0x70, 0x72, 0x6f, 0x6f,
+	0x66, 0x5f, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x74, 0x50, 0x72, 0x6f, 0x6f,
+	0x66, 0x54, 0x12, 0x1a, 0x0a, 0x09, 0x74, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x75, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x74, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x55, 0x22, 0x99,
+	0x01, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x34, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x65, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69,
+	0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0c, 0x64, 0x65, 0x43,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x70, 0x72, 0x6f,
+	0x6f, 0x66, 0x5f, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x5f, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x0b, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x41, 0x6c, 0x70, 0x68, 0x61, 0x58, 0x12, 0x22, 0x0a,
+	0x0d, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x5f, 0x79, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x41, 0x6c, 0x70, 0x68, 0x61,
+	0x59, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x06, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x54, 0x22, 0xe0, 0x02, 0x0a, 0x16, 0x53,
+	0x69, 0x67, 0x6e, 0x43, 0x6f, 0x6e, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x19, 0x0a, 0x09, 0x62, 0x69, 0x67, 0x5f, 0x72, 0x5f, 0x69,
+	0x5f, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x62, 0x69, 0x67, 0x52, 0x49, 0x58,
+	0x12, 0x19, 0x0a, 0x09, 0x62, 0x69, 0x67, 0x5f, 0x72, 0x5f, 0x69, 0x5f, 0x79, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x06, 0x62, 0x69, 0x67, 0x52, 0x49, 0x59, 0x12, 0x19, 0x0a, 0x09, 0x62,
+	0x69, 0x67, 0x5f, 0x73, 0x5f, 0x69, 0x5f, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06,
+	0x62, 0x69, 0x67, 0x53, 0x49, 0x58, 0x12, 0x19, 0x0a, 0x09, 0x62, 0x69, 0x67, 0x5f, 0x73, 0x5f,
+	0x69, 0x5f, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x62, 0x69, 0x67, 0x53, 0x49,
+	0x59, 0x12, 0x27, 0x0a, 0x10, 0x73, 0x74, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x5f, 0x78, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x73, 0x74, 0x50,
+	0x72, 0x6f, 0x6f, 0x66, 0x41, 0x6c, 0x70, 0x68, 0x61, 0x58, 0x12, 0x27, 0x0a, 0x10, 0x73, 0x74,
+	0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x5f, 0x79, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x73, 0x74, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x41, 0x6c, 0x70,
+	0x68, 0x61, 0x59, 0x12, 0x25, 0x0a, 0x0f, 0x73, 0x74, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f,
+	0x62, 0x65, 0x74, 0x61, 0x5f, 0x78, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x73, 0x74,
+	0x50, 0x72, 0x6f, 0x6f, 0x66, 0x42, 0x65, 0x74, 0x61, 0x58, 0x12, 0x25, 0x0a, 0x0f, 0x73, 0x74,
+	0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x62, 0x65, 0x74, 0x61, 0x5f, 0x79, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x0c, 0x73, 0x74, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x42, 0x65, 0x74, 0x61,
+	0x59, 0x12, 0x1c, 0x0a, 0x0a, 0x73, 0x74, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x74, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x73, 0x74, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x54, 0x12,
+	0x1c, 0x0a, 0x0a, 0x73, 0x74, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x75, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x08, 0x73, 0x74, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x55, 0x22, 0x3a, 0x0a,
+	0x1b, 0x53, 0x69, 0x67, 0x6e, 0x43, 0x6f, 0x6e, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x79,
+	0x50, 0x72, 0x6f, 0x6f, 0x66, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1b, 0x0a, 0x09,
+	0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x70, 0x64, 0x6c, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52,
+	0x08, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x50, 0x64, 0x6c, 0x22, 0x33, 0x0a, 0x11, 0x53, 0x69, 0x67,
+	0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x35, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1e,
+	0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x9f,
+	0x02, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x36, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x65, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69,
+	0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0c, 0x64, 0x65, 0x43,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x70, 0x72, 0x6f,
+	0x6f, 0x66, 0x5f, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x5f, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x0b, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x41, 0x6c, 0x70, 0x68, 0x61, 0x58, 0x12, 0x22, 0x0a,
+	0x0d, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x5f, 0x79, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x41, 0x6c, 0x70, 0x68, 0x61,
+	0x59, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x06, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x54, 0x12, 0x25, 0x0a, 0x0f, 0x76, 0x5f,
+	0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x5f, 0x78, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x0c, 0x76, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x41, 0x6c, 0x70, 0x68, 0x61,
+	0x58, 0x12, 0x25, 0x0a, 0x0f, 0x76, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x5f, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x76, 0x50, 0x72, 0x6f,
+	0x6f, 0x66, 0x41, 0x6c, 0x70, 0x68, 0x61, 0x59, 0x12, 0x1a, 0x0a, 0x09, 0x76, 0x5f, 0x70, 0x72,
+	0x6f, 0x6f, 0x66, 0x5f, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x76, 0x50, 0x72,
+	0x6f, 0x6f, 0x66, 0x54, 0x12, 0x1a, 0x0a, 0x09, 0x76, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f,
+	0x75, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x76, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x55,
+	0x22, 0x33, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x37, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d,
+	0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69,
+	0x74, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x38, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75,
+	0x6e, 0x64, 0x38, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x65,
+	0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0c, 0x52, 0x0c, 0x64, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x22,
+	0x21, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x39, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x0c, 0x0a, 0x01, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x01, 0x73, 0x22, 0x49, 0x0a, 0x19, 0x53, 0x69, 0x67, 0x6e, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69,
+	0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x0c, 0x0a, 0x01, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x6c, 0x12, 0x10, 0x0a,
+	0x03, 0x72, 0x68, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x72, 0x68, 0x6f, 0x12,
+	0x0c, 0x0a, 0x01, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x73, 0x22, 0x48, 0x0a,
+	0x10, 0x53, 0x69, 0x67, 0x6e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0d, 0x52, 0x07, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x08, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x42, 0x0f, 0x5a, 0x0d, 0x65, 0x63, 0x64, 0x73, 0x61,
+	0x2f, 0x73, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x67, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -709,18 +1087,22 @@ func file_protob_ecdsa_signing_proto_rawDescGZIP() []byte {
 	return file_protob_ecdsa_signing_proto_rawDescData
 }
 
-var file_protob_ecdsa_signing_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_protob_ecdsa_signing_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_protob_ecdsa_signing_proto_goTypes = []interface{}{
-	(*SignRound1Message1)(nil), // 0: binance.tsslib.ecdsa.signing.SignRound1Message1
-	(*SignRound1Message2)(nil), // 1: binance.tsslib.ecdsa.signing.SignRound1Message2
-	(*SignRound2Message)(nil),  // 2: binance.tsslib.ecdsa.signing.SignRound2Message
-	(*SignRound3Message)(nil),  // 3: binance.tsslib.ecdsa.signing.SignRound3Message
-	(*SignRound4Message)(nil),  // 4: binance.tsslib.ecdsa.signing.SignRound4Message
-	(*SignRound5Message)(nil),  // 5: binance.tsslib.ecdsa.signing.SignRound5Message
-	(*SignRound6Message)(nil),  // 6: binance.tsslib.ecdsa.signing.SignRound6Message
-	(*SignRound7Message)(nil),  // 7: binance.tsslib.ecdsa.signing.SignRound7Message
-	(*SignRound8Message)(nil),  // 8: binance.tsslib.ecdsa.signing.SignRound8Message
-	(*SignRound9Message)(nil),  // 9: binance.tsslib.ecdsa.signing.SignRound9Message
+	(*SignRound1Message1)(nil),          // 0: binance.tsslib.ecdsa.signing.SignRound1Message1
+	(*SignRound1Message2)(nil),          // 1: binance.tsslib.ecdsa.signing.SignRound1Message2
+	(*SignRound2Message)(nil),           // 2: binance.tsslib.ecdsa.signing.SignRound2Message
+	(*SignRound3Message)(nil),           // 3: binance.tsslib.ecdsa.signing.SignRound3Message
+	(*SignRound4Message)(nil),           // 4: binance.tsslib.ecdsa.signing.SignRound4Message
+	(*SignConsistencyMessage)(nil),      // 5: binance.tsslib.ecdsa.signing.SignConsistencyMessage
+	(*SignConsistencyProofMessage)(nil), // 6: binance.tsslib.ecdsa.signing.SignConsistencyProofMessage
+	(*SignRound5Message)(nil),           // 7: binance.tsslib.ecdsa.signing.SignRound5Message
+	(*SignRound6Message)(nil),           // 8: binance.tsslib.ecdsa.signing.SignRound6Message
+	(*SignRound7Message)(nil),           // 9: binance.tsslib.ecdsa.signing.SignRound7Message
+	(*SignRound8Message)(nil),           // 10: binance.tsslib.ecdsa.signing.SignRound8Message
+	(*SignRound9Message)(nil),           // 11: binance.tsslib.ecdsa.signing.SignRound9Message
+	(*SignIdentificationMessage)(nil),   // 12: binance.tsslib.ecdsa.signing.SignIdentificationMessage
+	(*SignBatchMessage)(nil),            // 13: binance.tsslib.ecdsa.signing.SignBatchMessage
 }
 var file_protob_ecdsa_signing_proto_depIdxs = []int32{
 	0, // [0:0] is the sub-list for method output_type
@@ -797,7 +1179,7 @@ func file_protob_ecdsa_signing_proto_init() {
 			}
 		}
 		file_protob_ecdsa_signing_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SignRound5Message); i {
+			switch v := v.(*SignConsistencyMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -809,7 +1191,7 @@ func file_protob_ecdsa_signing_proto_init() {
 			}
 		}
 		file_protob_ecdsa_signing_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SignRound6Message); i {
+			switch v := v.(*SignConsistencyProofMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -821,7 +1203,7 @@ func file_protob_ecdsa_signing_proto_init() {
 			}
 		}
 		file_protob_ecdsa_signing_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SignRound7Message); i {
+			switch v := v.(*SignRound5Message); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -833,7 +1215,7 @@ func file_protob_ecdsa_signing_proto_init() {
 			}
 		}
 		file_protob_ecdsa_signing_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SignRound8Message); i {
+			switch v := v.(*SignRound6Message); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -845,6 +1227,30 @@ func file_protob_ecdsa_signing_proto_init() {
 			}
 		}
 		file_protob_ecdsa_signing_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignRound7Message); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_signing_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignRound8Message); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_signing_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SignRound9Message); i {
 			case 0:
 				return &v.state
@@ -856,6 +1262,30 @@ func file_protob_ecdsa_signing_proto_init() {
 				return nil
 			}
 		}
+		file_protob_ecdsa_signing_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignIdentificationMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_signing_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignBatchMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -863,7 +1293,7 @@ func file_protob_ecdsa_signing_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_protob_ecdsa_signing_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
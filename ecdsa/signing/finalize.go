@@ -9,7 +9,6 @@ package signing
 import (
 	"crypto/ecdsa"
 	"errors"
-	"fmt"
 	"math/big"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
@@ -24,6 +23,41 @@ func (round *finalization) Start() *tss.Error {
 	round.started = true
 	round.resetOK()
 
+	if !round.buildSignature() {
+		return round.startIdentification(causeSignatureInvalid)
+	}
+
+	round.end <- round.data
+
+	return nil
+}
+
+func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
+	if round.temp.identifyCause != "" {
+		return round.canAcceptIdentification(msg)
+	}
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *finalization) Update() (bool, *tss.Error) {
+	if round.temp.identifyCause != "" {
+		return round.updateIdentification()
+	}
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *finalization) NextRound() tss.Round {
+	if round.temp.identifyCause != "" {
+		round.started = false
+		return &identification{round}
+	}
+	return nil // finished!
+}
+
+// buildSignature combines the si of all parties into round.data and reports whether the signature is valid
+func (round *base) buildSignature() bool {
 	sumS := round.temp.si
 	modN := common.ModInt(round.Params().EC().Params().N)
 
@@ -36,60 +70,45 @@ func (round *finalization) Start() *tss.Error {
 		sumS = modN.Add(sumS, r9msg.UnmarshalS())
 	}
 
+	// r = R.x mod N; the recovery id records whether R.x was reduced, which can only happen on curves with p > N,
+	// and the parity of R.y: byte v = if(R.X >= curve.N) then 2 else 0) | (if R.Y.IsEven then 0 else 1);
+	N := round.Params().EC().Params().N
+	r := new(big.Int).Mod(round.temp.rx, N)
 	recid := 0
-	// byte v = if(R.X > curve.N) then 2 else 0) | (if R.Y.IsEven then 0 else 1);
-	if round.temp.rx.Cmp(round.Params().EC().Params().N) > 0 {
+	if round.temp.rx.Cmp(N) >= 0 {
 		recid = 2
 	}
 	if round.temp.ry.Bit(0) != 0 {
 		recid |= 1
 	}
 
-	// This is copied from:
+	// Output the low-S form unless it was disabled in the parameters. This is copied from:
 	// https://github.com/btcsuite/btcd/blob/c26ffa870fd817666a857af1bf6498fabba1ffe3/btcec/signature.go#L442-L444
 	// This is needed because of tendermint checks here:
 	// https://github.com/tendermint/tendermint/blob/d9481e3648450cb99e15c6a070c1fb69aa0c255b/crypto/secp256k1/secp256k1_nocgo.go#L43-L47
-	secp256k1halfN := new(big.Int).Rsh(round.Params().EC().Params().N, 1)
-	if sumS.Cmp(secp256k1halfN) > 0 {
-		sumS.Sub(round.Params().EC().Params().N, sumS)
+	halfN := new(big.Int).Rsh(N, 1)
+	if !round.Params().NoLowS() && sumS.Cmp(halfN) > 0 {
+		sumS.Sub(N, sumS)
 		recid ^= 1
 	}
 
 	// save the signature for final output
-	bitSizeInBytes := round.Params().EC().Params().BitSize / 8
-	round.data.R = padToLengthBytesInPlace(round.temp.rx.Bytes(), bitSizeInBytes)
+	bitSizeInBytes := (round.Params().EC().Params().BitSize + 7) / 8
+	round.data.R = padToLengthBytesInPlace(r.Bytes(), bitSizeInBytes)
 	round.data.S = padToLengthBytesInPlace(sumS.Bytes(), bitSizeInBytes)
 	round.data.Signature = append(round.data.R, round.data.S...)
 	round.data.SignatureRecovery = []byte{byte(recid)}
 	round.data.M = round.temp.m.Bytes()
+	if round.temp.digest != nil {
+		round.data.M = round.temp.digest
+	}
 
 	pk := ecdsa.PublicKey{
 		Curve: round.Params().EC(),
 		X:     round.key.ECDSAPub.X(),
 		Y:     round.key.ECDSAPub.Y(),
 	}
-	ok := ecdsa.Verify(&pk, round.temp.m.Bytes(), round.temp.rx, sumS)
-	if !ok {
-		return round.WrapError(fmt.Errorf("signature verification failed"))
-	}
-
-	round.end <- round.data
-
-	return nil
-}
-
-func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
-	// not expecting any incoming messages in this round
-	return false
-}
-
-func (round *finalization) Update() (bool, *tss.Error) {
-	// not expecting any incoming messages in this round
-	return false, nil
-}
-
-func (round *finalization) NextRound() tss.Round {
-	return nil // finished!
+	return ecdsa.Verify(&pk, round.temp.m.Bytes(), r, sumS)
 }
 
 func padToLengthBytesInPlace(src []byte, length int) []byte {
@@ -0,0 +1,251 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// The identification step runs when the U = T check of round 9 or the verification of the final signature fails.
+// Every party reveals si, and li and roi, the random values that blind si in Vi = R^si * g^li, Ai = g^roi,
+// Ui = V^roi and Ti = A^li. li and roi are chosen independently of the key and the nonce, and R^si = Ri^m * Si^r is
+// public once the presign checks of GG20 have passed in round 6, so revealing them after an abort is safe.
+// Every party can then check the revealed si against Ri and Si, and the values committed by the others in rounds 5-8.
+//
+// When every check passes, every other party holds a correct share of s, and U = T and the signature would have
+// verified had this party's own messages been received as it sent them; this party is then the culprit.
+// A party that does not reveal its values is left as the party that the round is waiting for, so a party started with
+// StartWithContext reports it as the culprit when the round times out.
+
+const (
+	causeUNotEqualT       = "U doesn't equal T"
+	causeSignatureInvalid = "signature verification failed"
+)
+
+// Evidence is the proof that a party deviated from the protocol, found in the identification step.
+type Evidence struct {
+	Culprit *tss.PartyID
+	// the check that failed
+	Reason string
+	// the share of s and the blinding values revealed by the culprit
+	S, L, Rho *big.Int
+}
+
+// IdentifiedAbortError is the cause of the error returned by a party whose signing was aborted with culprits
+// identified. Use errors.As on the *tss.Error to get it.
+type IdentifiedAbortError struct {
+	// the check that started the identification step
+	Cause    string
+	Evidence []*Evidence
+}
+
+func (e *IdentifiedAbortError) Error() string {
+	reasons := make([]string, 0, len(e.Evidence))
+	for _, ev := range e.Evidence {
+		reasons = append(reasons, fmt.Sprintf("%s: %s", ev.Culprit, ev.Reason))
+	}
+	return fmt.Sprintf("%s; identified misbehaving parties: %s", e.Cause, strings.Join(reasons, ", "))
+}
+
+func (round *base) startIdentification(cause string) *tss.Error {
+	common.Logger.Warningf("party %s: %s, starting identification", round.PartyID(), cause)
+	round.temp.identifyCause = cause
+	round.resetOK()
+	msg := NewSignIdentificationMessage(round.PartyID(), round.temp.li, round.temp.roi, round.temp.si)
+	round.temp.signIdentificationMessages[round.PartyID().Index] = msg
+	round.out <- msg
+	return nil
+}
+
+func (round *base) updateIdentification() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.signIdentificationMessages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.canAcceptIdentification(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *base) canAcceptIdentification(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignIdentificationMessage); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *identification) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 11
+	round.started = true
+
+	ec := round.Params().EC()
+	Ps := round.Parties().IDs()
+	i := round.PartyID().Index
+	bigVjs := make([]*crypto.ECPoint, len(Ps))
+	bigAjs := make([]*crypto.ECPoint, len(Ps))
+	bigUjs := make([]*crypto.ECPoint, len(Ps))
+	bigTjs := make([]*crypto.ECPoint, len(Ps))
+	bigVjs[i], bigAjs[i] = round.temp.bigVi, round.temp.bigAi
+	for j, Pj := range Ps {
+		if j == i {
+			continue
+		}
+		// these de-commitments were checked in rounds 7 and 9
+		r5msg := round.temp.signRound5Messages[j].Content().(*SignRound5Message)
+		r6msg := round.temp.signRound6Messages[j].Content().(*SignRound6Message)
+		VA, err := deCommitPoints(ec, r5msg.UnmarshalCommitment(), r6msg.UnmarshalDeCommitment())
+		if err != nil {
+			return round.WrapError(err, Pj)
+		}
+		bigVjs[j], bigAjs[j] = VA[0], VA[1]
+		r7msg := round.temp.signRound7Messages[j].Content().(*SignRound7Message)
+		r8msg := round.temp.signRound8Messages[j].Content().(*SignRound8Message)
+		UT, err := deCommitPointsNoCurveCheck(ec, r7msg.UnmarshalCommitment(), r8msg.UnmarshalDeCommitment())
+		if err != nil {
+			return round.WrapError(err, Pj)
+		}
+		bigUjs[j], bigTjs[j] = UT[0], UT[1]
+	}
+
+	// V = g^-m * y^-r * Prod(Vj) and A = Prod(Aj), as computed in round 7
+	modN := common.ModInt(ec.Params().N)
+	V, err := crypto.ScalarBaseMult(ec, modN.Sub(zero, round.temp.m)).Add(
+		round.key.ECDSAPub.ScalarMult(modN.Sub(zero, round.temp.rx)))
+	if err != nil {
+		return round.WrapError(err)
+	}
+	A := bigAjs[i]
+	for j := range Ps {
+		if V, err = V.Add(bigVjs[j]); err != nil {
+			return round.WrapError(err)
+		}
+		if j == i {
+			continue
+		}
+		if A, err = A.Add(bigAjs[j]); err != nil {
+			return round.WrapError(err)
+		}
+	}
+
+	// the si of round 9 are checked if they were revealed, as they are the ones that made the signature invalid
+	fromRound9 := round.temp.identifyCause == causeSignatureInvalid
+	R := round.temp.bigR
+	culprits := make([]*tss.PartyID, 0, len(Ps))
+	evidence := make([]*Evidence, 0, len(Ps))
+	for j, Pj := range Ps {
+		if j == i {
+			continue
+		}
+		idMsg := round.temp.signIdentificationMessages[j].Content().(*SignIdentificationMessage)
+		sj, lj, rhoj := idMsg.UnmarshalS(), idMsg.UnmarshalL(), idMsg.UnmarshalRho()
+		if fromRound9 {
+			sj = round.temp.signRound9Messages[j].Content().(*SignRound9Message).UnmarshalS()
+		}
+		reason := ""
+		switch {
+		case !checkShare(R, round.temp.bigRis[j], round.temp.bigSis[j], round.temp.m, round.temp.rx, sj):
+			reason = "R^sj != Rj^m * Sj^r"
+		case !crypto.ScalarBaseMult(ec, rhoj).Equals(bigAjs[j]):
+			reason = "Aj != g^rhoj"
+		case !V.ScalarMult(rhoj).Equals(bigUjs[j]):
+			reason = "Uj != V^rhoj"
+		case !A.ScalarMult(lj).Equals(bigTjs[j]):
+			reason = "Tj != A^lj"
+		default:
+			RSj, err := R.ScalarMult(sj).Add(crypto.ScalarBaseMult(ec, lj))
+			if err != nil || !RSj.Equals(bigVjs[j]) {
+				reason = "Vj != R^sj * g^lj"
+			}
+		}
+		if reason == "" {
+			continue
+		}
+		culprits = append(culprits, Pj)
+		evidence = append(evidence, &Evidence{Culprit: Pj, Reason: reason, S: sj, L: lj, Rho: rhoj})
+	}
+	if len(culprits) == 0 {
+		Pi := round.PartyID()
+		culprits = append(culprits, Pi)
+		evidence = append(evidence, &Evidence{
+			Culprit: Pi,
+			Reason:  "every other party passed the checks",
+			S:       round.temp.si,
+			L:       round.temp.li,
+			Rho:     round.temp.roi,
+		})
+	}
+	return round.WrapError(&IdentifiedAbortError{Cause: round.temp.identifyCause, Evidence: evidence}, culprits...)
+}
+
+func (round *identification) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *identification) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *identification) NextRound() tss.Round {
+	return nil // aborted
+}
+
+// deCommitPoints opens a commitment to two EC points
+func deCommitPoints(ec elliptic.Curve, c commitments.HashCommitment, d commitments.HashDeCommitment) ([]*crypto.ECPoint, error) {
+	values, err := deCommitTwoPoints(c, d)
+	if err != nil {
+		return nil, err
+	}
+	first, err := crypto.NewECPoint(ec, values[0], values[1])
+	if err != nil {
+		return nil, err
+	}
+	second, err := crypto.NewECPoint(ec, values[2], values[3])
+	if err != nil {
+		return nil, err
+	}
+	return []*crypto.ECPoint{first, second}, nil
+}
+
+// deCommitPointsNoCurveCheck is like deCommitPoints for the points of round 7, which are not checked to be on the curve
+func deCommitPointsNoCurveCheck(ec elliptic.Curve, c commitments.HashCommitment, d commitments.HashDeCommitment) ([]*crypto.ECPoint, error) {
+	values, err := deCommitTwoPoints(c, d)
+	if err != nil {
+		return nil, err
+	}
+	return []*crypto.ECPoint{
+		crypto.NewECPointNoCurveCheck(ec, values[0], values[1]),
+		crypto.NewECPointNoCurveCheck(ec, values[2], values[3]),
+	}, nil
+}
+
+func deCommitTwoPoints(c commitments.HashCommitment, d commitments.HashDeCommitment) ([]*big.Int, error) {
+	cmtDeCmt := commitments.HashCommitDecommit{C: c, D: d}
+	ok, values := cmtDeCmt.DeCommit()
+	if !ok || len(values) != 4 {
+		return nil, errors.New("de-commitment failed")
+	}
+	return values, nil
+}
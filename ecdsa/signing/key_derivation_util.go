@@ -37,23 +37,18 @@ func UpdatePublicKeyAndAdjustBigXj(keyDerivationDelta *big.Int, keys []keygen.Lo
 	return nil
 }
 
-func derivingPubkeyFromPath(masterPub *crypto.ECPoint, chainCode []byte, path []uint32, ec elliptic.Curve) (*big.Int, *ckd.ExtendedKey, error) {
-	// build ecdsa key pair
-	pk := ecdsa.PublicKey{
-		Curve: ec,
-		X:     masterPub.X(),
-		Y:     masterPub.Y(),
+// DerivingPubkeyFromPath derives the child key at `path` from the master public key of a threshold key and its chain
+// code. It returns the key derivation delta to give to NewLocalPartyWithKDD and the child key, which is serialized with
+// the version bytes of `net`, e.g. &chaincfg.TestNet3Params; the delta is the same on every network.
+func DerivingPubkeyFromPath(masterPub *crypto.ECPoint, chainCode []byte, path []uint32, net *chaincfg.Params) (*big.Int, *ckd.ExtendedKey, error) {
+	version, err := ckd.PublicKeyVersion(net, ckd.FormatLegacy)
+	if err != nil {
+		return nil, nil, err
 	}
-
-	net := &chaincfg.MainNetParams
-	extendedParentPk := &ckd.ExtendedKey{
-		PublicKey:  pk,
-		Depth:      0,
-		ChildIndex: 0,
-		ChainCode:  chainCode[:],
-		ParentFP:   []byte{0x00, 0x00, 0x00, 0x00},
-		Version:    net.HDPrivateKeyID[:],
+	extendedParentPk, err := ckd.NewExtendedPublicKey(masterPub, chainCode, version)
+	if err != nil {
+		return nil, nil, err
 	}
-
+	ec := masterPub.Curve()
 	return ckd.DeriveChildKeyFromHierarchy(path, extendedParentPk, ec.Params().N, ec)
 }
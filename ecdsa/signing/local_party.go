@@ -7,6 +7,7 @@
 package signing
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math/big"
@@ -34,8 +35,9 @@ type (
 		data *common.SignatureData
 
 		// outbound messaging
-		out chan<- tss.Message
-		end chan<- *common.SignatureData
+		out        chan<- tss.Message
+		end        chan<- *common.SignatureData
+		presignEnd chan<- *PreSignatureData
 	}
 
 	localMessageStore struct {
@@ -44,11 +46,14 @@ type (
 		signRound2Messages,
 		signRound3Messages,
 		signRound4Messages,
+		signConsistencyMessages,
+		signConsistencyProofMessages,
 		signRound5Messages,
 		signRound6Messages,
 		signRound7Messages,
 		signRound8Messages,
-		signRound9Messages []tss.ParsedMessage
+		signRound9Messages,
+		signIdentificationMessages []tss.ParsedMessage
 	}
 
 	localTempData struct {
@@ -63,7 +68,8 @@ type (
 		sigma,
 		keyDerivationDelta,
 		gamma *big.Int
-		cis        []*big.Int
+		cis,
+		cRandomness []*big.Int // the Paillier randomness of cis
 		bigWs      []*crypto.ECPoint
 		pointGamma *crypto.ECPoint
 		deCommit   cmt.HashDeCommitment
@@ -76,6 +82,14 @@ type (
 		pi1jis []*mta.ProofBob
 		pi2jis []*mta.ProofBobWC
 
+		// round 3; the commitment Tj = g^sigmaj h^lj of every party and the blinding li of Ti
+		bigTis        []*crypto.ECPoint
+		sigmaBlinding *big.Int
+
+		// presign checks; R^kj and R^sigmaj of every party
+		bigRis,
+		bigSis []*crypto.ECPoint
+
 		// round 5
 		li,
 		si,
@@ -92,8 +106,23 @@ type (
 		Ti *crypto.ECPoint
 		DTelda cmt.HashDeCommitment
 
+		// identification; the check that failed, or empty
+		identifyCause string
+
 		ssidNonce *big.Int
 		ssid      []byte
+
+		// the index of the digest when signing a batch, which separates the sessions of the batch
+		batchIndex int
+		// whether the session is part of a batch, whose round proves and verifies the range proofs of round 1 for all
+		// of its sessions at once
+		batched bool
+
+		// the message given to NewLocalPartyFromBytes and its hash; the digest is output in SignatureData.M with its
+		// leading zeros
+		message []byte
+		hash    Hash
+		digest  []byte
 	}
 )
 
@@ -115,15 +144,58 @@ func NewLocalPartyWithKDD(
 	out chan<- tss.Message,
 	end chan<- *common.SignatureData,
 ) tss.Party {
+	return newLocalParty(msg, params, key, keyDerivationDelta, out, end, nil)
+}
+
+// NewLocalPartyFromBytes returns a party that signs the digest of `msg` with `hash`; with RawDigest, `msg` is the digest.
+// The digest is converted to an integer as in FIPS 186-4, so a digest longer than the order of the curve is truncated to
+// its leftmost bits, and SignatureData.M holds the digest as it was before truncation.
+func NewLocalPartyFromBytes(
+	msg []byte,
+	hash Hash,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+) tss.Party {
+	return NewLocalPartyFromBytesWithKDD(msg, hash, params, key, nil, out, end)
+}
+
+// NewLocalPartyFromBytesWithKDD returns a party that signs the digest of `msg`, with key derivation delta for HD support
+func NewLocalPartyFromBytesWithKDD(
+	msg []byte,
+	hash Hash,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	keyDerivationDelta *big.Int,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+) tss.Party {
+	p := newLocalParty(nil, params, key, keyDerivationDelta, out, end, nil)
+	p.temp.message = append([]byte{}, msg...)
+	p.temp.hash = hash
+	return p
+}
+
+func newLocalParty(
+	msg *big.Int,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	keyDerivationDelta *big.Int,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+	presignEnd chan<- *PreSignatureData,
+) *LocalParty {
 	partyCount := len(params.Parties().IDs())
 	p := &LocalParty{
-		BaseParty: new(tss.BaseParty),
-		params:    params,
-		keys:      keygen.BuildLocalSaveDataSubset(key, params.Parties().IDs()),
-		temp:      localTempData{},
-		data:      &common.SignatureData{},
-		out:       out,
-		end:       end,
+		BaseParty:  new(tss.BaseParty),
+		params:     params,
+		keys:       keygen.BuildLocalSaveDataSubset(key, params.Parties().IDs()),
+		temp:       localTempData{},
+		data:       &common.SignatureData{},
+		out:        out,
+		end:        end,
+		presignEnd: presignEnd,
 	}
 	// msgs init
 	p.temp.signRound1Message1s = make([]tss.ParsedMessage, partyCount)
@@ -131,15 +203,19 @@ func NewLocalPartyWithKDD(
 	p.temp.signRound2Messages = make([]tss.ParsedMessage, partyCount)
 	p.temp.signRound3Messages = make([]tss.ParsedMessage, partyCount)
 	p.temp.signRound4Messages = make([]tss.ParsedMessage, partyCount)
+	p.temp.signConsistencyMessages = make([]tss.ParsedMessage, partyCount)
+	p.temp.signConsistencyProofMessages = make([]tss.ParsedMessage, partyCount)
 	p.temp.signRound5Messages = make([]tss.ParsedMessage, partyCount)
 	p.temp.signRound6Messages = make([]tss.ParsedMessage, partyCount)
 	p.temp.signRound7Messages = make([]tss.ParsedMessage, partyCount)
 	p.temp.signRound8Messages = make([]tss.ParsedMessage, partyCount)
 	p.temp.signRound9Messages = make([]tss.ParsedMessage, partyCount)
+	p.temp.signIdentificationMessages = make([]tss.ParsedMessage, partyCount)
 	// temp data init
 	p.temp.keyDerivationDelta = keyDerivationDelta
 	p.temp.m = msg
 	p.temp.cis = make([]*big.Int, partyCount)
+	p.temp.cRandomness = make([]*big.Int, partyCount)
 	p.temp.bigWs = make([]*crypto.ECPoint, partyCount)
 	p.temp.betas = make([]*big.Int, partyCount)
 	p.temp.c1jis = make([]*big.Int, partyCount)
@@ -147,24 +223,33 @@ func NewLocalPartyWithKDD(
 	p.temp.pi1jis = make([]*mta.ProofBob, partyCount)
 	p.temp.pi2jis = make([]*mta.ProofBobWC, partyCount)
 	p.temp.vs = make([]*big.Int, partyCount)
+	p.temp.bigTis = make([]*crypto.ECPoint, partyCount)
+	p.temp.bigRis = make([]*crypto.ECPoint, partyCount)
+	p.temp.bigSis = make([]*crypto.ECPoint, partyCount)
 	return p
 }
 
 func (p *LocalParty) FirstRound() tss.Round {
-	return newRound1(p.params, &p.keys, p.data, &p.temp, p.out, p.end)
+	return newRound1(p.params, &p.keys, p.data, &p.temp, p.out, p.end, p.presignEnd)
 }
 
 func (p *LocalParty) Start() *tss.Error {
-	return tss.BaseStart(p, TaskName, func(round tss.Round) *tss.Error {
-		round1, ok := round.(*round1)
-		if !ok {
-			return round.WrapError(errors.New("unable to Start(). party is in an unexpected round"))
-		}
-		if err := round1.prepare(); err != nil {
-			return round.WrapError(err)
-		}
-		return nil
-	})
+	return tss.BaseStart(p, TaskName, p.prepare)
+}
+
+func (p *LocalParty) StartWithContext(ctx context.Context, errCh chan<- *tss.Error) *tss.Error {
+	return tss.BaseStartWithContext(ctx, p, TaskName, errCh, p.prepare)
+}
+
+func (p *LocalParty) prepare(round tss.Round) *tss.Error {
+	round1, ok := round.(*round1)
+	if !ok {
+		return round.WrapError(errors.New("unable to Start(). party is in an unexpected round"))
+	}
+	if err := round1.prepare(); err != nil {
+		return round.WrapError(err)
+	}
+	return nil
 }
 
 func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
@@ -211,6 +296,10 @@ func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
 		p.temp.signRound3Messages[fromPIdx] = msg
 	case *SignRound4Message:
 		p.temp.signRound4Messages[fromPIdx] = msg
+	case *SignConsistencyMessage:
+		p.temp.signConsistencyMessages[fromPIdx] = msg
+	case *SignConsistencyProofMessage:
+		p.temp.signConsistencyProofMessages[fromPIdx] = msg
 	case *SignRound5Message:
 		p.temp.signRound5Messages[fromPIdx] = msg
 	case *SignRound6Message:
@@ -221,6 +310,8 @@ func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
 		p.temp.signRound8Messages[fromPIdx] = msg
 	case *SignRound9Message:
 		p.temp.signRound9Messages[fromPIdx] = msg
+	case *SignIdentificationMessage:
+		p.temp.signIdentificationMessages[fromPIdx] = msg
 	default: // unrecognised message, just ignore!
 		common.Logger.Warningf("unrecognised message ignored: %v", msg)
 		return false, nil
@@ -7,18 +7,31 @@
 package signing
 
 import (
+	"bytes"
 	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 	"runtime"
+	"strings"
 	"sync/atomic"
 	"testing"
 
 	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/ipfs/go-log"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	cmt "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/schnorr"
 	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
 	"github.com/bnb-chain/tss-lib/v2/test"
 	"github.com/bnb-chain/tss-lib/v2/tss"
@@ -131,6 +144,388 @@ signing:
 	}
 }
 
+func TestE2EBatchConcurrent(t *testing.T) {
+	setUp("info")
+	threshold := testThreshold
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	msgs := []*big.Int{big.NewInt(42), big.NewInt(43), big.NewInt(44)}
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*BatchParty, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endChs := make([]chan *common.SignatureData, len(signPIDs))
+
+	updater := test.SharedPartyUpdater
+
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		endChs[i] = make(chan *common.SignatureData, len(msgs))
+		P := NewBatchParty(msgs, params, keys[i], outCh, endChs[i]).(*BatchParty)
+		parties = append(parties, P)
+		go func(P *BatchParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	pk := ecdsa.PublicKey{
+		Curve: tss.EC(),
+		X:     keys[0].ECDSAPub.X(),
+		Y:     keys[0].ECDSAPub.Y(),
+	}
+	// collect the signatures of each party, which arrive in the order of the digests
+	type partySigs struct {
+		index int
+		sigs  []*common.SignatureData
+	}
+	doneCh := make(chan partySigs, len(signPIDs))
+	for i, endCh := range endChs {
+		go func(i int, endCh chan *common.SignatureData) {
+			sigs := make([]*common.SignatureData, len(msgs))
+			for k := range sigs {
+				sigs[k] = <-endCh
+			}
+			doneCh <- partySigs{i, sigs}
+		}(i, endCh)
+	}
+
+	sigs := make([][]*common.SignatureData, len(signPIDs))
+	for ended := 0; ended < len(signPIDs); {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+
+		case msg := <-outCh:
+			// every message of a batch party is a SignBatchMessage
+			_, ok := msg.(tss.ParsedMessage).Content().(*SignBatchMessage)
+			assert.True(t, ok, "batch parties should only send batch messages")
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case done := <-doneCh:
+			sigs[done.index] = done.sigs
+			ended++
+		}
+	}
+
+	for k, msg := range msgs {
+		r, s := new(big.Int).SetBytes(sigs[0][k].R), new(big.Int).SetBytes(sigs[0][k].S)
+		assert.True(t, ecdsa.Verify(&pk, msg.Bytes(), r, s), "ecdsa verify must pass for digest %d", k)
+		assert.Equal(t, msg.Bytes(), sigs[0][k].M)
+		for i := range sigs {
+			assert.Equal(t, sigs[0][k].Signature, sigs[i][k].Signature, "the parties should agree on signature %d", k)
+		}
+	}
+	// the digests were signed with different nonces
+	assert.NotEqual(t, sigs[0][0].R, sigs[0][1].R)
+}
+
+func TestBatchInvalidDigests(t *testing.T) {
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[0], len(signPIDs), testThreshold)
+
+	for _, msgs := range [][]*big.Int{nil, {}, {big.NewInt(42), nil}} {
+		outCh := make(chan tss.Message, 2*len(signPIDs))
+		endCh := make(chan *common.SignatureData, 1)
+		P := NewBatchParty(msgs, params, keys[0], outCh, endCh)
+		assert.Error(t, P.Start(), "a batch without digests or with a nil digest should not start")
+		assert.Empty(t, outCh, "no message should be sent")
+	}
+}
+
+func TestE2EBatchForgedRangeProof(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	msgs := []*big.Int{big.NewInt(42), big.NewInt(43)}
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*BatchParty, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+
+	updater := test.SharedPartyUpdater
+
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		P := NewBatchParty(msgs, params, keys[i], outCh, make(chan *common.SignatureData, len(msgs))).(*BatchParty)
+		parties = append(parties, P)
+		go func(P *BatchParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	// party 0 sends party 1 the range proof of its first session in place of that of the second one
+	forger, victim := signPIDs[0], signPIDs[1]
+	for {
+		select {
+		case err := <-errCh:
+			assert.Contains(t, err.Cause().Error(), "batched range proofs")
+			assert.Equal(t, victim.Index, err.Victim().Index)
+			if assert.Equal(t, 1, len(err.Culprits())) {
+				assert.Equal(t, forger.Index, err.Culprits()[0].Index)
+			}
+			return
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+				continue
+			}
+			if msg.GetFrom().Index == forger.Index && dest[0].Index == victim.Index {
+				msg = forgeBatchRangeProof(t, msg.(tss.ParsedMessage))
+			}
+			go updater(parties[dest[0].Index], msg, errCh)
+		}
+	}
+}
+
+// forgeBatchRangeProof replaces the range proof of the second session in a batch of SignRound1Message1s with that of
+// the first one
+func forgeBatchRangeProof(t *testing.T, msg tss.ParsedMessage) tss.ParsedMessage {
+	batch := msg.Content().(*SignBatchMessage)
+	r1msgs := make([]*SignRound1Message1, len(batch.GetMessages()))
+	for n, bz := range batch.GetMessages() {
+		sessionMsg, err := tss.ParseWireMessage(bz, msg.GetFrom(), msg.IsBroadcast())
+		assert.NoError(t, err)
+		r1msg, ok := sessionMsg.Content().(*SignRound1Message1)
+		if !ok {
+			return msg
+		}
+		r1msgs[n] = r1msg
+	}
+	pf, err := r1msgs[0].UnmarshalRangeProofAlice()
+	assert.NoError(t, err)
+	forged, _, err := NewSignRound1Message1(msg.GetTo()[0], msg.GetFrom(), r1msgs[1].UnmarshalC(), pf).WireBytes()
+	assert.NoError(t, err)
+	messages := append([][]byte{}, batch.GetMessages()...)
+	messages[1] = forged
+	return NewSignBatchMessage(msg.GetFrom(), msg.GetTo(), false, batch.GetIndexes(), messages)
+}
+
+func TestE2EMessageBytes(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	// a 64-byte digest that starts with a zero byte is truncated to its leftmost 256 bits
+	digest := sha512.Sum512([]byte("hello"))
+	digest[0] = 0
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		P := NewLocalPartyFromBytes(digest[:], RawDigest, params, keys[i], outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	sigs := make([]*common.SignatureData, 0, len(signPIDs))
+	for len(sigs) < len(signPIDs) {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go test.SharedPartyUpdater(P, msg, errCh)
+				}
+			} else {
+				go test.SharedPartyUpdater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case sig := <-endCh:
+			sigs = append(sigs, sig)
+		}
+	}
+
+	pk := ecdsa.PublicKey{
+		Curve: tss.EC(),
+		X:     keys[0].ECDSAPub.X(),
+		Y:     keys[0].ECDSAPub.Y(),
+	}
+	for _, sig := range sigs {
+		assert.Equal(t, digest[:], sig.M)
+		r, s := new(big.Int).SetBytes(sig.R), new(big.Int).SetBytes(sig.S)
+		assert.True(t, ecdsa.Verify(&pk, digest[:], r, s), "ecdsa verify must pass")
+	}
+}
+
+func TestE2EMessageBytesInvalid(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	params := tss.NewParameters(tss.S256(), tss.NewPeerContext(signPIDs), signPIDs[0], len(signPIDs), testThreshold)
+	for _, hash := range []Hash{RawDigest, Hash(5)} {
+		P := NewLocalPartyFromBytes(nil, hash, params, keys[0], make(chan tss.Message, len(signPIDs)), nil)
+		assert.Error(t, P.Start(), "an empty digest or an unknown hash should be rejected")
+	}
+}
+
+func TestHashDigest(t *testing.T) {
+	for _, tc := range []struct {
+		hash   Hash
+		msg    string
+		digest string
+	}{
+		{SHA256, "abc", "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"},
+		{SHA512, "abc", "ddaf35a193617abacc417349ae20413112e6fa4e89a97ea20a9eeee64b55d39a2192992a274fc1a836ba3c23a3feebbd454d4423643ce80e2a9ac94fa54ca49f"},
+		{Keccak256, "", "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470"},
+		{DoubleSHA256, "hello", "9595c9df90075148eb06860365df33584b75bff782a510c6cd4883a419833d50"},
+		{RawDigest, "\x00\x01", "0001"},
+	} {
+		digest, err := tc.hash.Digest([]byte(tc.msg))
+		assert.NoError(t, err)
+		assert.Equal(t, tc.digest, hex.EncodeToString(digest), "%s", tc.hash)
+	}
+}
+
+func TestHashToInt(t *testing.T) {
+	ec := tss.S256()
+	// a digest of the size of the order is only reduced
+	digest := bytes.Repeat([]byte{0xff}, 32)
+	assert.Equal(t, new(big.Int).Mod(new(big.Int).SetBytes(digest), ec.Params().N), HashToInt(ec, digest))
+	assert.Equal(t, big.NewInt(1), HashToInt(ec, []byte{0, 1}))
+	// a longer digest is truncated to the leftmost 256 bits
+	digest = append(bytes.Repeat([]byte{0}, 31), 0x01, 0xff, 0xff)
+	assert.Equal(t, big.NewInt(1), HashToInt(ec, digest))
+	// on a curve with an order of 521 bits, the leftmost 521 bits of a 66-byte digest are kept
+	digest = bytes.Repeat([]byte{0}, 66)
+	digest[65] = 0x80
+	assert.Equal(t, big.NewInt(1), HashToInt(elliptic.P521(), digest))
+}
+
+func TestE2ENISTCurves(t *testing.T) {
+	setUp("info")
+
+	for _, tc := range []struct {
+		ec      elliptic.Curve
+		hash    Hash
+		noLowS  bool
+		digestF func([]byte) []byte
+	}{
+		{tss.P256(), SHA256, false, func(msg []byte) []byte { d := sha256.Sum256(msg); return d[:] }},
+		{tss.P256(), SHA256, true, func(msg []byte) []byte { d := sha256.Sum256(msg); return d[:] }},
+		{tss.P384(), SHA512, false, func(msg []byte) []byte { d := sha512.Sum512(msg); return d[:] }},
+	} {
+		keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSetForCurve(tc.ec, testThreshold+1, testParticipants)
+		if !assert.NoError(t, err, "should load keygen fixtures") {
+			return
+		}
+		msg := []byte("a message for a NIST curve")
+
+		p2pCtx := tss.NewPeerContext(signPIDs)
+		parties := make([]*LocalParty, 0, len(signPIDs))
+
+		errCh := make(chan *tss.Error, len(signPIDs))
+		outCh := make(chan tss.Message, len(signPIDs))
+		endCh := make(chan *common.SignatureData, len(signPIDs))
+
+		for i := 0; i < len(signPIDs); i++ {
+			params := tss.NewParameters(tc.ec, p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+			if tc.noLowS {
+				params.SetNoLowS()
+			}
+			P := NewLocalPartyFromBytes(msg, tc.hash, params, keys[i], outCh, endCh).(*LocalParty)
+			parties = append(parties, P)
+			go func(P *LocalParty) {
+				if err := P.Start(); err != nil {
+					errCh <- err
+				}
+			}(P)
+		}
+
+		sigs := make([]*common.SignatureData, 0, len(signPIDs))
+		for len(sigs) < len(signPIDs) {
+			select {
+			case err := <-errCh:
+				assert.FailNow(t, err.Error())
+
+			case msg := <-outCh:
+				dest := msg.GetTo()
+				if dest == nil {
+					for _, P := range parties {
+						if P.PartyID().Index == msg.GetFrom().Index {
+							continue
+						}
+						go test.SharedPartyUpdater(P, msg, errCh)
+					}
+				} else {
+					go test.SharedPartyUpdater(parties[dest[0].Index], msg, errCh)
+				}
+
+			case sig := <-endCh:
+				sigs = append(sigs, sig)
+			}
+		}
+
+		pk := ecdsa.PublicKey{
+			Curve: tc.ec,
+			X:     keys[0].ECDSAPub.X(),
+			Y:     keys[0].ECDSAPub.Y(),
+		}
+		size := (tc.ec.Params().BitSize + 7) / 8
+		s := new(big.Int).SetBytes(sigs[0].S)
+		halfN := new(big.Int).Rsh(tc.ec.Params().N, 1)
+		for _, sig := range sigs {
+			assert.Equal(t, sigs[0].Signature, sig.Signature, "the parties should agree on the signature")
+			assert.Len(t, sig.Signature, 2*size)
+			r := new(big.Int).SetBytes(sig.R)
+			assert.True(t, ecdsa.Verify(&pk, tc.digestF(msg), r, s), "ecdsa verify must pass")
+		}
+		if !tc.noLowS {
+			assert.True(t, s.Cmp(halfN) <= 0, "s should be low")
+		} else {
+			// the low-S form is the same signature
+			assert.True(t, ecdsa.Verify(&pk, tc.digestF(msg), new(big.Int).SetBytes(sigs[0].R), new(big.Int).Sub(tc.ec.Params().N, s)))
+		}
+	}
+}
+
 func TestE2EWithHDKeyDerivation(t *testing.T) {
 	setUp("info")
 	threshold := testThreshold
@@ -146,8 +541,12 @@ func TestE2EWithHDKeyDerivation(t *testing.T) {
 	max32b = new(big.Int).Sub(max32b, new(big.Int).SetUint64(1))
 	fillBytes(common.GetRandomPositiveInt(max32b), chainCode)
 
-	il, extendedChildPk, errorDerivation := derivingPubkeyFromPath(keys[0].ECDSAPub, chainCode, []uint32{12, 209, 3}, btcec.S256())
+	il, extendedChildPk, errorDerivation := DerivingPubkeyFromPath(keys[0].ECDSAPub, chainCode, []uint32{12, 209, 3}, &chaincfg.TestNet3Params)
 	assert.NoErrorf(t, errorDerivation, "there should not be an error deriving the child public key")
+	assert.True(t, strings.HasPrefix(extendedChildPk.String(), "tpub"), "the child key should be serialized for testnet")
+	mainnetIl, _, err := DerivingPubkeyFromPath(keys[0].ECDSAPub, chainCode, []uint32{12, 209, 3}, &chaincfg.MainNetParams)
+	assert.NoError(t, err)
+	assert.Equal(t, mainnetIl, il, "the key derivation delta should not depend on the network")
 
 	keyDerivationDelta := il
 
@@ -239,6 +638,511 @@ signing:
 	}
 }
 
+func TestE2ESnapshotAndRestore(t *testing.T) {
+	setUp("info")
+	threshold := testThreshold
+
+	// PHASE: load keygen fixtures
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	// PHASE: signing
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	params := make([]*tss.Parameters, 0, len(signPIDs))
+	parties := make([]tss.Party, 0, len(signPIDs))
+
+	// messages are routed synchronously below, so the channels must be able to hold everything that is produced
+	errCh := make(chan *tss.Error, len(signPIDs)*len(signPIDs)*10)
+	outCh := make(chan tss.Message, len(signPIDs)*len(signPIDs)*10)
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+
+	key := make([]byte, tss.SnapshotKeyLength)
+	_, err = rand.Read(key)
+	assert.NoError(t, err)
+
+	for i := 0; i < len(signPIDs); i++ {
+		params = append(params, tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), threshold))
+		P := NewLocalParty(big.NewInt(42), params[i], keys[i], outCh, endCh)
+		parties = append(parties, P)
+		if err := P.Start(); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+	}
+
+	// snapshot and restore every party regularly, so that the state of each round goes through a snapshot
+	routed := 0
+	sigs := make([]*common.SignatureData, 0, len(signPIDs))
+	for len(sigs) < len(signPIDs) {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case msg := <-outCh:
+			if dest := msg.GetTo(); dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index != msg.GetFrom().Index {
+						test.SharedPartyUpdater(P, msg, errCh)
+					}
+				}
+			} else {
+				test.SharedPartyUpdater(parties[dest[0].Index], msg, errCh)
+			}
+			if routed++; routed%2 != 0 {
+				continue
+			}
+			for i, P := range parties {
+				if !P.Running() {
+					continue // finished
+				}
+				snapshot, err := P.Snapshot(key)
+				if err != nil {
+					assert.FailNow(t, err.Error())
+				}
+				restored, err := RestoreLocalParty(params[i], snapshot, key, outCh, endCh)
+				if err != nil {
+					assert.FailNow(t, err.Error())
+				}
+				parties[i] = restored
+			}
+		case sig := <-endCh:
+			sigs = append(sigs, sig)
+		}
+	}
+
+	pk := ecdsa.PublicKey{
+		Curve: tss.EC(),
+		X:     keys[0].ECDSAPub.X(),
+		Y:     keys[0].ECDSAPub.Y(),
+	}
+	for _, sig := range sigs {
+		assert.Equal(t, sigs[0].Signature, sig.Signature)
+		ok := ecdsa.Verify(&pk, big.NewInt(42).Bytes(), new(big.Int).SetBytes(sig.R), new(big.Int).SetBytes(sig.S))
+		assert.True(t, ok, "ecdsa verify must pass")
+	}
+}
+
+func TestE2EIdentifyWrongS(t *testing.T) {
+	setUp("info")
+
+	// party 0 reveals an si in round 9 that does not match the one committed to in Vi
+	errs := runSigningWithTampering(t, func(parties []*LocalParty, msg tss.ParsedMessage) tss.ParsedMessage {
+		r9msg, ok := msg.Content().(*SignRound9Message)
+		if !ok {
+			return msg
+		}
+		return NewSignRound9Message(msg.GetFrom(), new(big.Int).Add(r9msg.UnmarshalS(), big.NewInt(1)))
+	})
+	assertIdentified(t, errs, causeSignatureInvalid, "R^sj != Rj^m * Sj^r")
+}
+
+func TestE2EIdentifyWrongSConsistentWithV(t *testing.T) {
+	setUp("info")
+
+	// party 0 computes a wrong si in round 5 and commits to it consistently in Vi, so that every proof and
+	// de-commitment of rounds 6-8 holds and only the check against R0 and S0 catches it
+	errs := runSigningWithTampering(t, func(parties []*LocalParty, msg tss.ParsedMessage) tss.ParsedMessage {
+		if _, ok := msg.Content().(*SignRound5Message); !ok {
+			return msg
+		}
+		P := parties[0]
+		N := tss.EC().Params().N
+		wrongSi := new(big.Int).Mod(new(big.Int).Add(P.temp.si, big.NewInt(1)), N)
+		bigVi, err := P.temp.bigR.ScalarMult(wrongSi).Add(crypto.ScalarBaseMult(tss.EC(), P.temp.li))
+		assert.NoError(t, err)
+		commitment := cmt.NewHashCommitment(bigVi.X(), bigVi.Y(), P.temp.bigAi.X(), P.temp.bigAi.Y())
+		P.temp.si, P.temp.bigVi, P.temp.DPower = wrongSi, bigVi, commitment.D
+		return NewSignRound5Message(msg.GetFrom(), commitment.C)
+	})
+	assertIdentified(t, errs, causeUNotEqualT, "R^sj != Rj^m * Sj^r")
+}
+
+func TestE2EIdentifyWrongT(t *testing.T) {
+	setUp("info")
+
+	// party 0 commits to a Ti that is not A^li in round 7, so that U = T fails for everyone else
+	var deCommit cmt.HashDeCommitment
+	errs := runSigningWithTampering(t, func(parties []*LocalParty, msg tss.ParsedMessage) tss.ParsedMessage {
+		switch msg.Content().(type) {
+		case *SignRound7Message:
+			Ui, Ti := parties[0].temp.Ui, parties[0].temp.Ti
+			wrongTi, err := Ti.Add(crypto.ScalarBaseMult(tss.EC(), big.NewInt(1)))
+			assert.NoError(t, err)
+			commitment := cmt.NewHashCommitment(Ui.X(), Ui.Y(), wrongTi.X(), wrongTi.Y())
+			deCommit = commitment.D
+			return NewSignRound7Message(msg.GetFrom(), commitment.C)
+		case *SignRound8Message:
+			return NewSignRound8Message(msg.GetFrom(), deCommit)
+		}
+		return msg
+	})
+	assertIdentified(t, errs, causeUNotEqualT, "Tj != A^lj")
+}
+
+func TestE2EPresignAndOnlineSign(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(signPIDs)
+
+	// PHASE: presign
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs)*len(signPIDs))
+	presigCh := make(chan *PreSignatureData, len(signPIDs))
+
+	parties := make([]tss.Party, 0, len(signPIDs))
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		P := NewPresignParty(params, keys[i], outCh, presigCh)
+		parties = append(parties, P)
+		go func(P tss.Party) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+	presigs := make([]PreSignatureData, len(signPIDs))
+	for ended := 0; ended < len(signPIDs); {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case msg := <-outCh:
+			routeMessage(parties, msg, errCh)
+		case presig := <-presigCh:
+			// presignatures are meant to be stored
+			bz, err := json.Marshal(presig)
+			assert.NoError(t, err)
+			assert.NoError(t, json.Unmarshal(bz, &presigs[presig.Index]))
+			ended++
+		}
+	}
+	for _, presig := range presigs {
+		assert.True(t, presig.ValidateBasic())
+		assert.Equal(t, presigs[0].ID, presig.ID)
+		assert.True(t, presigs[0].R.Equals(presig.R))
+	}
+
+	// PHASE: online signing
+	msg := big.NewInt(42)
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+	parties = parties[:0]
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		P := NewOnlineParty(msg, params, presigs[i], outCh, endCh)
+		parties = append(parties, P)
+		if err := P.Start(); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+	}
+	// a single round of broadcasts
+	assert.Equal(t, len(signPIDs), len(outCh))
+
+	pk := ecdsa.PublicKey{
+		Curve: tss.EC(),
+		X:     keys[0].ECDSAPub.X(),
+		Y:     keys[0].ECDSAPub.Y(),
+	}
+	for ended := 0; ended < len(signPIDs); {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case msg := <-outCh:
+			routeMessage(parties, msg, errCh)
+		case sig := <-endCh:
+			ok := ecdsa.Verify(&pk, msg.Bytes(), new(big.Int).SetBytes(sig.R), new(big.Int).SetBytes(sig.S))
+			assert.True(t, ok, "ecdsa verify must pass")
+			ended++
+		}
+	}
+
+	// a party that broadcasts a wrong share of s is identified
+	parties = parties[:0]
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		P := NewOnlineParty(msg, params, presigs[i], outCh, endCh)
+		parties = append(parties, P)
+		assert.Nil(t, P.Start())
+	}
+	for len(outCh) > 0 {
+		out := (<-outCh).(tss.ParsedMessage)
+		if out.GetFrom().Index == 0 {
+			s := out.Content().(*SignRound9Message).UnmarshalS()
+			out = NewSignRound9Message(out.GetFrom(), new(big.Int).Add(s, big.NewInt(1)))
+		}
+		for _, P := range parties[1:] {
+			if P.PartyID().Index != out.GetFrom().Index {
+				test.SharedPartyUpdater(P, out, errCh)
+			}
+		}
+	}
+	assert.Equal(t, len(signPIDs)-1, len(errCh))
+	for len(errCh) > 0 {
+		err := <-errCh
+		if assert.Equal(t, 1, len(err.Culprits()), err.Error()) {
+			assert.Equal(t, 0, err.Culprits()[0].Index)
+		}
+	}
+
+	// a corrupted presignature is rejected before the share of s is broadcast
+	corrupted := presigs[1]
+	corrupted.Sigma = new(big.Int).Add(corrupted.Sigma, big.NewInt(1))
+	params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[1], len(signPIDs), testThreshold)
+	err = NewOnlineParty(msg, params, corrupted, outCh, endCh).Start()
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "the presignature is corrupted")
+	}
+	assert.Zero(t, len(outCh), "nothing must be sent")
+
+	// a presignature only works for the signers that created it
+	otherPIDs := tss.SortPartyIDs(append(tss.UnSortedPartyIDs{}, signPIDs[1:]...))
+	params = tss.NewParameters(tss.S256(), tss.NewPeerContext(otherPIDs), otherPIDs[0], len(otherPIDs), testThreshold-1)
+	err = NewOnlineParty(msg, params, presigs[1], outCh, endCh).Start()
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "not the signers of the presignature")
+	}
+}
+
+func TestE2EPresignRejectsWrongSi(t *testing.T) {
+	setUp("info")
+
+	// party 0 sends an Si that does not match its commitment T0; its proof does not verify
+	errs, presigs := runPresignWithTampering(t, func(parties []*LocalParty, msg tss.ParsedMessage) tss.ParsedMessage {
+		content, ok := msg.Content().(*SignConsistencyMessage)
+		if !ok {
+			return msg
+		}
+		bigRi, _ := content.UnmarshalBigRi(tss.S256())
+		bigSi, _ := content.UnmarshalBigSi(tss.S256())
+		stProof, _ := content.UnmarshalSTProof(tss.S256())
+		wrongSi, err := bigSi.Add(crypto.ScalarBaseMult(tss.S256(), big.NewInt(1)))
+		assert.NoError(t, err)
+		return NewSignConsistencyMessage(msg.GetFrom(), bigRi, wrongSi, stProof)
+	})
+	assert.Empty(t, presigs, "no presignature must be output")
+	assertPresignCulprit(t, errs, "failed to prove that Sj matches the commitment Tj")
+}
+
+func TestE2EPresignRejectsBalancedSi(t *testing.T) {
+	setUp("info")
+
+	// party 0 commits to a wrong sigma0 in round 3, and then sends the S0 that balances Sum(Sj) = y once it has seen
+	// the Sj of the others; S0 is bound to T0, so party 0 is named although the sum check passes
+	errs, presigs := runPresignWithTampering(t, func(parties []*LocalParty, msg tss.ParsedMessage) tss.ParsedMessage {
+		ec := tss.S256()
+		switch content := msg.Content().(type) {
+		case *SignRound3Message:
+			h, err := pedersenH(ec)
+			assert.NoError(t, err)
+			wrongSigma := new(big.Int).Add(parties[0].temp.sigma, big.NewInt(1))
+			l := common.GetRandomPositiveInt(ec.Params().N)
+			wrongTi, err := crypto.ScalarBaseMult(ec, wrongSigma).Add(h.ScalarMult(l))
+			assert.NoError(t, err)
+			ContextI := common.AppendBigIntToBytesSlice(parties[0].temp.ssid, big.NewInt(0))
+			tProof, err := schnorr.NewZKVProof(ContextI, wrongTi, h, l, wrongSigma)
+			assert.NoError(t, err)
+			return NewSignRound3Message(msg.GetFrom(), new(big.Int).SetBytes(content.GetTheta()), wrongTi, tProof)
+
+		case *SignConsistencyMessage:
+			// S0 = y - Sum(R^sigmaj), j != 0
+			others := big.NewInt(0)
+			for _, P := range parties[1:] {
+				others.Add(others, P.temp.sigma)
+			}
+			minusOthers := new(big.Int).Sub(ec.Params().N, new(big.Int).Mod(others, ec.Params().N))
+			balancedSi, err := parties[0].keys.ECDSAPub.Add(parties[0].temp.bigR.ScalarMult(minusOthers))
+			assert.NoError(t, err)
+			bigRi, _ := content.UnmarshalBigRi(ec)
+			stProof, _ := content.UnmarshalSTProof(ec)
+			return NewSignConsistencyMessage(msg.GetFrom(), bigRi, balancedSi, stProof)
+		}
+		return msg
+	})
+	assert.Empty(t, presigs, "no presignature must be output")
+	assertPresignCulprit(t, errs, "failed to prove that Sj matches the commitment Tj")
+}
+
+func TestE2EPresignRejectsWrongRi(t *testing.T) {
+	setUp("info")
+
+	// party 0 sends an Ri that is not R^k0; its proof does not verify
+	errs, presigs := runPresignWithTampering(t, func(parties []*LocalParty, msg tss.ParsedMessage) tss.ParsedMessage {
+		content, ok := msg.Content().(*SignConsistencyMessage)
+		if !ok {
+			return msg
+		}
+		R := parties[0].temp.bigR
+		bigRi, _ := content.UnmarshalBigRi(tss.S256())
+		bigSi, _ := content.UnmarshalBigSi(tss.S256())
+		stProof, _ := content.UnmarshalSTProof(tss.S256())
+		wrongRi, err := bigRi.Add(R)
+		assert.NoError(t, err)
+		return NewSignConsistencyMessage(msg.GetFrom(), wrongRi, bigSi, stProof)
+	})
+	assert.Empty(t, presigs, "no presignature must be output")
+	assertPresignCulprit(t, errs, "failed to prove that Rj matches the encryption of kj")
+}
+
+func assertPresignCulprit(t *testing.T, errs map[int]*tss.Error, cause string) {
+	assert.NotEmpty(t, errs)
+	for _, err := range errs {
+		assert.Equal(t, 6, err.Round())
+		assert.Equal(t, cause, err.Cause().Error())
+		if assert.Equal(t, 1, len(err.Culprits()), err.Error()) {
+			assert.Equal(t, 0, err.Culprits()[0].Index)
+		}
+	}
+}
+
+// runPresignWithTampering runs presigning with the messages of party 0 passed through `tamper` and returns the errors
+// of the other parties by index and the presignatures that were output
+func runPresignWithTampering(t *testing.T, tamper func([]*LocalParty, tss.ParsedMessage) tss.ParsedMessage) (map[int]*tss.Error, []*PreSignatureData) {
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+
+	// messages are routed synchronously below, so the channels must be able to hold everything that is produced
+	errCh := make(chan *tss.Error, len(signPIDs)*len(signPIDs)*10)
+	outCh := make(chan tss.Message, len(signPIDs)*len(signPIDs)*10)
+	presigCh := make(chan *PreSignatureData, len(signPIDs))
+
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		P := NewPresignParty(params, keys[i], outCh, presigCh).(*LocalParty)
+		parties = append(parties, P)
+		if err := P.Start(); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+	}
+
+	errs := make(map[int]*tss.Error, len(signPIDs))
+	for len(outCh) > 0 {
+		msg := <-outCh
+		if msg.GetFrom().Index == 0 {
+			msg = tamper(parties, msg.(tss.ParsedMessage))
+		}
+		if dest := msg.GetTo(); dest == nil {
+			for _, P := range parties {
+				if P.PartyID().Index != msg.GetFrom().Index {
+					test.SharedPartyUpdater(P, msg, errCh)
+				}
+			}
+		} else {
+			test.SharedPartyUpdater(parties[dest[0].Index], msg, errCh)
+		}
+	}
+	for len(errCh) > 0 {
+		err := <-errCh
+		if err.Victim() == nil || err.Victim().Index == 0 {
+			assert.FailNow(t, err.Error())
+		}
+		errs[err.Victim().Index] = err
+	}
+	assert.Equal(t, len(signPIDs)-1, len(errs), "every honest party must reject the presignature")
+	presigs := make([]*PreSignatureData, 0, len(signPIDs))
+	for len(presigCh) > 0 {
+		presig := <-presigCh
+		// only the cheater can finish, as it does not see its own tampering
+		if presig.Index != 0 {
+			presigs = append(presigs, presig)
+		}
+	}
+	return errs, presigs
+}
+
+func routeMessage(parties []tss.Party, msg tss.Message, errCh chan<- *tss.Error) {
+	if dest := msg.GetTo(); dest == nil {
+		for _, P := range parties {
+			if P.PartyID().Index != msg.GetFrom().Index {
+				go test.SharedPartyUpdater(P, msg, errCh)
+			}
+		}
+	} else {
+		go test.SharedPartyUpdater(parties[dest[0].Index], msg, errCh)
+	}
+}
+
+// runSigningWithTampering runs signing with the messages of party 0 passed through `tamper` and returns the errors
+// of the other parties by index
+func runSigningWithTampering(t *testing.T, tamper func([]*LocalParty, tss.ParsedMessage) tss.ParsedMessage) map[int]*tss.Error {
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+
+	// messages are routed synchronously below, so the channels must be able to hold everything that is produced
+	errCh := make(chan *tss.Error, len(signPIDs)*len(signPIDs)*10)
+	outCh := make(chan tss.Message, len(signPIDs)*len(signPIDs)*10)
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		P := NewLocalParty(big.NewInt(42), params, keys[i], outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		if err := P.Start(); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+	}
+
+	errs := make(map[int]*tss.Error, len(signPIDs))
+	revealed := false
+	for len(errs) < len(signPIDs)-1 {
+		select {
+		case err := <-errCh:
+			if err.Victim() == nil {
+				assert.FailNow(t, err.Error())
+			}
+			if err.Victim().Index == 0 {
+				// the cheater may abort too, as its own view can be inconsistent
+				continue
+			}
+			errs[err.Victim().Index] = err
+		case msg := <-outCh:
+			if msg.GetFrom().Index == 0 {
+				msg = tamper(parties, msg.(tss.ParsedMessage))
+			}
+			if dest := msg.GetTo(); dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index != msg.GetFrom().Index {
+						test.SharedPartyUpdater(P, msg, errCh)
+					}
+				}
+			} else {
+				test.SharedPartyUpdater(parties[dest[0].Index], msg, errCh)
+			}
+		case sig := <-endCh:
+			// only the cheater can finish, as it does not see its own tampering
+			assert.Equal(t, parties[0].data, sig)
+		default:
+			// the cheater believes that signing went well and does not reveal its values on its own
+			if revealed {
+				assert.FailNow(t, "signing stalled before the honest parties aborted")
+			}
+			revealed = true
+			outCh <- NewSignIdentificationMessage(parties[0].PartyID(), parties[0].temp.li, parties[0].temp.roi, parties[0].temp.si)
+		}
+	}
+	for _, P := range parties[1:] {
+		assert.False(t, P.Running(), "a party must stop running once the identification step has ended")
+	}
+	return errs
+}
+
+func assertIdentified(t *testing.T, errs map[int]*tss.Error, cause, reason string) {
+	for _, err := range errs {
+		assert.Equal(t, 11, err.Round())
+		assert.Equal(t, 1, len(err.Culprits()))
+		assert.Equal(t, 0, err.Culprits()[0].Index)
+		var abortErr *IdentifiedAbortError
+		if assert.True(t, errors.As(err, &abortErr), "expected an IdentifiedAbortError, got: %s", err) {
+			assert.Equal(t, cause, abortErr.Cause)
+			assert.Equal(t, 1, len(abortErr.Evidence))
+			assert.Equal(t, reason, abortErr.Evidence[0].Reason)
+		}
+	}
+}
+
 func TestFillTo32BytesInPlace(t *testing.T) {
 	s := big.NewInt(123456789)
 	normalizedS := padToLengthBytesInPlace(s.Bytes(), 32)
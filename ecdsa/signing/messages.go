@@ -14,6 +14,7 @@ import (
 	"github.com/bnb-chain/tss-lib/v2/crypto"
 	cmt "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
 	"github.com/bnb-chain/tss-lib/v2/crypto/mta"
+	"github.com/bnb-chain/tss-lib/v2/crypto/pdlproof"
 	"github.com/bnb-chain/tss-lib/v2/crypto/schnorr"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 )
@@ -29,11 +30,15 @@ var (
 		(*SignRound2Message)(nil),
 		(*SignRound3Message)(nil),
 		(*SignRound4Message)(nil),
+		(*SignConsistencyMessage)(nil),
+		(*SignConsistencyProofMessage)(nil),
 		(*SignRound5Message)(nil),
 		(*SignRound6Message)(nil),
 		(*SignRound7Message)(nil),
 		(*SignRound8Message)(nil),
 		(*SignRound9Message)(nil),
+		(*SignIdentificationMessage)(nil),
+		(*SignBatchMessage)(nil),
 	}
 )
 
@@ -145,13 +150,21 @@ func (m *SignRound2Message) UnmarshalProofBobWC(ec elliptic.Curve) (*mta.ProofBo
 func NewSignRound3Message(
 	from *tss.PartyID,
 	theta *big.Int,
+	bigTi *crypto.ECPoint,
+	tProof *schnorr.ZKVProof,
 ) tss.ParsedMessage {
 	meta := tss.MessageRouting{
 		From:        from,
 		IsBroadcast: true,
 	}
 	content := &SignRound3Message{
-		Theta: theta.Bytes(),
+		Theta:        theta.Bytes(),
+		TIX:          bigTi.X().Bytes(),
+		TIY:          bigTi.Y().Bytes(),
+		TProofAlphaX: tProof.Alpha.X().Bytes(),
+		TProofAlphaY: tProof.Alpha.Y().Bytes(),
+		TProofT:      tProof.T.Bytes(),
+		TProofU:      tProof.U.Bytes(),
 	}
 	msg := tss.NewMessageWrapper(meta, content)
 	return tss.NewMessage(meta, content, msg)
@@ -159,7 +172,35 @@ func NewSignRound3Message(
 
 func (m *SignRound3Message) ValidateBasic() bool {
 	return m != nil &&
-		common.NonEmptyBytes(m.Theta)
+		common.NonEmptyBytes(m.Theta) &&
+		common.NonEmptyBytes(m.TIX) &&
+		common.NonEmptyBytes(m.TIY) &&
+		common.NonEmptyBytes(m.TProofAlphaX) &&
+		common.NonEmptyBytes(m.TProofAlphaY) &&
+		common.NonEmptyBytes(m.TProofT) &&
+		common.NonEmptyBytes(m.TProofU)
+}
+
+func (m *SignRound3Message) UnmarshalBigTi(ec elliptic.Curve) (*crypto.ECPoint, error) {
+	return crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetTIX()),
+		new(big.Int).SetBytes(m.GetTIY()))
+}
+
+func (m *SignRound3Message) UnmarshalTProof(ec elliptic.Curve) (*schnorr.ZKVProof, error) {
+	point, err := crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetTProofAlphaX()),
+		new(big.Int).SetBytes(m.GetTProofAlphaY()))
+	if err != nil {
+		return nil, err
+	}
+	return &schnorr.ZKVProof{
+		Alpha: point,
+		T:     new(big.Int).SetBytes(m.GetTProofT()),
+		U:     new(big.Int).SetBytes(m.GetTProofU()),
+	}, nil
 }
 
 // ----- //
@@ -213,6 +254,112 @@ func (m *SignRound4Message) UnmarshalZKProof(ec elliptic.Curve) (*schnorr.ZKProo
 
 // ----- //
 
+func NewSignConsistencyMessage(
+	from *tss.PartyID,
+	bigRi, bigSi *crypto.ECPoint,
+	stProof *schnorr.ZKSTProof,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &SignConsistencyMessage{
+		BigRIX:        bigRi.X().Bytes(),
+		BigRIY:        bigRi.Y().Bytes(),
+		BigSIX:        bigSi.X().Bytes(),
+		BigSIY:        bigSi.Y().Bytes(),
+		StProofAlphaX: stProof.Alpha.X().Bytes(),
+		StProofAlphaY: stProof.Alpha.Y().Bytes(),
+		StProofBetaX:  stProof.Beta.X().Bytes(),
+		StProofBetaY:  stProof.Beta.Y().Bytes(),
+		StProofT:      stProof.T.Bytes(),
+		StProofU:      stProof.U.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignConsistencyMessage) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetBigRIX()) &&
+		common.NonEmptyBytes(m.GetBigRIY()) &&
+		common.NonEmptyBytes(m.GetBigSIX()) &&
+		common.NonEmptyBytes(m.GetBigSIY()) &&
+		common.NonEmptyBytes(m.GetStProofAlphaX()) &&
+		common.NonEmptyBytes(m.GetStProofAlphaY()) &&
+		common.NonEmptyBytes(m.GetStProofBetaX()) &&
+		common.NonEmptyBytes(m.GetStProofBetaY()) &&
+		common.NonEmptyBytes(m.GetStProofT()) &&
+		common.NonEmptyBytes(m.GetStProofU())
+}
+
+func (m *SignConsistencyMessage) UnmarshalBigRi(ec elliptic.Curve) (*crypto.ECPoint, error) {
+	return crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetBigRIX()),
+		new(big.Int).SetBytes(m.GetBigRIY()))
+}
+
+func (m *SignConsistencyMessage) UnmarshalBigSi(ec elliptic.Curve) (*crypto.ECPoint, error) {
+	return crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetBigSIX()),
+		new(big.Int).SetBytes(m.GetBigSIY()))
+}
+
+func (m *SignConsistencyMessage) UnmarshalSTProof(ec elliptic.Curve) (*schnorr.ZKSTProof, error) {
+	alpha, err := crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetStProofAlphaX()),
+		new(big.Int).SetBytes(m.GetStProofAlphaY()))
+	if err != nil {
+		return nil, err
+	}
+	beta, err := crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetStProofBetaX()),
+		new(big.Int).SetBytes(m.GetStProofBetaY()))
+	if err != nil {
+		return nil, err
+	}
+	return &schnorr.ZKSTProof{
+		Alpha: alpha,
+		Beta:  beta,
+		T:     new(big.Int).SetBytes(m.GetStProofT()),
+		U:     new(big.Int).SetBytes(m.GetStProofU()),
+	}, nil
+}
+
+// ----- //
+
+func NewSignConsistencyProofMessage(
+	to, from *tss.PartyID,
+	proof *pdlproof.ProofPDL,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	pfBz := proof.Bytes()
+	content := &SignConsistencyProofMessage{
+		ProofPdl: pfBz[:],
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignConsistencyProofMessage) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyMultiBytes(m.GetProofPdl(), pdlproof.ProofPDLBytesParts)
+}
+
+func (m *SignConsistencyProofMessage) UnmarshalProofPDL(R *crypto.ECPoint) (*pdlproof.ProofPDL, error) {
+	return pdlproof.NewProofFromBytes(m.GetProofPdl(), R)
+}
+
+// ----- //
+
 func NewSignRound5Message(
 	from *tss.PartyID,
 	commitment cmt.HashCommitment,
@@ -389,3 +536,70 @@ func (m *SignRound9Message) ValidateBasic() bool {
 func (m *SignRound9Message) UnmarshalS() *big.Int {
 	return new(big.Int).SetBytes(m.S)
 }
+
+// ----- //
+
+func NewSignIdentificationMessage(
+	from *tss.PartyID,
+	li, roi, si *big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &SignIdentificationMessage{
+		L:   li.Bytes(),
+		Rho: roi.Bytes(),
+		S:   si.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignIdentificationMessage) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.L) &&
+		common.NonEmptyBytes(m.Rho) &&
+		common.NonEmptyBytes(m.S)
+}
+
+func (m *SignIdentificationMessage) UnmarshalL() *big.Int {
+	return new(big.Int).SetBytes(m.L)
+}
+
+func (m *SignIdentificationMessage) UnmarshalRho() *big.Int {
+	return new(big.Int).SetBytes(m.Rho)
+}
+
+func (m *SignIdentificationMessage) UnmarshalS() *big.Int {
+	return new(big.Int).SetBytes(m.S)
+}
+
+// ----- //
+
+func NewSignBatchMessage(
+	from *tss.PartyID,
+	to []*tss.PartyID,
+	isBroadcast bool,
+	indexes []uint32,
+	messages [][]byte,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          to,
+		IsBroadcast: isBroadcast,
+	}
+	content := &SignBatchMessage{
+		Indexes:  indexes,
+		Messages: messages,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignBatchMessage) ValidateBasic() bool {
+	return m != nil &&
+		0 < len(m.GetIndexes()) &&
+		len(m.GetIndexes()) == len(m.GetMessages()) &&
+		common.NonEmptyMultiBytes(m.GetMessages())
+}
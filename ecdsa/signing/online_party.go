@@ -0,0 +1,159 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// Implements Party
+// Implements Stringer
+var _ tss.Party = (*OnlineParty)(nil)
+var _ fmt.Stringer = (*OnlineParty)(nil)
+
+// OnlineParty signs a message in a single round using a presignature created by NewPresignParty.
+// Every party broadcasts its share of s, and the combined signature is verified before it is output.
+//
+// This is the one-round online signing of GG20: the presignature is only output once the presign checks have passed,
+// which makes the shares of s safe to release, so the checks of rounds 5-9 of the full protocol are not needed.
+// Before its share is broadcast, the party checks again that the presignature is consistent and that its own shares
+// match it. A party that sends a wrong share sj is identified by R^sj != Rj^m * Sj^r; the presignature must then be
+// discarded.
+type OnlineParty struct {
+	*tss.BaseParty
+	params *tss.Parameters
+
+	presig PreSignatureData
+	keys   keygen.LocalPartySaveData
+	temp   localTempData
+	data   *common.SignatureData
+
+	// outbound messaging
+	out chan<- tss.Message
+	end chan<- *common.SignatureData
+}
+
+// NewOnlineParty returns a party that signs `msg` with `presig`. The parties in `params` must be the signers of the
+// presignature. A presignature must never be used for more than one message; running the online party again for the
+// same message is safe.
+func NewOnlineParty(
+	msg *big.Int,
+	params *tss.Parameters,
+	presig PreSignatureData,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+) tss.Party {
+	partyCount := len(params.Parties().IDs())
+	p := &OnlineParty{
+		BaseParty: new(tss.BaseParty),
+		params:    params,
+		presig:    presig,
+		keys:      keygen.LocalPartySaveData{ECDSAPub: presig.ECDSAPub},
+		temp:      localTempData{},
+		data:      &common.SignatureData{},
+		out:       out,
+		end:       end,
+	}
+	p.temp.signRound9Messages = make([]tss.ParsedMessage, partyCount)
+	p.temp.m = msg
+	return p
+}
+
+func (p *OnlineParty) FirstRound() tss.Round {
+	return &onlineRound{&base{p.params, &p.keys, p.data, &p.temp, p.out, p.end, make([]bool, len(p.params.Parties().IDs())), false, 1, nil}}
+}
+
+func (p *OnlineParty) Start() *tss.Error {
+	return tss.BaseStart(p, TaskName, p.prepare)
+}
+
+func (p *OnlineParty) StartWithContext(ctx context.Context, errCh chan<- *tss.Error) *tss.Error {
+	return tss.BaseStartWithContext(ctx, p, TaskName, errCh, p.prepare)
+}
+
+func (p *OnlineParty) prepare(round tss.Round) *tss.Error {
+	if !p.presig.ValidateBasic() {
+		return round.WrapError(errors.New("the presignature is incomplete"))
+	}
+	if !equalKs(p.params.Parties().IDs().Keys(), p.presig.Ks) {
+		return round.WrapError(errors.New("the parties are not the signers of the presignature"))
+	}
+	if p.presig.Index != p.PartyID().Index {
+		return round.WrapError(errors.New("the presignature belongs to another party"))
+	}
+	if err := p.presig.verify(); err != nil {
+		return round.WrapError(fmt.Errorf("the presignature is corrupted: %v", err))
+	}
+	p.temp.k = p.presig.K
+	p.temp.sigma = p.presig.Sigma
+	p.temp.bigR = p.presig.R
+	p.temp.bigRis = p.presig.Rs
+	p.temp.bigSis = p.presig.Ss
+	return nil
+}
+
+func (p *OnlineParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(p, msg, TaskName)
+}
+
+func (p *OnlineParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *OnlineParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if ok, err := p.BaseParty.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	// check that the message's "from index" will fit into the array
+	if maxFromIdx := len(p.params.Parties().IDs()) - 1; maxFromIdx < msg.GetFrom().Index {
+		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+			maxFromIdx, msg.GetFrom().Index), msg.GetFrom())
+	}
+	return true, nil
+}
+
+func (p *OnlineParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	fromPIdx := msg.GetFrom().Index
+
+	// this does not handle message replays. we expect the caller to apply replay and spoofing protection.
+	switch msg.Content().(type) {
+	case *SignRound9Message:
+		p.temp.signRound9Messages[fromPIdx] = msg
+	default: // unrecognised message, just ignore!
+		common.Logger.Warningf("unrecognised message ignored: %v", msg)
+		return false, nil
+	}
+	return true, nil
+}
+
+// Snapshot is not supported by online parties; create a new party from the same presignature and message instead.
+func (p *OnlineParty) Snapshot(key []byte) ([]byte, *tss.Error) {
+	return nil, p.WrapError(errors.New("could not snapshot. an online party can be recreated from its presignature"))
+}
+
+func (p *OnlineParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *OnlineParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
@@ -0,0 +1,171 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// PreSignatureData is the output of presigning: a party's shares of the nonce k and of k*x, and the nonce point R.
+// It is bound to the public key and to the set of signers that created it, and is consumed by an online signing
+// party in one round once the message is known.
+// It is only output once the presign checks of GG20 have passed: Rs and Ss hold R^kj and R^sigmaj of every signer,
+// which sum to g and to the public key, and which the online party uses to check the shares of s of the others.
+// A presignature must be used to sign at most one message and then be deleted; signing two different messages with
+// the same presignature reveals the private key.
+type PreSignatureData struct {
+	// ID is the same for all the signers of a presignature
+	ID []byte
+	// Ks are the keys of the signers, in the order of their indexes; Index is the index of the owner of this share
+	Ks       []*big.Int
+	Index    int
+	ECDSAPub *crypto.ECPoint
+	R        *crypto.ECPoint
+	K, Sigma *big.Int
+	Rs, Ss   []*crypto.ECPoint
+}
+
+// NewPresignParty returns a party that runs rounds 1-4 of signing, which do not depend on the message, and the presign
+// checks of GG20, and outputs a presignature for the signers in `params` through `end`. Sign with it using
+// NewOnlineParty.
+func NewPresignParty(
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *PreSignatureData,
+) tss.Party {
+	return NewPresignPartyWithKDD(params, key, nil, out, end)
+}
+
+// NewPresignPartyWithKDD returns a presign party with key derivation delta for HD support
+func NewPresignPartyWithKDD(
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	keyDerivationDelta *big.Int,
+	out chan<- tss.Message,
+	end chan<- *PreSignatureData,
+) tss.Party {
+	return newLocalParty(nil, params, key, keyDerivationDelta, out, nil, end)
+}
+
+func (round *presignCheck) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 5
+	round.started = true
+	round.resetOK()
+
+	R, err := round.computeR()
+	if err != nil {
+		return err
+	}
+	round.temp.bigR = R
+	return round.startConsistencyCheck(R)
+}
+
+func (round *presignCheck) Update() (bool, *tss.Error) {
+	return round.updateConsistencyCheck()
+}
+
+func (round *presignCheck) CanAccept(msg tss.ParsedMessage) bool {
+	return round.canAcceptConsistency(msg)
+}
+
+func (round *presignCheck) NextRound() tss.Round {
+	round.started = false
+	return &presignFinalization{round}
+}
+
+// ----- //
+
+func (round *presignFinalization) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 6
+	round.started = true
+	round.resetOK()
+
+	// nothing is output if the checks fail, so no share of s is ever computed from an inconsistent presignature
+	if err := round.verifyConsistency(); err != nil {
+		return err
+	}
+	R := round.temp.bigR
+	ks := round.Parties().IDs().Keys()
+	idList := append([]*big.Int{R.X(), R.Y(), round.key.ECDSAPub.X(), round.key.ECDSAPub.Y()}, ks...)
+	presig := &PreSignatureData{
+		ID:       common.SHA512_256i(idList...).Bytes(),
+		Ks:       ks,
+		Index:    round.PartyID().Index,
+		ECDSAPub: round.key.ECDSAPub,
+		R:        R,
+		K:        round.temp.k,
+		Sigma:    round.temp.sigma,
+		Rs:       append([]*crypto.ECPoint{}, round.temp.bigRis...),
+		Ss:       append([]*crypto.ECPoint{}, round.temp.bigSis...),
+	}
+
+	// clear temp.w, temp.k and temp.sigma from memory, lint ignore
+	round.temp.w = zero
+	round.temp.k = zero
+	round.temp.sigma = zero
+
+	for j := range round.ok {
+		round.ok[j] = true
+	}
+	round.presignEnd <- presig
+
+	return nil
+}
+
+func (round *presignFinalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *presignFinalization) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *presignFinalization) NextRound() tss.Round {
+	return nil // finished!
+}
+
+// ValidateBasic checks that the presignature is complete
+func (presig PreSignatureData) ValidateBasic() bool {
+	if len(presig.ID) == 0 || len(presig.Ks) == 0 || presig.Index < 0 || len(presig.Ks) <= presig.Index ||
+		presig.ECDSAPub == nil || presig.R == nil || presig.K == nil || presig.Sigma == nil ||
+		len(presig.Rs) != len(presig.Ks) || len(presig.Ss) != len(presig.Ks) {
+		return false
+	}
+	for j := range presig.Ks {
+		if presig.Rs[j] == nil || presig.Ss[j] == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// verify checks that the presignature passed the presign checks and that the shares of this party match them
+func (presig PreSignatureData) verify() error {
+	if err := checkPresignSums(presig.R.Curve(), presig.Rs, presig.Ss, presig.ECDSAPub); err != nil {
+		return err
+	}
+	if !presig.R.ScalarMult(presig.K).Equals(presig.Rs[presig.Index]) ||
+		!presig.R.ScalarMult(presig.Sigma).Equals(presig.Ss[presig.Index]) {
+		return errors.New("the shares of the presignature do not match R^ki and R^sigmai")
+	}
+	return nil
+}
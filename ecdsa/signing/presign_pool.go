@@ -0,0 +1,105 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+)
+
+// PreSignatureGenerator runs the presign protocol, e.g. with NewPresignParty, and returns this party's presignature.
+// All the signers must run it together, so it is up to the caller to coordinate the sessions of the parties.
+type PreSignatureGenerator func(ctx context.Context) (*PreSignatureData, error)
+
+// PreSignaturePool keeps a number of presignatures for one public key and set of signers ready in a store.
+// Run generates new presignatures in the background whenever the pool falls below its size.
+type PreSignaturePool struct {
+	store    PreSignatureStore
+	pub      *crypto.ECPoint
+	ks       []*big.Int
+	size     int
+	generate PreSignatureGenerator
+	refill   chan struct{}
+}
+
+// NewPreSignaturePool returns a pool of `size` presignatures for `pub` and the signers `ks` (in the order of their
+// indexes), kept in `store` and created with `generate`.
+func NewPreSignaturePool(store PreSignatureStore, pub *crypto.ECPoint, ks []*big.Int, size int, generate PreSignatureGenerator) *PreSignaturePool {
+	return &PreSignaturePool{
+		store:    store,
+		pub:      pub,
+		ks:       ks,
+		size:     size,
+		generate: generate,
+		refill:   make(chan struct{}, 1),
+	}
+}
+
+// Run fills the pool and refills it whenever presignatures are taken, until `ctx` is done.
+// It returns the first error of the store or of the generator; the caller may call Run again to retry.
+func (p *PreSignaturePool) Run(ctx context.Context) error {
+	for {
+		count, err := p.store.Count(p.pub, p.ks)
+		if err != nil {
+			return err
+		}
+		if p.size <= count {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-p.refill:
+				continue
+			}
+		}
+		presig, err := p.generate(ctx)
+		if err != nil {
+			return err
+		}
+		if presig.ECDSAPub == nil || !presig.ECDSAPub.Equals(p.pub) || !equalKs(presig.Ks, p.ks) {
+			return errors.New("the generator returned a presignature for another key or set of signers")
+		}
+		if err = p.store.Put(presig); err != nil {
+			return err
+		}
+	}
+}
+
+// Take hands out an unused presignature, which is marked as used in the store before it is returned.
+func (p *PreSignaturePool) Take() (*PreSignatureData, error) {
+	presig, err := p.store.Take(p.pub, p.ks)
+	p.signalRefill()
+	return presig, err
+}
+
+// TakeID is like Take for the presignature with the given ID.
+func (p *PreSignaturePool) TakeID(id []byte) (*PreSignatureData, error) {
+	presig, err := p.store.TakeID(id)
+	p.signalRefill()
+	return presig, err
+}
+
+func (p *PreSignaturePool) signalRefill() {
+	select {
+	case p.refill <- struct{}{}:
+	default:
+	}
+}
+
+func equalKs(ks1, ks2 []*big.Int) bool {
+	if len(ks1) != len(ks2) {
+		return false
+	}
+	for i := range ks1 {
+		if ks1[i].Cmp(ks2[i]) != 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,244 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+)
+
+var (
+	// ErrPreSignatureUsed is returned when a presignature has already been handed out once
+	ErrPreSignatureUsed = errors.New("the presignature has already been used")
+	// ErrNoPreSignature is returned when there is no unused presignature for the given key and signers
+	ErrNoPreSignature = errors.New("no presignature is available")
+)
+
+// PreSignatureStore keeps presignatures until they are used and makes sure that each one is handed out only once,
+// also across restarts. Implementations must be safe for concurrent use.
+type PreSignatureStore interface {
+	// Put stores a new presignature; it fails with ErrPreSignatureUsed if a presignature with the same ID was used
+	Put(presig *PreSignatureData) error
+	// Take returns any unused presignature for the public key `pub` and the signers `ks` (in the order of their
+	// indexes), after durably marking it as used. It fails with ErrNoPreSignature if there is none.
+	Take(pub *crypto.ECPoint, ks []*big.Int) (*PreSignatureData, error)
+	// TakeID is like Take for the presignature with the given ID, e.g. the one chosen by the party that coordinates
+	// the signing. It fails with ErrPreSignatureUsed if it was already used.
+	TakeID(id []byte) (*PreSignatureData, error)
+	// Count returns the number of unused presignatures for `pub` and `ks`
+	Count(pub *crypto.ECPoint, ks []*big.Int) (int, error)
+}
+
+// FileStore is a PreSignatureStore that keeps every presignature in its own file under a directory, grouped by public
+// key and signers. Taking a presignature first creates a marker file for its ID, which is synced to disk before the
+// presignature is returned, and then deletes the presignature. The markers are kept forever so that the same
+// presignature can never be stored or taken again.
+// The files contain secret values and should be protected like the key share itself.
+type FileStore struct {
+	mtx sync.Mutex
+	dir string
+}
+
+const (
+	presigFileExt = ".presig"
+	usedFileExt   = ".used"
+)
+
+var _ PreSignatureStore = (*FileStore)(nil)
+
+// NewFileStore returns a store that keeps its files under `dir`, which is created if it does not exist.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+func (s *FileStore) Put(presig *PreSignatureData) error {
+	if presig == nil || !presig.ValidateBasic() {
+		return errors.New("the presignature is incomplete")
+	}
+	bz, err := json.Marshal(presig)
+	if err != nil {
+		return err
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	id := hex.EncodeToString(presig.ID)
+	if _, err := os.Stat(filepath.Join(s.dir, id+usedFileExt)); err == nil {
+		return ErrPreSignatureUsed
+	}
+	groupDir := filepath.Join(s.dir, groupName(presig.ECDSAPub, presig.Ks))
+	if err := os.MkdirAll(groupDir, 0700); err != nil {
+		return err
+	}
+	// write to a temporary file first so that a crash never leaves a partial presignature behind
+	path := filepath.Join(groupDir, id+presigFileExt)
+	if err := writeFileSync(path+".tmp", bz); err != nil {
+		return err
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return err
+	}
+	return syncDir(groupDir)
+}
+
+func (s *FileStore) Take(pub *crypto.ECPoint, ks []*big.Int) (*PreSignatureData, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	groupDir := filepath.Join(s.dir, groupName(pub, ks))
+	names, err := presigFileNames(groupDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		presig, err := s.take(filepath.Join(groupDir, name))
+		if err == ErrPreSignatureUsed {
+			continue // left behind by a crash after marking
+		}
+		return presig, err
+	}
+	return nil, ErrNoPreSignature
+}
+
+func (s *FileStore) TakeID(id []byte) (*PreSignatureData, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	idHex := hex.EncodeToString(id)
+	if _, err := os.Stat(filepath.Join(s.dir, idHex+usedFileExt)); err == nil {
+		return nil, ErrPreSignatureUsed
+	}
+	groups, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range groups {
+		if !group.IsDir() {
+			continue
+		}
+		path := filepath.Join(s.dir, group.Name(), idHex+presigFileExt)
+		if _, err := os.Stat(path); err == nil {
+			return s.take(path)
+		}
+	}
+	return nil, ErrNoPreSignature
+}
+
+func (s *FileStore) Count(pub *crypto.ECPoint, ks []*big.Int) (int, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	names, err := presigFileNames(filepath.Join(s.dir, groupName(pub, ks)))
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, name := range names {
+		if _, err := os.Stat(filepath.Join(s.dir, strings.TrimSuffix(name, presigFileExt)+usedFileExt)); os.IsNotExist(err) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// take marks the presignature at `path` as used and then deletes it. must be called with the store locked
+func (s *FileStore) take(path string) (*PreSignatureData, error) {
+	bz, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	presig := new(PreSignatureData)
+	if err := json.Unmarshal(bz, presig); err != nil {
+		return nil, fmt.Errorf("could not read presignature %s: %v", path, err)
+	}
+	// O_EXCL makes the marking atomic, also between processes sharing the directory
+	usedPath := filepath.Join(s.dir, hex.EncodeToString(presig.ID)+usedFileExt)
+	f, err := os.OpenFile(usedPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if os.IsExist(err) {
+		_ = os.Remove(path)
+		return nil, ErrPreSignatureUsed
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = f.Sync(); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	if err = f.Close(); err != nil {
+		return nil, err
+	}
+	if err = syncDir(s.dir); err != nil {
+		return nil, err
+	}
+	if err = os.Remove(path); err != nil {
+		common.Logger.Warningf("could not delete used presignature %s: %v", path, err)
+	}
+	return presig, nil
+}
+
+// groupName identifies the presignatures of a public key and a set of signers
+func groupName(pub *crypto.ECPoint, ks []*big.Int) string {
+	if pub == nil {
+		return "invalid"
+	}
+	return hex.EncodeToString(common.SHA512_256i(append([]*big.Int{pub.X(), pub.Y()}, ks...)...).Bytes())
+}
+
+func presigFileNames(dir string) ([]string, error) {
+	files, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(files))
+	for _, f := range files {
+		if strings.HasSuffix(f.Name(), presigFileExt) {
+			names = append(names, f.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func writeFileSync(path string, bz []byte) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(bz); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err = f.Sync(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
@@ -0,0 +1,160 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"context"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func newTestPreSignature(pub *crypto.ECPoint, ks []*big.Int) *PreSignatureData {
+	N := tss.S256().Params().N
+	R := crypto.ScalarBaseMult(tss.S256(), common.GetRandomPositiveInt(N))
+	Rs, Ss := make([]*crypto.ECPoint, len(ks)), make([]*crypto.ECPoint, len(ks))
+	for j := range ks {
+		Rs[j] = crypto.ScalarBaseMult(tss.S256(), common.GetRandomPositiveInt(N))
+		Ss[j] = crypto.ScalarBaseMult(tss.S256(), common.GetRandomPositiveInt(N))
+	}
+	return &PreSignatureData{
+		ID:       common.SHA512_256i(R.X(), R.Y()).Bytes(),
+		Ks:       ks,
+		ECDSAPub: pub,
+		R:        R,
+		K:        common.GetRandomPositiveInt(N),
+		Sigma:    common.GetRandomPositiveInt(N),
+		Rs:       Rs,
+		Ss:       Ss,
+	}
+}
+
+func newTestStore(t *testing.T) (*FileStore, string) {
+	dir, err := ioutil.TempDir("", "presig")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	store, err := NewFileStore(dir)
+	assert.NoError(t, err)
+	return store, dir
+}
+
+func TestFileStoreSingleUse(t *testing.T) {
+	store, dir := newTestStore(t)
+	pub := crypto.ScalarBaseMult(tss.S256(), big.NewInt(7))
+	ks := []*big.Int{big.NewInt(1), big.NewInt(2)}
+	otherKs := []*big.Int{big.NewInt(1), big.NewInt(3)}
+
+	presig1, presig2 := newTestPreSignature(pub, ks), newTestPreSignature(pub, ks)
+	assert.NoError(t, store.Put(presig1))
+	assert.NoError(t, store.Put(presig2))
+	assert.NoError(t, store.Put(newTestPreSignature(pub, otherKs)))
+	count, err := store.Count(pub, ks)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	taken, err := store.TakeID(presig1.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, presig1.ID, taken.ID)
+	assert.Equal(t, 0, presig1.K.Cmp(taken.K))
+	_, err = store.TakeID(presig1.ID)
+	assert.Equal(t, ErrPreSignatureUsed, err)
+
+	taken, err = store.Take(pub, ks)
+	assert.NoError(t, err)
+	assert.Equal(t, presig2.ID, taken.ID)
+	_, err = store.Take(pub, ks)
+	assert.Equal(t, ErrNoPreSignature, err)
+
+	// used presignatures are refused after a restart
+	store, err = NewFileStore(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, ErrPreSignatureUsed, store.Put(presig1))
+	assert.Equal(t, ErrPreSignatureUsed, store.Put(presig2))
+	_, err = store.TakeID(presig2.ID)
+	assert.Equal(t, ErrPreSignatureUsed, err)
+	count, err = store.Count(pub, otherKs)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestFileStoreConcurrentTake(t *testing.T) {
+	store, _ := newTestStore(t)
+	pub := crypto.ScalarBaseMult(tss.S256(), big.NewInt(7))
+	ks := []*big.Int{big.NewInt(1), big.NewInt(2)}
+	const presigs = 10
+	for i := 0; i < presigs; i++ {
+		assert.NoError(t, store.Put(newTestPreSignature(pub, ks)))
+	}
+
+	var mtx sync.Mutex
+	taken := make(map[string]int)
+	wg := sync.WaitGroup{}
+	for i := 0; i < 2*presigs; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			presig, err := store.Take(pub, ks)
+			if err == ErrNoPreSignature {
+				return
+			}
+			assert.NoError(t, err)
+			mtx.Lock()
+			taken[string(presig.ID)]++
+			mtx.Unlock()
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, presigs, len(taken))
+	for _, n := range taken {
+		assert.Equal(t, 1, n, "a presignature was handed out twice")
+	}
+}
+
+func TestPreSignaturePool(t *testing.T) {
+	store, _ := newTestStore(t)
+	pub := crypto.ScalarBaseMult(tss.S256(), big.NewInt(7))
+	ks := []*big.Int{big.NewInt(1), big.NewInt(2)}
+	const size = 3
+
+	generated := make(chan struct{}, 10)
+	pool := NewPreSignaturePool(store, pub, ks, size, func(ctx context.Context) (*PreSignatureData, error) {
+		generated <- struct{}{}
+		return newTestPreSignature(pub, ks), nil
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- pool.Run(ctx) }()
+
+	waitForCount := func(expected int) {
+		for i := 0; i < 100; i++ {
+			if count, _ := store.Count(pub, ks); count == expected {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		assert.FailNow(t, "the pool was not filled")
+	}
+	waitForCount(size)
+	presig, err := pool.Take()
+	assert.NoError(t, err)
+	_, err = pool.TakeID(presig.ID)
+	assert.Equal(t, ErrPreSignatureUsed, err)
+	waitForCount(size)
+	assert.Equal(t, size+1, len(generated))
+
+	cancel()
+	assert.Equal(t, context.Canceled, <-done)
+}
@@ -24,9 +24,9 @@ var (
 )
 
 // round 1 represents round 1 of the signing part of the GG18 ECDSA TSS spec (Gennaro, Goldfeder; 2018)
-func newRound1(params *tss.Parameters, key *keygen.LocalPartySaveData, data *common.SignatureData, temp *localTempData, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Round {
+func newRound1(params *tss.Parameters, key *keygen.LocalPartySaveData, data *common.SignatureData, temp *localTempData, out chan<- tss.Message, end chan<- *common.SignatureData, presignEnd chan<- *PreSignatureData) tss.Round {
 	return &round1{
-		&base{params, key, data, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1}}
+		&base{params, key, data, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1, presignEnd}}
 }
 
 func (round *round1) Start() *tss.Error {
@@ -38,14 +38,15 @@ func (round *round1) Start() *tss.Error {
 	// but considered different blockchain use different hash function we accept the converted big.Int
 	// if this big.Int is not belongs to Zq, the client might not comply with common rule (for ECDSA):
 	// https://github.com/btcsuite/btcd/blob/c26ffa870fd817666a857af1bf6498fabba1ffe3/btcec/signature.go#L263
-	if round.temp.m.Cmp(round.Params().EC().Params().N) >= 0 {
+	// the message is not known yet when presigning
+	if round.temp.m != nil && round.temp.m.Cmp(round.Params().EC().Params().N) >= 0 {
 		return round.WrapError(errors.New("hashed message is not valid"))
 	}
 
 	round.number = 1
 	round.started = true
 	round.resetOK()
-	round.temp.ssidNonce = new(big.Int).SetUint64(0)
+	round.temp.ssidNonce = new(big.Int).SetUint64(uint64(round.temp.batchIndex))
 	ssid, err := round.getSSID()
 	if err != nil {
 		return round.WrapError(err)
@@ -69,12 +70,21 @@ func (round *round1) Start() *tss.Error {
 		if j == i {
 			continue
 		}
-		cA, pi, err := mta.AliceInit(round.Params().EC(), round.key.PaillierPKs[i], k, round.key.NTildej[j], round.key.H1j[j], round.key.H2j[j])
+		// as mta.AliceInit, keeping the randomness of cA for the proof of R^ki in the presign checks
+		cA, rA, err := round.key.PaillierPKs[i].EncryptAndReturnRandomness(k)
 		if err != nil {
 			return round.WrapError(fmt.Errorf("failed to init mta: %v", err))
 		}
-		r1msg1 := NewSignRound1Message1(Pj, round.PartyID(), cA, pi)
 		round.temp.cis[j] = cA
+		round.temp.cRandomness[j] = rA
+		if round.temp.batched {
+			continue // the batch proves the range of cA with those of its other sessions, see batchRound
+		}
+		pi, err := mta.ProveRangeAlice(round.Params().EC(), round.key.PaillierPKs[i], cA, round.key.NTildej[j], round.key.H1j[j], round.key.H2j[j], k, rA)
+		if err != nil {
+			return round.WrapError(fmt.Errorf("failed to init mta: %v", err))
+		}
+		r1msg1 := NewSignRound1Message1(Pj, round.PartyID(), cA, pi)
 		round.out <- r1msg1
 	}
 
@@ -123,6 +133,16 @@ func (round *round1) NextRound() tss.Round {
 func (round *round1) prepare() error {
 	i := round.PartyID().Index
 
+	// hash the message given to NewLocalPartyFromBytes
+	if round.temp.message != nil {
+		digest, err := round.temp.hash.Digest(round.temp.message)
+		if err != nil {
+			return err
+		}
+		round.temp.digest = digest
+		round.temp.m = HashToInt(round.Params().EC(), digest)
+	}
+
 	xi := round.key.Xi
 	ks := round.key.Ks
 	bigXs := round.key.BigXj
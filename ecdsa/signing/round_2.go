@@ -40,24 +40,40 @@ func (round *round2) Start() *tss.Error {
 		go func(j int, Pj *tss.PartyID) {
 			defer wg.Done()
 			r1msg := round.temp.signRound1Message1s[j].Content().(*SignRound1Message1)
-			rangeProofAliceJ, err := r1msg.UnmarshalRangeProofAlice()
-			if err != nil {
-				errChs <- round.WrapError(errorspkg.Wrapf(err, "UnmarshalRangeProofAlice failed"), Pj)
-				return
+			var beta, c1ji *big.Int
+			var pi1ji *mta.ProofBob
+			var err error
+			if round.temp.batched {
+				// the batch has verified the range proof with those of its other sessions
+				beta, c1ji, _, pi1ji, err = mta.BobRespond(
+					ContextI,
+					round.Parameters.EC(),
+					round.key.PaillierPKs[j],
+					round.temp.gamma,
+					r1msg.UnmarshalC(),
+					round.key.NTildej[j],
+					round.key.H1j[j],
+					round.key.H2j[j])
+			} else {
+				var rangeProofAliceJ *mta.RangeProofAlice
+				if rangeProofAliceJ, err = r1msg.UnmarshalRangeProofAlice(); err != nil {
+					errChs <- round.WrapError(errorspkg.Wrapf(err, "UnmarshalRangeProofAlice failed"), Pj)
+					return
+				}
+				beta, c1ji, _, pi1ji, err = mta.BobMid(
+					ContextI,
+					round.Parameters.EC(),
+					round.key.PaillierPKs[j],
+					rangeProofAliceJ,
+					round.temp.gamma,
+					r1msg.UnmarshalC(),
+					round.key.NTildej[j],
+					round.key.H1j[j],
+					round.key.H2j[j],
+					round.key.NTildej[i],
+					round.key.H1j[i],
+					round.key.H2j[i])
 			}
-			beta, c1ji, _, pi1ji, err := mta.BobMid(
-				ContextI,
-				round.Parameters.EC(),
-				round.key.PaillierPKs[j],
-				rangeProofAliceJ,
-				round.temp.gamma,
-				r1msg.UnmarshalC(),
-				round.key.NTildej[j],
-				round.key.H1j[j],
-				round.key.H2j[j],
-				round.key.NTildej[i],
-				round.key.H1j[i],
-				round.key.H2j[i])
 			// should be thread safe as these are pre-allocated
 			round.temp.betas[j] = beta
 			round.temp.c1jis[j] = c1ji
@@ -70,25 +86,42 @@ func (round *round2) Start() *tss.Error {
 		go func(j int, Pj *tss.PartyID) {
 			defer wg.Done()
 			r1msg := round.temp.signRound1Message1s[j].Content().(*SignRound1Message1)
-			rangeProofAliceJ, err := r1msg.UnmarshalRangeProofAlice()
-			if err != nil {
-				errChs <- round.WrapError(errorspkg.Wrapf(err, "UnmarshalRangeProofAlice failed"), Pj)
-				return
+			var v, c2ji *big.Int
+			var pi2ji *mta.ProofBobWC
+			var err error
+			if round.temp.batched {
+				// the batch has verified the range proof with those of its other sessions
+				v, c2ji, _, pi2ji, err = mta.BobRespondWC(
+					ContextI,
+					round.Parameters.EC(),
+					round.key.PaillierPKs[j],
+					round.temp.w,
+					r1msg.UnmarshalC(),
+					round.key.NTildej[j],
+					round.key.H1j[j],
+					round.key.H2j[j],
+					round.temp.bigWs[i])
+			} else {
+				var rangeProofAliceJ *mta.RangeProofAlice
+				if rangeProofAliceJ, err = r1msg.UnmarshalRangeProofAlice(); err != nil {
+					errChs <- round.WrapError(errorspkg.Wrapf(err, "UnmarshalRangeProofAlice failed"), Pj)
+					return
+				}
+				v, c2ji, _, pi2ji, err = mta.BobMidWC(
+					ContextI,
+					round.Parameters.EC(),
+					round.key.PaillierPKs[j],
+					rangeProofAliceJ,
+					round.temp.w,
+					r1msg.UnmarshalC(),
+					round.key.NTildej[j],
+					round.key.H1j[j],
+					round.key.H2j[j],
+					round.key.NTildej[i],
+					round.key.H1j[i],
+					round.key.H2j[i],
+					round.temp.bigWs[i])
 			}
-			v, c2ji, _, pi2ji, err := mta.BobMidWC(
-				ContextI,
-				round.Parameters.EC(),
-				round.key.PaillierPKs[j],
-				rangeProofAliceJ,
-				round.temp.w,
-				r1msg.UnmarshalC(),
-				round.key.NTildej[j],
-				round.key.H1j[j],
-				round.key.H2j[j],
-				round.key.NTildej[i],
-				round.key.H1j[i],
-				round.key.H2j[i],
-				round.temp.bigWs[i])
 			round.temp.vs[j] = v
 			round.temp.c2jis[j] = c2ji
 			round.temp.pi2jis[j] = pi2ji
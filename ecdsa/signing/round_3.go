@@ -117,7 +117,13 @@ func (round *round3) Start() *tss.Error {
 
 	round.temp.theta = thelta
 	round.temp.sigma = sigma
-	r3msg := NewSignRound3Message(round.PartyID(), thelta)
+
+	// commit to sigmai before R is known, for the presign checks
+	bigTi, tProof, tErr := round.commitSigma()
+	if tErr != nil {
+		return tErr
+	}
+	r3msg := NewSignRound3Message(round.PartyID(), thelta, bigTi, tProof)
 	round.temp.signRound3Messages[round.PartyID().Index] = r3msg
 	round.out <- r3msg
 
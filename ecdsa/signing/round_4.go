@@ -25,6 +25,11 @@ func (round *round4) Start() *tss.Error {
 	round.started = true
 	round.resetOK()
 
+	// the commitments Tj are checked before Gamma is revealed, and so before R is known
+	if err := round.verifySigmaCommitments(); err != nil {
+		return err
+	}
+
 	theta := *round.temp.theta
 	thetaInverse := &theta
 
@@ -79,5 +84,8 @@ func (round *round4) CanAccept(msg tss.ParsedMessage) bool {
 
 func (round *round4) NextRound() tss.Round {
 	round.started = false
+	if round.presignEnd != nil {
+		return &presignCheck{round}
+	}
 	return &round5{round}
 }
@@ -26,45 +26,23 @@ func (round *round5) Start() *tss.Error {
 	round.started = true
 	round.resetOK()
 
-	R := round.temp.pointGamma
-	for j, Pj := range round.Parties().IDs() {
-		if j == round.PartyID().Index {
-			continue
-		}
-		ContextJ := common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(j)))
-		r1msg2 := round.temp.signRound1Message2s[j].Content().(*SignRound1Message2)
-		r4msg := round.temp.signRound4Messages[j].Content().(*SignRound4Message)
-		SCj, SDj := r1msg2.UnmarshalCommitment(), r4msg.UnmarshalDeCommitment()
-		cmtDeCmt := commitments.HashCommitDecommit{C: SCj, D: SDj}
-		ok, bigGammaJ := cmtDeCmt.DeCommit()
-		if !ok || len(bigGammaJ) != 2 {
-			return round.WrapError(errors.New("commitment verify failed"), Pj)
-		}
-		bigGammaJPoint, err := crypto.NewECPoint(round.Params().EC(), bigGammaJ[0], bigGammaJ[1])
-		if err != nil {
-			return round.WrapError(errors2.Wrapf(err, "NewECPoint(bigGammaJ)"), Pj)
-		}
-		proof, err := r4msg.UnmarshalZKProof(round.Params().EC())
-		if err != nil {
-			return round.WrapError(errors.New("failed to unmarshal bigGamma proof"), Pj)
-		}
-		ok = proof.Verify(ContextJ, bigGammaJPoint)
-		if !ok {
-			return round.WrapError(errors.New("failed to prove bigGamma"), Pj)
-		}
-		R, err = R.Add(bigGammaJPoint)
-		if err != nil {
-			return round.WrapError(errors2.Wrapf(err, "R.Add(bigGammaJ)"), Pj)
-		}
+	R, tErr := round.computeR()
+	if tErr != nil {
+		return tErr
 	}
 
-	R = R.ScalarMult(round.temp.thetaInverse)
 	N := round.Params().EC().Params().N
 	modN := common.ModInt(N)
 	rx := R.X()
 	ry := R.Y()
 	si := modN.Add(modN.Mul(round.temp.m, round.temp.k), modN.Mul(rx, round.temp.sigma))
 
+	// the presign checks of GG20 run alongside the commitment to Vi, and are verified before si is used in round 6
+	round.temp.bigR = R
+	if err := round.startConsistencyCheck(R); err != nil {
+		return err
+	}
+
 	// clear temp.w and temp.k from memory, lint ignore
 	round.temp.w = zero
 	round.temp.k = zero
@@ -92,11 +70,47 @@ func (round *round5) Start() *tss.Error {
 	round.temp.si = si
 	round.temp.rx = rx
 	round.temp.ry = ry
-	round.temp.bigR = R
 
 	return nil
 }
 
+// computeR checks the bigGammaJ revealed in round 4 and computes R = (Prod(bigGammaJ))^(thetaInverse)
+func (round *base) computeR() (*crypto.ECPoint, *tss.Error) {
+	R := round.temp.pointGamma
+	for j, Pj := range round.Parties().IDs() {
+		if j == round.PartyID().Index {
+			continue
+		}
+		ContextJ := common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(j)))
+		r1msg2 := round.temp.signRound1Message2s[j].Content().(*SignRound1Message2)
+		r4msg := round.temp.signRound4Messages[j].Content().(*SignRound4Message)
+		SCj, SDj := r1msg2.UnmarshalCommitment(), r4msg.UnmarshalDeCommitment()
+		cmtDeCmt := commitments.HashCommitDecommit{C: SCj, D: SDj}
+		ok, bigGammaJ := cmtDeCmt.DeCommit()
+		if !ok || len(bigGammaJ) != 2 {
+			return nil, round.WrapError(errors.New("commitment verify failed"), Pj)
+		}
+		bigGammaJPoint, err := crypto.NewECPoint(round.Params().EC(), bigGammaJ[0], bigGammaJ[1])
+		if err != nil {
+			return nil, round.WrapError(errors2.Wrapf(err, "NewECPoint(bigGammaJ)"), Pj)
+		}
+		proof, err := r4msg.UnmarshalZKProof(round.Params().EC())
+		if err != nil {
+			return nil, round.WrapError(errors.New("failed to unmarshal bigGamma proof"), Pj)
+		}
+		ok = proof.Verify(ContextJ, bigGammaJPoint)
+		if !ok {
+			return nil, round.WrapError(errors.New("failed to prove bigGamma"), Pj)
+		}
+		R, err = R.Add(bigGammaJPoint)
+		if err != nil {
+			return nil, round.WrapError(errors2.Wrapf(err, "R.Add(bigGammaJ)"), Pj)
+		}
+	}
+
+	return R.ScalarMult(round.temp.thetaInverse), nil
+}
+
 func (round *round5) Update() (bool, *tss.Error) {
 	ret := true
 	for j, msg := range round.temp.signRound5Messages {
@@ -107,6 +121,14 @@ func (round *round5) Update() (bool, *tss.Error) {
 			ret = false
 			continue
 		}
+		if cMsg := round.temp.signConsistencyMessages[j]; cMsg == nil || !round.canAcceptConsistency(cMsg) {
+			ret = false
+			continue
+		}
+		if pMsg := round.temp.signConsistencyProofMessages[j]; pMsg == nil || !round.canAcceptConsistency(pMsg) {
+			ret = false
+			continue
+		}
 		round.ok[j] = true
 	}
 	return ret, nil
@@ -116,7 +138,7 @@ func (round *round5) CanAccept(msg tss.ParsedMessage) bool {
 	if _, ok := msg.Content().(*SignRound5Message); ok {
 		return msg.IsBroadcast()
 	}
-	return false
+	return round.canAcceptConsistency(msg)
 }
 
 func (round *round5) NextRound() tss.Round {
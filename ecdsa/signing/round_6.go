@@ -24,6 +24,11 @@ func (round *round6) Start() *tss.Error {
 	round.started = true
 	round.resetOK()
 
+	// si is only used once the presign checks have passed, so that a wrong share of s can be identified
+	if err := round.verifyConsistency(); err != nil {
+		return err
+	}
+
 	i := round.PartyID().Index
 	ContextI := append(round.temp.ssid, new(big.Int).SetUint64(uint64(i)).Bytes()...)
 	piAi, err := schnorr.NewZKProof(ContextI, round.temp.roi, round.temp.bigAi)
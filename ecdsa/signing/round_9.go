@@ -33,15 +33,16 @@ func (round *round9) Start() *tss.Error {
 		cj, dj := r7msg.UnmarshalCommitment(), r8msg.UnmarshalDeCommitment()
 		cmt := commitments.HashCommitDecommit{C: cj, D: dj}
 		ok, values := cmt.DeCommit()
-		if !ok && len(values) != 4 {
-			return round.WrapError(errors.New("de-commitment for bigVj and bigAj failed"), Pj)
+		if !ok || len(values) != 4 {
+			return round.WrapError(errors.New("de-commitment for Uj and Tj failed"), Pj)
 		}
 		UjX, UjY, TjX, TjY := values[0], values[1], values[2], values[3]
 		UX, UY = round.Params().EC().Add(UX, UY, UjX, UjY)
 		TX, TY = round.Params().EC().Add(TX, TY, TjX, TjY)
 	}
 	if UX.Cmp(TX) != 0 || UY.Cmp(TY) != 0 {
-		return round.WrapError(errors.New("U doesn't equal T"), round.PartyID())
+		// do not reveal si; find the party at fault instead
+		return round.startIdentification(causeUNotEqualT)
 	}
 
 	r9msg := NewSignRound9Message(round.PartyID(), round.temp.si)
@@ -51,6 +52,9 @@ func (round *round9) Start() *tss.Error {
 }
 
 func (round *round9) Update() (bool, *tss.Error) {
+	if round.temp.identifyCause != "" {
+		return round.updateIdentification()
+	}
 	ret := true
 	for j, msg := range round.temp.signRound9Messages {
 		if round.ok[j] {
@@ -66,6 +70,9 @@ func (round *round9) Update() (bool, *tss.Error) {
 }
 
 func (round *round9) CanAccept(msg tss.ParsedMessage) bool {
+	if round.temp.identifyCause != "" {
+		return round.canAcceptIdentification(msg)
+	}
 	if _, ok := msg.Content().(*SignRound9Message); ok {
 		return msg.IsBroadcast()
 	}
@@ -74,5 +81,8 @@ func (round *round9) CanAccept(msg tss.ParsedMessage) bool {
 
 func (round *round9) NextRound() tss.Round {
 	round.started = false
+	if round.temp.identifyCause != "" {
+		return &identification{&finalization{round}}
+	}
 	return &finalization{round}
 }
@@ -0,0 +1,285 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto/mta"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// batchRound runs the current round of every session of a batch; a session that has finished has a nil round
+type batchRound struct {
+	party   *BatchParty
+	rounds  []tss.Round
+	started bool
+}
+
+var _ tss.Round = (*batchRound)(nil)
+
+func (round *batchRound) Params() *tss.Parameters {
+	return round.party.params
+}
+
+func (round *batchRound) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.started = true
+
+	if err := round.verifyRangeProofs(); err != nil {
+		return err
+	}
+	for k, rnd := range round.rounds {
+		if rnd == nil {
+			continue
+		}
+		if err := rnd.Start(); err != nil {
+			return round.wrapSessionError(k, err)
+		}
+	}
+	if err := round.proveRanges(); err != nil {
+		return err
+	}
+	if err := round.sendBatchMessages(); err != nil {
+		return err
+	}
+
+	// output the signatures once every session has finished
+	p := round.party
+	for k, end := range p.ends {
+		select {
+		case p.sigs[k] = <-end:
+		default:
+		}
+	}
+	for _, sig := range p.sigs {
+		if sig == nil {
+			return nil
+		}
+	}
+	for _, sig := range p.sigs {
+		p.end <- sig
+	}
+	return nil
+}
+
+func (round *batchRound) CanAccept(msg tss.ParsedMessage) bool {
+	_, ok := msg.Content().(*SignBatchMessage)
+	return ok
+}
+
+func (round *batchRound) Update() (bool, *tss.Error) {
+	ret := true
+	for k, rnd := range round.rounds {
+		if rnd == nil {
+			continue
+		}
+		ok, err := rnd.Update()
+		if err != nil {
+			return false, round.wrapSessionError(k, err)
+		}
+		ret = ret && ok
+	}
+	return ret, nil
+}
+
+func (round *batchRound) CanProceed() bool {
+	if !round.started {
+		return false
+	}
+	for _, rnd := range round.rounds {
+		if rnd != nil && !rnd.CanProceed() {
+			return false
+		}
+	}
+	return true
+}
+
+func (round *batchRound) NextRound() tss.Round {
+	next := make([]tss.Round, len(round.rounds))
+	finished := true
+	for k, rnd := range round.rounds {
+		if rnd == nil {
+			continue
+		}
+		if next[k] = rnd.NextRound(); next[k] != nil {
+			finished = false
+		}
+	}
+	if finished {
+		return nil // finished!
+	}
+	return &batchRound{round.party, next, false}
+}
+
+func (round *batchRound) RoundNumber() int {
+	number := 0
+	for _, rnd := range round.rounds {
+		if rnd != nil && number < rnd.RoundNumber() {
+			number = rnd.RoundNumber()
+		}
+	}
+	return number
+}
+
+// WaitingFor returns the parties that any session is waiting for
+func (round *batchRound) WaitingFor() []*tss.PartyID {
+	waiting := make(map[int]*tss.PartyID)
+	for _, rnd := range round.rounds {
+		if rnd == nil {
+			continue
+		}
+		for _, Pj := range rnd.WaitingFor() {
+			waiting[Pj.Index] = Pj
+		}
+	}
+	ids := make([]*tss.PartyID, 0, len(waiting))
+	for _, Pj := range waiting {
+		ids = append(ids, Pj)
+	}
+	sort.Slice(ids, func(a, b int) bool { return ids[a].Index < ids[b].Index })
+	return ids
+}
+
+func (round *batchRound) WrapError(err error, culprits ...*tss.PartyID) *tss.Error {
+	return tss.NewError(err, TaskName, round.RoundNumber(), round.Params().PartyID(), culprits...)
+}
+
+func (round *batchRound) wrapSessionError(k int, err *tss.Error) *tss.Error {
+	return tss.NewError(fmt.Errorf("digest %d: %v", k, err.Cause()), TaskName, err.Round(), err.Victim(), err.Culprits()...)
+}
+
+// proveRanges runs after round 1 of the sessions and sends their SignRound1Message1s; the ranges of the ciphertexts
+// of all the sessions for a peer are proven in one mta.BatchRangeProofAlice
+func (round *batchRound) proveRanges() *tss.Error {
+	if _, ok := round.rounds[0].(*round1); !ok {
+		return nil
+	}
+	p := round.party
+	key := p.sessions[0].keys
+	i := round.Params().PartyID().Index
+	for j, Pj := range round.Params().Parties().IDs() {
+		if j == i {
+			continue
+		}
+		cs := make([]*big.Int, len(p.sessions))
+		ks := make([]*big.Int, len(p.sessions))
+		rs := make([]*big.Int, len(p.sessions))
+		for k, session := range p.sessions {
+			cs[k], ks[k], rs[k] = session.temp.cis[j], session.temp.k, session.temp.cRandomness[j]
+		}
+		pi, err := mta.ProveBatchRangeAlice(round.Params().EC(), key.PaillierPKs[i], cs, key.NTildej[j], key.H1j[j], key.H2j[j], ks, rs)
+		if err != nil {
+			return round.WrapError(fmt.Errorf("failed to init mta: %v", err))
+		}
+		for k := range p.sessions {
+			p.outs[k] <- NewSignRound1Message1(Pj, round.Params().PartyID(), cs[k], pi[k])
+		}
+	}
+	return nil
+}
+
+// verifyRangeProofs runs before round 2 of the sessions, which rely on it to verify the range proofs of round 1; the
+// proofs of a peer for all the sessions are verified together as one mta.BatchRangeProofAlice
+func (round *batchRound) verifyRangeProofs() *tss.Error {
+	if _, ok := round.rounds[0].(*round2); !ok {
+		return nil
+	}
+	p := round.party
+	key := p.sessions[0].keys
+	i := round.Params().PartyID().Index
+	culpritCh := make(chan *tss.PartyID, len(round.Params().Parties().IDs()))
+	wg := sync.WaitGroup{}
+	for j, Pj := range round.Params().Parties().IDs() {
+		if j == i {
+			continue
+		}
+		wg.Add(1)
+		go func(j int, Pj *tss.PartyID) {
+			defer wg.Done()
+			cs := make([]*big.Int, len(p.sessions))
+			pi := make(mta.BatchRangeProofAlice, len(p.sessions))
+			for k, session := range p.sessions {
+				r1msg := session.temp.signRound1Message1s[j].Content().(*SignRound1Message1)
+				proof, err := r1msg.UnmarshalRangeProofAlice()
+				if err != nil {
+					culpritCh <- Pj
+					return
+				}
+				cs[k], pi[k] = r1msg.UnmarshalC(), proof
+			}
+			if !pi.Verify(round.Params().EC(), key.PaillierPKs[j], key.NTildej[i], key.H1j[i], key.H2j[i], cs) {
+				culpritCh <- Pj
+			}
+		}(j, Pj)
+	}
+	wg.Wait()
+	close(culpritCh)
+	culprits := make([]*tss.PartyID, 0, len(round.Params().Parties().IDs()))
+	for Pj := range culpritCh {
+		culprits = append(culprits, Pj)
+	}
+	if len(culprits) > 0 {
+		sort.Slice(culprits, func(a, b int) bool { return culprits[a].Index < culprits[b].Index })
+		return round.WrapError(errors.New("failed to verify the batched range proofs of Alice"), culprits...)
+	}
+	return nil
+}
+
+// sendBatchMessages combines the messages that the sessions sent in this round into one SignBatchMessage for each
+// recipient and one for the broadcast
+func (round *batchRound) sendBatchMessages() *tss.Error {
+	type batch struct {
+		to       []*tss.PartyID
+		indexes  []uint32
+		messages [][]byte
+	}
+	const broadcast = -1
+	batches := make(map[int]*batch)
+	for k, out := range round.party.outs {
+	drain:
+		for {
+			select {
+			case msg := <-out:
+				key, to := broadcast, []*tss.PartyID(nil)
+				if !msg.IsBroadcast() {
+					if len(msg.GetTo()) != 1 {
+						return round.WrapError(errors.New("a session sent a message to an unexpected number of parties"))
+					}
+					key, to = msg.GetTo()[0].Index, msg.GetTo()
+				}
+				bz, _, err := msg.WireBytes()
+				if err != nil {
+					return round.WrapError(err)
+				}
+				if batches[key] == nil {
+					batches[key] = &batch{to: to}
+				}
+				batches[key].indexes = append(batches[key].indexes, uint32(k))
+				batches[key].messages = append(batches[key].messages, bz)
+			default:
+				break drain
+			}
+		}
+	}
+	keys := make([]int, 0, len(batches))
+	for key := range batches {
+		keys = append(keys, key)
+	}
+	sort.Ints(keys)
+	for _, key := range keys {
+		b := batches[key]
+		round.party.out <- NewSignBatchMessage(round.Params().PartyID(), b.to, key == broadcast, b.indexes, b.messages)
+	}
+	return nil
+}
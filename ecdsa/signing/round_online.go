@@ -0,0 +1,113 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func (round *onlineRound) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	if round.temp.m.Cmp(round.Params().EC().Params().N) >= 0 {
+		return round.WrapError(errors.New("hashed message is not valid"))
+	}
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	modN := common.ModInt(round.Params().EC().Params().N)
+	round.temp.rx = round.temp.bigR.X()
+	round.temp.ry = round.temp.bigR.Y()
+	round.temp.si = modN.Add(modN.Mul(round.temp.m, round.temp.k), modN.Mul(round.temp.rx, round.temp.sigma))
+
+	// clear temp.k and temp.sigma from memory, lint ignore
+	round.temp.k = zero
+	round.temp.sigma = zero
+
+	// the share of s is sent in the same message as in round 9 of the full protocol
+	r9msg := NewSignRound9Message(round.PartyID(), round.temp.si)
+	round.temp.signRound9Messages[round.PartyID().Index] = r9msg
+	round.out <- r9msg
+	return nil
+}
+
+func (round *onlineRound) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.signRound9Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *onlineRound) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignRound9Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *onlineRound) NextRound() tss.Round {
+	round.started = false
+	return &onlineFinalization{round}
+}
+
+// ----- //
+
+func (round *onlineFinalization) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	if !round.buildSignature() {
+		return round.WrapError(errors.New("signature verification failed; discard the presignature"), round.wrongShares()...)
+	}
+
+	round.end <- round.data
+
+	return nil
+}
+
+// wrongShares returns the parties whose share sj does not match R^sj = Rj^m * Sj^r
+func (round *onlineFinalization) wrongShares() []*tss.PartyID {
+	culprits := make([]*tss.PartyID, 0)
+	for j, Pj := range round.Parties().IDs() {
+		sj := round.temp.signRound9Messages[j].Content().(*SignRound9Message).UnmarshalS()
+		if !checkShare(round.temp.bigR, round.temp.bigRis[j], round.temp.bigSis[j], round.temp.m, round.temp.rx, sj) {
+			culprits = append(culprits, Pj)
+		}
+	}
+	return culprits
+}
+
+func (round *onlineFinalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *onlineFinalization) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *onlineFinalization) NextRound() tss.Round {
+	return nil // finished!
+}
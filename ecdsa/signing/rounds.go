@@ -31,6 +31,9 @@ type (
 		ok      []bool // `ok` tracks parties which have been verified by Update()
 		started bool
 		number  int
+
+		// set only when presigning
+		presignEnd chan<- *PreSignatureData
 	}
 	round1 struct {
 		*base
@@ -62,6 +65,21 @@ type (
 	finalization struct {
 		*round9
 	}
+	identification struct {
+		*finalization
+	}
+	presignCheck struct {
+		*round4
+	}
+	presignFinalization struct {
+		*presignCheck
+	}
+	onlineRound struct {
+		*base
+	}
+	onlineFinalization struct {
+		*onlineRound
+	}
 )
 
 var (
@@ -75,6 +93,11 @@ var (
 	_ tss.Round = (*round8)(nil)
 	_ tss.Round = (*round9)(nil)
 	_ tss.Round = (*finalization)(nil)
+	_ tss.Round = (*identification)(nil)
+	_ tss.Round = (*presignCheck)(nil)
+	_ tss.Round = (*presignFinalization)(nil)
+	_ tss.Round = (*onlineRound)(nil)
+	_ tss.Round = (*onlineFinalization)(nil)
 )
 
 // ----- //
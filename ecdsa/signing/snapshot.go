@@ -0,0 +1,305 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	cmt "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/mta"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+type snapshotState struct {
+	Keys     keygen.LocalPartySaveData
+	Data     *common.SignatureData
+	OK       []bool
+	Messages [][]*tss.SnapshotMessage
+
+	W, M, K, Theta, ThetaInverse, Sigma, KeyDerivationDelta, Gamma *big.Int
+	Cis, CRandomness                                               []*big.Int
+	BigWs                                                          []*crypto.ECPoint
+	PointGamma                                                     *crypto.ECPoint
+	DeCommit                                                       cmt.HashDeCommitment
+
+	Betas, C1jis, C2jis, Vs []*big.Int
+	Pi1jis                  []*mta.ProofBob
+	Pi2jis                  []*mta.ProofBobWC
+
+	BigTis        []*crypto.ECPoint
+	SigmaBlinding *big.Int
+
+	BigRis, BigSis []*crypto.ECPoint
+
+	Li, Si, Rx, Ry, Roi *big.Int
+	BigR, BigAi, BigVi  *crypto.ECPoint
+	DPower              cmt.HashDeCommitment
+
+	Ui, Ti *crypto.ECPoint
+	DTelda cmt.HashDeCommitment
+
+	IdentifyCause string
+	Presign       bool
+
+	SSIDNonce *big.Int
+	SSID      []byte
+	Digest    []byte
+}
+
+// Snapshot exports the in-flight state of this party, encrypted with a key of tss.SnapshotKeyLength bytes.
+// The snapshot contains secret key material and should be stored with the same care as the key share itself.
+func (p *LocalParty) Snapshot(key []byte) ([]byte, *tss.Error) {
+	return tss.BaseSnapshot(p, TaskName, key, p.snapshotState)
+}
+
+// RestoreLocalParty rebuilds a party from a snapshot taken with Snapshot(). The party continues from the round it was in,
+// so it must not be started again; feed it the messages it has not yet received through Update() or UpdateFromBytes().
+// `params` must describe the same party and peers as the ones used to construct the original party.
+func RestoreLocalParty(
+	params *tss.Parameters,
+	snapshot, key []byte,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+) (tss.Party, *tss.Error) {
+	p := &LocalParty{
+		BaseParty: new(tss.BaseParty),
+		params:    params,
+		temp:      localTempData{},
+		data:      &common.SignatureData{},
+		out:       out,
+		end:       end,
+	}
+	if err := tss.BaseRestore(p, TaskName, snapshot, key, p.restoreState); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// RestorePresignParty is like RestoreLocalParty for a snapshot of a party created with NewPresignParty.
+func RestorePresignParty(
+	params *tss.Parameters,
+	snapshot, key []byte,
+	out chan<- tss.Message,
+	end chan<- *PreSignatureData,
+) (tss.Party, *tss.Error) {
+	p := &LocalParty{
+		BaseParty:  new(tss.BaseParty),
+		params:     params,
+		temp:       localTempData{},
+		data:       &common.SignatureData{},
+		out:        out,
+		presignEnd: end,
+	}
+	if err := tss.BaseRestore(p, TaskName, snapshot, key, p.restoreState); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *LocalParty) messageStores() []*[]tss.ParsedMessage {
+	return []*[]tss.ParsedMessage{
+		&p.temp.signRound1Message1s,
+		&p.temp.signRound1Message2s,
+		&p.temp.signRound2Messages,
+		&p.temp.signRound3Messages,
+		&p.temp.signRound4Messages,
+		&p.temp.signRound5Messages,
+		&p.temp.signRound6Messages,
+		&p.temp.signRound7Messages,
+		&p.temp.signRound8Messages,
+		&p.temp.signRound9Messages,
+		&p.temp.signIdentificationMessages,
+		&p.temp.signConsistencyMessages,
+		&p.temp.signConsistencyProofMessages,
+	}
+}
+
+func (p *LocalParty) snapshotState(rnd tss.Round) (interface{}, error) {
+	round, err := roundBase(rnd)
+	if err != nil {
+		return nil, err
+	}
+	state := &snapshotState{
+		Keys:               p.keys,
+		Data:               p.data,
+		OK:                 round.ok,
+		W:                  p.temp.w,
+		M:                  p.temp.m,
+		K:                  p.temp.k,
+		Theta:              p.temp.theta,
+		ThetaInverse:       p.temp.thetaInverse,
+		Sigma:              p.temp.sigma,
+		KeyDerivationDelta: p.temp.keyDerivationDelta,
+		Gamma:              p.temp.gamma,
+		Cis:                p.temp.cis,
+		CRandomness:        p.temp.cRandomness,
+		BigWs:              p.temp.bigWs,
+		PointGamma:         p.temp.pointGamma,
+		DeCommit:           p.temp.deCommit,
+		Betas:              p.temp.betas,
+		C1jis:              p.temp.c1jis,
+		C2jis:              p.temp.c2jis,
+		Vs:                 p.temp.vs,
+		Pi1jis:             p.temp.pi1jis,
+		Pi2jis:             p.temp.pi2jis,
+		BigTis:             p.temp.bigTis,
+		SigmaBlinding:      p.temp.sigmaBlinding,
+		BigRis:             p.temp.bigRis,
+		BigSis:             p.temp.bigSis,
+		Li:                 p.temp.li,
+		Si:                 p.temp.si,
+		Rx:                 p.temp.rx,
+		Ry:                 p.temp.ry,
+		Roi:                p.temp.roi,
+		BigR:               p.temp.bigR,
+		BigAi:              p.temp.bigAi,
+		BigVi:              p.temp.bigVi,
+		DPower:             p.temp.DPower,
+		Ui:                 p.temp.Ui,
+		Ti:                 p.temp.Ti,
+		DTelda:             p.temp.DTelda,
+		IdentifyCause:      p.temp.identifyCause,
+		Presign:            p.presignEnd != nil,
+		SSIDNonce:          p.temp.ssidNonce,
+		SSID:               p.temp.ssid,
+		Digest:             p.temp.digest,
+	}
+	for _, store := range p.messageStores() {
+		msgs, err := tss.NewSnapshotMessages(*store)
+		if err != nil {
+			return nil, err
+		}
+		state.Messages = append(state.Messages, msgs)
+	}
+	return state, nil
+}
+
+func (p *LocalParty) restoreState(stateBz []byte, number int) (tss.Round, error) {
+	state := new(snapshotState)
+	if err := json.Unmarshal(stateBz, state); err != nil {
+		return nil, err
+	}
+	partyCount := len(p.params.Parties().IDs())
+	stores := p.messageStores()
+	if len(state.OK) != partyCount || len(state.Messages) != len(stores) {
+		return nil, errors.New("the snapshot does not match the parties in params")
+	}
+	for k, store := range stores {
+		msgs, err := tss.ParseSnapshotMessages(state.Messages[k], partyCount)
+		if err != nil {
+			return nil, err
+		}
+		*store = msgs
+	}
+	if state.Presign != (p.presignEnd != nil) {
+		return nil, errors.New("the snapshot was not taken from the same kind of party")
+	}
+	p.keys = state.Keys
+	if state.Data != nil {
+		p.data = state.Data
+	}
+	p.temp.w = state.W
+	p.temp.m = state.M
+	p.temp.k = state.K
+	p.temp.theta = state.Theta
+	p.temp.thetaInverse = state.ThetaInverse
+	p.temp.sigma = state.Sigma
+	p.temp.keyDerivationDelta = state.KeyDerivationDelta
+	p.temp.gamma = state.Gamma
+	p.temp.cis = state.Cis
+	p.temp.cRandomness = state.CRandomness
+	p.temp.bigWs = state.BigWs
+	p.temp.pointGamma = state.PointGamma
+	p.temp.deCommit = state.DeCommit
+	p.temp.betas = state.Betas
+	p.temp.c1jis = state.C1jis
+	p.temp.c2jis = state.C2jis
+	p.temp.vs = state.Vs
+	p.temp.pi1jis = state.Pi1jis
+	p.temp.pi2jis = state.Pi2jis
+	p.temp.bigTis = state.BigTis
+	p.temp.sigmaBlinding = state.SigmaBlinding
+	p.temp.bigRis = state.BigRis
+	p.temp.bigSis = state.BigSis
+	p.temp.li = state.Li
+	p.temp.si = state.Si
+	p.temp.rx = state.Rx
+	p.temp.ry = state.Ry
+	p.temp.roi = state.Roi
+	p.temp.bigR = state.BigR
+	p.temp.bigAi = state.BigAi
+	p.temp.bigVi = state.BigVi
+	p.temp.DPower = state.DPower
+	p.temp.Ui = state.Ui
+	p.temp.Ti = state.Ti
+	p.temp.DTelda = state.DTelda
+	p.temp.identifyCause = state.IdentifyCause
+	p.temp.ssidNonce = state.SSIDNonce
+	p.temp.ssid = state.SSID
+	p.temp.digest = state.Digest
+
+	r1 := newRound1(p.params, &p.keys, p.data, &p.temp, p.out, p.end, p.presignEnd).(*round1)
+	copy(r1.ok, state.OK)
+	r1.started = true
+	r1.number = number
+	r2 := &round2{r1}
+	r3 := &round3{r2}
+	r4 := &round4{r3}
+	r5 := &round5{r4}
+	r6 := &round6{r5}
+	r7 := &round7{r6}
+	r8 := &round8{r7}
+	r9 := &round9{r8}
+	fin := &finalization{r9}
+	rounds := []tss.Round{r1, r2, r3, r4, r5, r6, r7, r8, r9, fin, &identification{fin}}
+	if p.presignEnd != nil {
+		check := &presignCheck{r4}
+		rounds = []tss.Round{r1, r2, r3, r4, check, &presignFinalization{check}}
+	}
+	if number < 1 || len(rounds) < number {
+		return nil, fmt.Errorf("the snapshot has an unexpected round number %d", number)
+	}
+	return rounds[number-1], nil
+}
+
+func roundBase(rnd tss.Round) (*base, error) {
+	switch round := rnd.(type) {
+	case *round1:
+		return round.base, nil
+	case *round2:
+		return round.base, nil
+	case *round3:
+		return round.base, nil
+	case *round4:
+		return round.base, nil
+	case *round5:
+		return round.base, nil
+	case *round6:
+		return round.base, nil
+	case *round7:
+		return round.base, nil
+	case *round8:
+		return round.base, nil
+	case *round9:
+		return round.base, nil
+	case *finalization:
+		return round.base, nil
+	case *identification:
+		return round.base, nil
+	case *presignCheck:
+		return round.base, nil
+	case *presignFinalization:
+		return round.base, nil
+	}
+	return nil, fmt.Errorf("unexpected round type %T", rnd)
+}
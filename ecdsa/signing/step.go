@@ -0,0 +1,71 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const stepRounds = 9
+
+// StepParty runs a signing party without channels or goroutines, which suits event loops, actors and deterministic tests.
+// Every call returns the messages that must be sent to the other parties and, once signing has finished, the signature.
+type StepParty struct {
+	party *LocalParty
+	out   chan tss.Message
+	end   chan *common.SignatureData
+}
+
+// NewStepParty creates a signing party driven by calls to Start and Update. The arguments are the same as for NewLocalParty.
+func NewStepParty(msg *big.Int, params *tss.Parameters, key keygen.LocalPartySaveData) *StepParty {
+	return NewStepPartyWithKDD(msg, params, key, nil)
+}
+
+// NewStepPartyWithKDD returns a step party with key derivation delta for HD support
+func NewStepPartyWithKDD(msg *big.Int, params *tss.Parameters, key keygen.LocalPartySaveData, keyDerivationDelta *big.Int) *StepParty {
+	out := make(chan tss.Message, tss.StepBufferSize(stepRounds, len(params.Parties().IDs())))
+	end := make(chan *common.SignatureData, 1)
+	return &StepParty{
+		party: NewLocalPartyWithKDD(msg, params, key, keyDerivationDelta, out, end).(*LocalParty),
+		out:   out,
+		end:   end,
+	}
+}
+
+// Party returns the underlying party, e.g. to query WaitingFor() or take a Snapshot().
+func (p *StepParty) Party() tss.Party {
+	return p.party
+}
+
+func (p *StepParty) Start() ([]tss.Message, *common.SignatureData, *tss.Error) {
+	return p.collect(p.party.Start())
+}
+
+func (p *StepParty) Update(msg tss.ParsedMessage) ([]tss.Message, *common.SignatureData, *tss.Error) {
+	_, err := p.party.Update(msg)
+	return p.collect(err)
+}
+
+func (p *StepParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) ([]tss.Message, *common.SignatureData, *tss.Error) {
+	_, err := p.party.UpdateFromBytes(wireBytes, from, isBroadcast)
+	return p.collect(err)
+}
+
+// messages produced before an error are still returned
+func (p *StepParty) collect(err *tss.Error) ([]tss.Message, *common.SignatureData, *tss.Error) {
+	msgs := tss.DrainMessages(p.out)
+	select {
+	case sig := <-p.end:
+		return msgs, sig, err
+	default:
+		return msgs, nil, err
+	}
+}
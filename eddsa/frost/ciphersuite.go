@@ -0,0 +1,202 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package frost
+
+import (
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha512"
+	"errors"
+	"math/big"
+
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+)
+
+// The FROST(Ed25519, SHA-512) ciphersuite of RFC 9591, section 6.1
+
+const (
+	contextString = "FROST-ED25519-SHA512-v1"
+
+	scalarLength  = 32
+	elementLength = 32
+)
+
+// h1 derives the binding factors
+func h1(ec elliptic.Curve, m ...[]byte) *big.Int {
+	return hashToScalar(ec, append([][]byte{[]byte(contextString + "rho")}, m...)...)
+}
+
+// h2 derives the challenge; it is the hash of standard Ed25519 and has no context string
+func h2(ec elliptic.Curve, m ...[]byte) *big.Int {
+	return hashToScalar(ec, m...)
+}
+
+// h3 derives the nonces
+func h3(ec elliptic.Curve, m ...[]byte) *big.Int {
+	return hashToScalar(ec, append([][]byte{[]byte(contextString + "nonce")}, m...)...)
+}
+
+// h4 hashes the message
+func h4(m []byte) []byte {
+	return hash(append([][]byte{[]byte(contextString + "msg")}, m)...)
+}
+
+// h5 hashes the encoded commitment list
+func h5(m []byte) []byte {
+	return hash(append([][]byte{[]byte(contextString + "com")}, m)...)
+}
+
+func hash(m ...[]byte) []byte {
+	h := sha512.New()
+	for _, bz := range m {
+		h.Write(bz)
+	}
+	return h.Sum(nil)
+}
+
+// hashToScalar interprets the SHA-512 digest as a little-endian integer and reduces it modulo the group order
+func hashToScalar(ec elliptic.Curve, m ...[]byte) *big.Int {
+	digest := hash(m...)
+	return new(big.Int).Mod(new(big.Int).SetBytes(reverse(digest)), ec.Params().N)
+}
+
+// generateNonce implements nonce_generate, which mixes the secret share into fresh randomness
+func generateNonce(ec elliptic.Curve, secret *big.Int) (*big.Int, error) {
+	randomBytes := make([]byte, 32)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return nil, err
+	}
+	return h3(ec, randomBytes, serializeScalar(secret)), nil
+}
+
+// serializeScalar encodes a scalar as 32 little-endian bytes
+func serializeScalar(k *big.Int) []byte {
+	bz := make([]byte, scalarLength)
+	return reverse(k.FillBytes(bz))
+}
+
+func deserializeScalar(ec elliptic.Curve, bz []byte) (*big.Int, error) {
+	if len(bz) != scalarLength {
+		return nil, errors.New("invalid scalar length")
+	}
+	k := new(big.Int).SetBytes(reverse(append([]byte{}, bz...)))
+	if k.Cmp(ec.Params().N) >= 0 {
+		return nil, errors.New("scalar is not reduced")
+	}
+	return k, nil
+}
+
+// serializeElement encodes a point in the compressed form of RFC 8032
+func serializeElement(p *crypto.ECPoint) []byte {
+	return edwards.NewPublicKey(p.X(), p.Y()).Serialize()
+}
+
+// deserializeElement decodes a point and checks that it is not the identity and is in the prime-order subgroup
+func deserializeElement(ec elliptic.Curve, bz []byte) (*crypto.ECPoint, error) {
+	if len(bz) != elementLength {
+		return nil, errors.New("invalid element length")
+	}
+	pk, err := edwards.ParsePubKey(bz)
+	if err != nil {
+		return nil, err
+	}
+	p, err := crypto.NewECPoint(ec, pk.X, pk.Y)
+	if err != nil {
+		return nil, err
+	}
+	if isIdentity(p) || !isIdentity(p.ScalarMult(ec.Params().N)) {
+		return nil, errors.New("element is the identity or not in the prime-order subgroup")
+	}
+	return p, nil
+}
+
+func isIdentity(p *crypto.ECPoint) bool {
+	return p.X().Sign() == 0 && p.Y().Cmp(big.NewInt(1)) == 0
+}
+
+// encodeCommitmentList implements encode_group_commitment_list for the signers in the order of their indexes
+func encodeCommitmentList(ks []*big.Int, hiding, binding []*crypto.ECPoint) []byte {
+	encoded := make([]byte, 0, len(ks)*(scalarLength+2*elementLength))
+	for j := range ks {
+		encoded = append(encoded, serializeScalar(ks[j])...)
+		encoded = append(encoded, serializeElement(hiding[j])...)
+		encoded = append(encoded, serializeElement(binding[j])...)
+	}
+	return encoded
+}
+
+// computeBindingFactors implements compute_binding_factors and returns the binding factor of every signer
+func computeBindingFactors(ec elliptic.Curve, pub *crypto.ECPoint, ks []*big.Int, hiding, binding []*crypto.ECPoint, msg []byte) []*big.Int {
+	prefix := append(serializeElement(pub), h4(msg)...)
+	prefix = append(prefix, h5(encodeCommitmentList(ks, hiding, binding))...)
+	rhos := make([]*big.Int, len(ks))
+	for j, kj := range ks {
+		rhos[j] = h1(ec, prefix, serializeScalar(kj))
+	}
+	return rhos
+}
+
+// computeGroupCommitment implements compute_group_commitment: R = sum(Dj + rhoj*Ej)
+func computeGroupCommitment(hiding, binding []*crypto.ECPoint, rhos []*big.Int) (*crypto.ECPoint, error) {
+	var R *crypto.ECPoint
+	for j := range hiding {
+		Rj, err := hiding[j].Add(binding[j].ScalarMult(rhos[j]))
+		if err != nil {
+			return nil, err
+		}
+		if R == nil {
+			R = Rj
+			continue
+		}
+		if R, err = R.Add(Rj); err != nil {
+			return nil, err
+		}
+	}
+	if R == nil || isIdentity(R) {
+		return nil, errors.New("the group commitment is the identity")
+	}
+	return R, nil
+}
+
+// computeChallenge implements compute_challenge, the challenge of an Ed25519 signature
+func computeChallenge(ec elliptic.Curve, R, pub *crypto.ECPoint, msg []byte) *big.Int {
+	return h2(ec, serializeElement(R), serializeElement(pub), msg)
+}
+
+// computeSignatureShare implements the signature share of round two: zi = di + ei*rhoi + lambdai*xi*c
+func computeSignatureShare(ec elliptic.Curve, d, e, rho, lambda, x, c *big.Int) *big.Int {
+	modQ := common.ModInt(ec.Params().N)
+	zi := modQ.Add(d, modQ.Mul(e, rho))
+	return modQ.Add(zi, modQ.Mul(modQ.Mul(lambda, x), c))
+}
+
+// deriveInterpolatingValue implements derive_interpolating_value, the Lagrange coefficient of signer i at zero
+func deriveInterpolatingValue(ec elliptic.Curve, i int, ks []*big.Int) (*big.Int, error) {
+	modQ := common.ModInt(ec.Params().N)
+	num, den := big.NewInt(1), big.NewInt(1)
+	for j, kj := range ks {
+		if j == i {
+			continue
+		}
+		if kj.Cmp(ks[i]) == 0 {
+			return nil, errors.New("index of two parties are equal")
+		}
+		num = modQ.Mul(num, kj)
+		den = modQ.Mul(den, modQ.Sub(kj, ks[i]))
+	}
+	return modQ.Mul(num, modQ.ModInverse(den)), nil
+}
+
+func reverse(bz []byte) []byte {
+	for i, j := 0, len(bz)-1; i < j; i, j = i+1, j-1 {
+		bz[i], bz[j] = bz[j], bz[i]
+	}
+	return bz
+}
@@ -0,0 +1,137 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package frost
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// The FROST(Ed25519, SHA-512) test vectors of RFC 9591, appendix E.1: a 2-of-3 key signed by participants 1 and 3
+const (
+	rfcGroupSecretKey = "7b1c33d3f5291d85de664833beb1ad469f7fb6025a0ec78b3a790c6e13a98304"
+	rfcGroupPublicKey = "15d21ccd7ee42959562fc8aa63224c8851fb3ec85a3faf66040d380fb9738673"
+	rfcMessage        = "74657374"
+	rfcCoefficient    = "178199860edd8c62f5212ee91eff1295d0d670ab4ed4506866bae57e7030b204"
+	rfcSignature      = "36282629c383bb820a88b71cae937d41f2f2adfcc3d02e55507e2fb9e2dd3cbe" +
+		"bd9d2b0844e49ae0f3fa935161e1419aab7b47d21a37ebeae1f17d4987b3160b"
+)
+
+var rfcParticipants = []struct {
+	identifier                                    int64
+	share                                         string
+	hidingNonceRandomness, bindingNonceRandomness string
+	hidingNonce, bindingNonce                     string
+	hidingNonceCommitment, bindingNonceCommitment string
+	bindingFactor                                 string
+	sigShare                                      string
+}{
+	{
+		identifier:             1,
+		share:                  "929dcc590407aae7d388761cddb0c0db6f5627aea8e217f4a033f2ec83d93509",
+		hidingNonceRandomness:  "0fd2e39e111cdc266f6c0f4d0fd45c947761f1f5d3cb583dfcb9bbaf8d4c9fec",
+		bindingNonceRandomness: "69cd85f631d5f7f2721ed5e40519b1366f340a87c2f6856363dbdcda348a7501",
+		hidingNonce:            "812d6104142944d5a55924de6d49940956206909f2acaeedecda2b726e630407",
+		bindingNonce:           "b1110165fc2334149750b28dd813a39244f315cff14d4e89e6142f262ed83301",
+		hidingNonceCommitment:  "b5aa8ab305882a6fc69cbee9327e5a45e54c08af61ae77cb8207be3d2ce13de3",
+		bindingNonceCommitment: "67e98ab55aa310c3120418e5050c9cf76cf387cb20ac9e4b6fdb6f82a469f932",
+		bindingFactor:          "f2cb9d7dd9beff688da6fcc83fa89046b3479417f47f55600b106760eb3b5603",
+		sigShare:               "001719ab5a53ee1a12095cd088fd149702c0720ce5fd2f29dbecf24b7281b603",
+	},
+	{
+		identifier:             3,
+		share:                  "d3cb090a075eb154e82fdb4b3cb507f110040905468bb9c46da8bdea643a9a02",
+		hidingNonceRandomness:  "86d64a260059e495d0fb4fcc17ea3da7452391baa494d4b00321098ed2a0062f",
+		bindingNonceRandomness: "13e6b25afb2eba51716a9a7d44130c0dbae0004a9ef8d7b5550c8a0e07c61775",
+		hidingNonce:            "c256de65476204095ebdc01bd11dc10e57b36bc96284595b8215222374f99c0e",
+		bindingNonce:           "243d71944d929063bc51205714ae3c2218bd3451d0214dfb5aeec2a90c35180d",
+		hidingNonceCommitment:  "cfbdb165bd8aad6eb79deb8d287bcc0ab6658ae57fdcc98ed12c0669e90aec91",
+		bindingNonceCommitment: "7487bc41a6e712eea2f2af24681b58b1cf1da278ea11fe4e8b78398965f13552",
+		bindingFactor:          "b087686bf35a13f3dc78e780a34b0fe8a77fef1b9938c563f5573d71d8d7890f",
+		sigShare:               "bd86125de990acc5e1f13781d8e32c03a9bbd4c53539bbc106058bfd14326007",
+	},
+}
+
+func decodeHex(t *testing.T, s string) []byte {
+	bz, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bz
+}
+
+func decodeScalar(t *testing.T, s string) *big.Int {
+	k, err := deserializeScalar(tss.Edwards(), decodeHex(t, s))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return k
+}
+
+func TestRFC9591Vectors(t *testing.T) {
+	ec := tss.Edwards()
+	modQ := ec.Params().N
+	msg := decodeHex(t, rfcMessage)
+
+	// key generation with a trusted dealer
+	secret, coefficient := decodeScalar(t, rfcGroupSecretKey), decodeScalar(t, rfcCoefficient)
+	pub := crypto.ScalarBaseMult(ec, secret)
+	assert.Equal(t, rfcGroupPublicKey, hex.EncodeToString(serializeElement(pub)))
+
+	// round one
+	n := len(rfcParticipants)
+	ks, xs := make([]*big.Int, n), make([]*big.Int, n)
+	ds, es := make([]*big.Int, n), make([]*big.Int, n)
+	hiding, binding := make([]*crypto.ECPoint, n), make([]*crypto.ECPoint, n)
+	for j, v := range rfcParticipants {
+		ks[j] = big.NewInt(v.identifier)
+		xs[j] = new(big.Int).Mod(new(big.Int).Add(secret, new(big.Int).Mul(coefficient, ks[j])), modQ)
+		assert.Equal(t, v.share, hex.EncodeToString(serializeScalar(xs[j])))
+
+		ds[j] = h3(ec, decodeHex(t, v.hidingNonceRandomness), serializeScalar(xs[j]))
+		es[j] = h3(ec, decodeHex(t, v.bindingNonceRandomness), serializeScalar(xs[j]))
+		assert.Equal(t, v.hidingNonce, hex.EncodeToString(serializeScalar(ds[j])))
+		assert.Equal(t, v.bindingNonce, hex.EncodeToString(serializeScalar(es[j])))
+
+		hiding[j], binding[j] = crypto.ScalarBaseMult(ec, ds[j]), crypto.ScalarBaseMult(ec, es[j])
+		assert.Equal(t, v.hidingNonceCommitment, hex.EncodeToString(serializeElement(hiding[j])))
+		assert.Equal(t, v.bindingNonceCommitment, hex.EncodeToString(serializeElement(binding[j])))
+	}
+
+	// round two
+	rhos := computeBindingFactors(ec, pub, ks, hiding, binding, msg)
+	R, err := computeGroupCommitment(hiding, binding, rhos)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, rfcSignature[:2*elementLength], hex.EncodeToString(serializeElement(R)))
+	c := computeChallenge(ec, R, pub, msg)
+	z := big.NewInt(0)
+	for j, v := range rfcParticipants {
+		assert.Equal(t, v.bindingFactor, hex.EncodeToString(serializeScalar(rhos[j])))
+
+		lambda, err := deriveInterpolatingValue(ec, j, ks)
+		if !assert.NoError(t, err) {
+			return
+		}
+		zj := computeSignatureShare(ec, ds[j], es[j], rhos[j], lambda, xs[j], c)
+		assert.Equal(t, v.sigShare, hex.EncodeToString(serializeScalar(zj)))
+		z.Add(z, zj)
+	}
+
+	// aggregation
+	sig := append(serializeElement(R), serializeScalar(z.Mod(z, modQ))...)
+	assert.Equal(t, rfcSignature, hex.EncodeToString(sig))
+	assert.True(t, ed25519.Verify(decodeHex(t, rfcGroupPublicKey), msg, sig))
+}
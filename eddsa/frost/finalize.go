@@ -0,0 +1,100 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package frost
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func (round *finalization) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 3
+	round.started = true
+	round.resetOK()
+
+	ec := round.Params().EC()
+	modQ := common.ModInt(ec.Params().N)
+
+	// 1. verify every signature share: zj*G == Dj + rhoj*Ej + (c*lambdaj)*Xj, and sum them up
+	z := big.NewInt(0)
+	culprits := make([]*tss.PartyID, 0, len(round.temp.signRound2Messages))
+	for j, msg := range round.temp.signRound2Messages {
+		round.ok[j] = true
+		Pj := msg.GetFrom()
+		zj, err := msg.Content().(*SignRound2Message).UnmarshalZ(ec)
+		if err != nil {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		lambdaJ, err := deriveInterpolatingValue(ec, j, round.temp.ks)
+		if err != nil {
+			return round.WrapError(err)
+		}
+		if !round.verifyShare(j, zj, lambdaJ) {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		z = modQ.Add(z, zj)
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("invalid signature shares"), culprits...)
+	}
+
+	// 2. the signature is R || z in the encoding of RFC 8032
+	encodedR := serializeElement(round.temp.bigR)
+	encodedZ := serializeScalar(z)
+	round.data.Signature = append(encodedR, encodedZ...)
+	round.data.R = new(big.Int).SetBytes(reverse(append([]byte{}, encodedR...))).Bytes()
+	round.data.S = z.Bytes()
+	round.data.M = round.temp.m
+
+	// 3. check the signature with a standard verifier
+	if !ed25519.Verify(serializeElement(round.key.EDDSAPub), round.temp.m, round.data.Signature) {
+		return round.WrapError(fmt.Errorf("signature verification failed"))
+	}
+	round.end <- round.data
+
+	return nil
+}
+
+// verifyShare implements verify_signature_share for the signer with index j
+func (round *finalization) verifyShare(j int, zj, lambdaJ *big.Int) bool {
+	ec := round.Params().EC()
+	modQ := common.ModInt(ec.Params().N)
+	commShare, err := round.temp.hiding[j].Add(round.temp.binding[j].ScalarMult(round.temp.rhos[j]))
+	if err != nil {
+		return false
+	}
+	expected, err := commShare.Add(round.key.BigXj[j].ScalarMult(modQ.Mul(round.temp.c, lambdaJ)))
+	if err != nil {
+		return false
+	}
+	return crypto.ScalarBaseMult(ec, zj).Equals(expected)
+}
+
+func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *finalization) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *finalization) NextRound() tss.Round {
+	return nil // finished!
+}
@@ -0,0 +1,241 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package frost
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// Implements Party
+// Implements Stringer
+var _ tss.Party = (*LocalParty)(nil)
+var _ fmt.Stringer = (*LocalParty)(nil)
+
+type (
+	// LocalParty signs with the FROST protocol of RFC 9591 using the FROST(Ed25519, SHA-512) ciphersuite.
+	// The signatures are plain Ed25519 signatures of the message bytes.
+	LocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		keys   keygen.LocalPartySaveData
+		nonces *NonceData
+		temp   localTempData
+		data   *common.SignatureData
+
+		// outbound messaging
+		out        chan<- tss.Message
+		end        chan<- *common.SignatureData
+		nonceCount int
+		nonceEnd   chan<- []*NonceData
+	}
+
+	localMessageStore struct {
+		signRound1Messages,
+		signRound2Messages []tss.ParsedMessage
+	}
+
+	localTempData struct {
+		localMessageStore
+
+		// temp data (thrown away after sign) / round 1
+		m  []byte
+		ks []*big.Int // the identifiers of the signers
+		ds,
+		es []*big.Int // hiding and binding nonces
+
+		// round 2
+		hiding,
+		binding []*crypto.ECPoint
+		rhos []*big.Int
+		bigR *crypto.ECPoint
+		c,
+		zi *big.Int
+	}
+)
+
+// NewLocalParty returns a party that signs `msg` in two rounds: it broadcasts its nonce commitments in round 1 and its
+// signature share in round 2.
+func NewLocalParty(
+	msg []byte,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+) tss.Party {
+	return newLocalParty(msg, params, key, nil, 1, out, end, nil)
+}
+
+// NewPreprocessParty returns a party that runs round 1 ahead of time for `count` future signatures and outputs this
+// party's nonces for them through `end`. Sign with each of them once using NewOnlineParty.
+func NewPreprocessParty(
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	count int,
+	out chan<- tss.Message,
+	end chan<- []*NonceData,
+) tss.Party {
+	return newLocalParty(nil, params, key, nil, count, out, nil, end)
+}
+
+// NewOnlineParty returns a party that signs `msg` in a single round with nonces created by NewPreprocessParty.
+// The parties in `params` must be the signers of the nonces. The nonces must never be used for more than one message;
+// running the online party again for the same message is safe.
+func NewOnlineParty(
+	msg []byte,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	nonces NonceData,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+) tss.Party {
+	return newLocalParty(msg, params, key, &nonces, 0, out, end, nil)
+}
+
+func newLocalParty(
+	msg []byte,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	nonces *NonceData,
+	nonceCount int,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+	nonceEnd chan<- []*NonceData,
+) tss.Party {
+	partyCount := len(params.Parties().IDs())
+	p := &LocalParty{
+		BaseParty:  new(tss.BaseParty),
+		params:     params,
+		keys:       keygen.BuildLocalSaveDataSubset(key, params.Parties().IDs()),
+		nonces:     nonces,
+		temp:       localTempData{},
+		data:       &common.SignatureData{},
+		out:        out,
+		end:        end,
+		nonceCount: nonceCount,
+		nonceEnd:   nonceEnd,
+	}
+	// msgs init
+	p.temp.signRound1Messages = make([]tss.ParsedMessage, partyCount)
+	p.temp.signRound2Messages = make([]tss.ParsedMessage, partyCount)
+
+	// temp data init
+	p.temp.m = msg
+	return p
+}
+
+func (p *LocalParty) FirstRound() tss.Round {
+	round := newRound1(p.params, &p.keys, p.data, &p.temp, p.out, p.end, p.nonceCount, p.nonceEnd)
+	if p.nonces != nil {
+		// the nonce commitments were exchanged during preprocessing
+		return &round2{round}
+	}
+	return round
+}
+
+func (p *LocalParty) Start() *tss.Error {
+	return tss.BaseStart(p, TaskName, p.prepare)
+}
+
+func (p *LocalParty) StartWithContext(ctx context.Context, errCh chan<- *tss.Error) *tss.Error {
+	return tss.BaseStartWithContext(ctx, p, TaskName, errCh, p.prepare)
+}
+
+func (p *LocalParty) prepare(round tss.Round) *tss.Error {
+	if p.params.Threshold()+1 > len(p.keys.Ks) {
+		return round.WrapError(fmt.Errorf("t+1=%d is not satisfied by the key count of %d", p.params.Threshold()+1, len(p.keys.Ks)))
+	}
+	// the shares were evaluated at the keys modulo the group order
+	N := p.params.EC().Params().N
+	p.temp.ks = make([]*big.Int, len(p.keys.Ks))
+	for j, kj := range p.keys.Ks {
+		p.temp.ks[j] = new(big.Int).Mod(kj, N)
+	}
+	if p.nonces == nil {
+		if p.nonceCount < 1 {
+			return round.WrapError(errors.New("the nonce count must be positive"))
+		}
+		return nil
+	}
+	if err := p.nonces.validate(p.params, p.keys.EDDSAPub); err != nil {
+		return round.WrapError(err)
+	}
+	p.temp.ds = []*big.Int{p.nonces.D}
+	p.temp.es = []*big.Int{p.nonces.E}
+	p.temp.hiding = p.nonces.HidingCommitments
+	p.temp.binding = p.nonces.BindingCommitments
+	return nil
+}
+
+func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(p, msg, TaskName)
+}
+
+func (p *LocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *LocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if ok, err := p.BaseParty.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	// check that the message's "from index" will fit into the array
+	if maxFromIdx := len(p.params.Parties().IDs()) - 1; maxFromIdx < msg.GetFrom().Index {
+		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+			maxFromIdx, msg.GetFrom().Index), msg.GetFrom())
+	}
+	return true, nil
+}
+
+func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	fromPIdx := msg.GetFrom().Index
+
+	// switch/case is necessary to store any messages beyond current round
+	// this does not handle message replays. we expect the caller to apply replay and spoofing protection.
+	switch msg.Content().(type) {
+	case *SignRound1Message:
+		p.temp.signRound1Messages[fromPIdx] = msg
+
+	case *SignRound2Message:
+		p.temp.signRound2Messages[fromPIdx] = msg
+
+	default: // unrecognised message, just ignore!
+		common.Logger.Warningf("unrecognised message ignored: %v", msg)
+		return false, nil
+	}
+	return true, nil
+}
+
+// Snapshot is not supported by FROST parties: a restored party could sign two messages with the same nonces, which
+// reveals its key share. Start a new party instead.
+func (p *LocalParty) Snapshot(key []byte) ([]byte, *tss.Error) {
+	return nil, p.WrapError(errors.New("could not snapshot. restoring a FROST party could reuse its nonces"))
+}
+
+func (p *LocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *LocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
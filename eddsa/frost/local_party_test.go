@@ -0,0 +1,284 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package frost
+
+import (
+	"crypto/ed25519"
+	"math/big"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+
+	// only for test
+	tss.SetCurve(tss.Edwards())
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+
+	// PHASE: load keygen fixtures
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	// PHASE: signing
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]tss.Party, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+
+	msg := []byte("FROST signs the message bytes as they are")
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		P := NewLocalParty(msg, params, keys[i], outCh, endCh)
+		parties = append(parties, P)
+		go func(P tss.Party) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	sigs := make([]*common.SignatureData, 0, len(signPIDs))
+signing:
+	for {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case msg := <-outCh:
+			routeMessage(parties, msg, errCh)
+		case data := <-endCh:
+			sigs = append(sigs, data)
+			if len(sigs) == len(signPIDs) {
+				break signing
+			}
+		}
+	}
+	for _, data := range sigs {
+		assert.Equal(t, sigs[0].Signature, data.Signature, "all the parties should output the same signature")
+	}
+	assertValidSignature(t, keys[0], msg, sigs[0])
+}
+
+func TestE2EPreprocessAndOnlineSign(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+
+	// PHASE: preprocessing
+	const count = 3
+	nonceCh := make(chan []*NonceData, len(signPIDs))
+	parties := make([]tss.Party, 0, len(signPIDs))
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		P := NewPreprocessParty(params, keys[i], count, outCh, nonceCh)
+		parties = append(parties, P)
+		go func(P tss.Party) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+	nonces := make([][]*NonceData, len(signPIDs))
+	for ended := 0; ended < len(signPIDs); {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case msg := <-outCh:
+			routeMessage(parties, msg, errCh)
+		case data := <-nonceCh:
+			if !assert.Len(t, data, count) {
+				t.FailNow()
+			}
+			nonces[data[0].Index] = data
+			ended++
+		}
+	}
+	for k := 0; k < count; k++ {
+		for i := range nonces {
+			assert.True(t, nonces[i][k].ValidateBasic())
+			assert.Equal(t, nonces[0][k].ID, nonces[i][k].ID, "the signers should agree on the nonces")
+		}
+		if k > 0 {
+			assert.NotEqual(t, nonces[0][k-1].ID, nonces[0][k].ID)
+		}
+	}
+
+	// PHASE: online signing, with a different set of nonces for every message
+	for k, msg := range [][]byte{[]byte("first message"), {}} {
+		endCh := make(chan *common.SignatureData, len(signPIDs))
+		parties = parties[:0]
+		for i := 0; i < len(signPIDs); i++ {
+			params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+			P := NewOnlineParty(msg, params, keys[i], *nonces[i][k], outCh, endCh)
+			parties = append(parties, P)
+			go func(P tss.Party) {
+				if err := P.Start(); err != nil {
+					errCh <- err
+				}
+			}(P)
+		}
+		var data *common.SignatureData
+		for ended := 0; ended < len(signPIDs); {
+			select {
+			case err := <-errCh:
+				assert.FailNow(t, err.Error())
+			case msg := <-outCh:
+				routeMessage(parties, msg, errCh)
+			case data = <-endCh:
+				ended++
+			}
+		}
+		assertValidSignature(t, keys[0], msg, data)
+	}
+}
+
+func TestOnlinePartyRejectsForeignNonces(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	nonce := func(index int) NonceData {
+		return NonceData{
+			ID:                 []byte{1},
+			Ks:                 signPIDs.Keys(),
+			Index:              index,
+			EDDSAPub:           keys[0].EDDSAPub,
+			D:                  big.NewInt(1),
+			E:                  big.NewInt(2),
+			HidingCommitments:  keys[0].BigXj[:len(signPIDs)],
+			BindingCommitments: keys[0].BigXj[:len(signPIDs)],
+		}
+	}
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+	params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[0], len(signPIDs), testThreshold)
+
+	err1 := NewOnlineParty([]byte("msg"), params, keys[0], nonce(1), outCh, endCh).Start()
+	if assert.NotNil(t, err1) {
+		assert.Contains(t, err1.Error(), "another party")
+	}
+	err2 := NewOnlineParty([]byte("msg"), params, keys[0], nonce(0), outCh, endCh).Start()
+	if assert.NotNil(t, err2) {
+		assert.Contains(t, err2.Error(), "do not match their commitments")
+	}
+	assert.Len(t, outCh, 0, "no signature share should be sent")
+}
+
+func TestE2EInvalidShareIdentifiesCulprit(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]tss.Party, 0, len(signPIDs))
+
+	// messages are routed synchronously below, so the channel must be able to hold everything that is produced
+	outCh := make(chan tss.Message, len(signPIDs)*len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		parties = append(parties, NewLocalParty([]byte("msg"), params, keys[i], outCh, endCh))
+	}
+	for _, P := range parties {
+		if err := P.Start(); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+	}
+
+	cheater := parties[0].PartyID()
+	errs := make(map[int]*tss.Error)
+	for len(outCh) > 0 {
+		msg := (<-outCh).(tss.ParsedMessage)
+		if r2msg, ok := msg.Content().(*SignRound2Message); ok && msg.GetFrom().Index == cheater.Index {
+			zi, _ := r2msg.UnmarshalZ(tss.Edwards())
+			msg = NewSignRound2Message(cheater, new(big.Int).Add(zi, big.NewInt(1)))
+		}
+		for _, P := range parties {
+			if P.PartyID().Index == msg.GetFrom().Index {
+				continue
+			}
+			if _, err := P.Update(msg); err != nil {
+				errs[P.PartyID().Index] = err
+			}
+		}
+	}
+	// the cheater itself sees the honest shares only
+	assert.Len(t, endCh, 1, "only the cheater should output a signature")
+	for _, P := range parties[1:] {
+		err := errs[P.PartyID().Index]
+		if assert.NotNil(t, err) {
+			assert.Equal(t, []*tss.PartyID{cheater}, err.Culprits())
+		}
+	}
+}
+
+func assertValidSignature(t *testing.T, key keygen.LocalPartySaveData, msg []byte, data *common.SignatureData) {
+	pk := edwards.PublicKey{Curve: tss.Edwards(), X: key.EDDSAPub.X(), Y: key.EDDSAPub.Y()}
+	assert.True(t, ed25519.Verify(pk.Serialize(), msg, data.Signature), "the signature should verify with crypto/ed25519")
+
+	sig, err := edwards.ParseSignature(data.Signature)
+	if assert.NoError(t, err) {
+		assert.True(t, edwards.Verify(&pk, msg, sig.R, sig.S), "the signature should verify with edwards.Verify")
+		assert.Equal(t, sig.R.Bytes(), data.R)
+		assert.Equal(t, sig.S.Bytes(), data.S)
+	}
+	assert.Equal(t, msg, data.M)
+}
+
+func routeMessage(parties []tss.Party, msg tss.Message, errCh chan<- *tss.Error) {
+	if dest := msg.GetTo(); dest == nil {
+		for _, P := range parties {
+			if P.PartyID().Index != msg.GetFrom().Index {
+				go test.SharedPartyUpdater(P, msg, errCh)
+			}
+		}
+	} else {
+		go test.SharedPartyUpdater(parties[dest[0].Index], msg, errCh)
+	}
+}
+
+func TestStoreMessageRejectsNil(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	params := tss.NewParameters(tss.Edwards(), tss.NewPeerContext(signPIDs), signPIDs[0], len(signPIDs), testThreshold)
+	P := NewLocalParty([]byte("msg"), params, keys[0], make(chan tss.Message, len(signPIDs)), nil).(*LocalParty)
+
+	ok, err2 := P.StoreMessage(nil)
+	assert.False(t, ok)
+	assert.NotNil(t, err2, "a nil message should be rejected")
+}
@@ -0,0 +1,95 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package frost
+
+import (
+	"crypto/elliptic"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into eddsa-frost.pb.go
+// The following messages are registered on the Protocol Buffers "wire"
+
+var (
+	// Ensure that signing messages implement ValidateBasic
+	_ = []tss.MessageContent{
+		(*SignRound1Message)(nil),
+		(*SignRound2Message)(nil),
+	}
+)
+
+// ----- //
+
+func NewSignRound1Message(
+	from *tss.PartyID,
+	hiding, binding []*crypto.ECPoint,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &SignRound1Message{
+		Hiding:  make([][]byte, len(hiding)),
+		Binding: make([][]byte, len(binding)),
+	}
+	for k := range hiding {
+		content.Hiding[k] = serializeElement(hiding[k])
+		content.Binding[k] = serializeElement(binding[k])
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignRound1Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyMultiBytes(m.GetHiding()) &&
+		common.NonEmptyMultiBytes(m.GetBinding(), len(m.GetHiding()))
+}
+
+func (m *SignRound1Message) UnmarshalCommitments(ec elliptic.Curve) (hiding, binding []*crypto.ECPoint, err error) {
+	hiding = make([]*crypto.ECPoint, len(m.GetHiding()))
+	binding = make([]*crypto.ECPoint, len(m.GetBinding()))
+	for k := range hiding {
+		if hiding[k], err = deserializeElement(ec, m.GetHiding()[k]); err != nil {
+			return nil, nil, err
+		}
+		if binding[k], err = deserializeElement(ec, m.GetBinding()[k]); err != nil {
+			return nil, nil, err
+		}
+	}
+	return
+}
+
+// ----- //
+
+func NewSignRound2Message(
+	from *tss.PartyID,
+	zi *big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &SignRound2Message{
+		Z: serializeScalar(zi),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignRound2Message) ValidateBasic() bool {
+	return m != nil &&
+		len(m.GetZ()) == scalarLength
+}
+
+func (m *SignRound2Message) UnmarshalZ(ec elliptic.Curve) (*big.Int, error) {
+	return deserializeScalar(ec, m.GetZ())
+}
@@ -0,0 +1,128 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package frost
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// NonceData is the output of preprocessing for one future signature: a party's secret nonces and the commitments of
+// all the signers to theirs. It is bound to the public key and to the set of signers that created it.
+// The nonces must be used to sign at most one message and then be deleted; signing two different messages with the
+// same nonces reveals the key share.
+type NonceData struct {
+	// ID is the same for all the signers of the nonces
+	ID []byte
+	// Ks are the keys of the signers, in the order of their indexes; Index is the index of the owner of the nonces
+	Ks       []*big.Int
+	Index    int
+	EDDSAPub *crypto.ECPoint
+	// D and E are the hiding and binding nonces of the owner
+	D, E *big.Int
+	// HidingCommitments and BindingCommitments are the commitments of every signer, by index
+	HidingCommitments,
+	BindingCommitments []*crypto.ECPoint
+}
+
+func (round *preprocessFinalization) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	hiding, binding, err := round.unmarshalCommitments()
+	if err != nil {
+		return err
+	}
+	ks := round.Parties().IDs().Keys()
+	pub := round.key.EDDSAPub
+	nonces := make([]*NonceData, round.nonceCount)
+	for k := range nonces {
+		nonces[k] = &NonceData{
+			Ks:                 ks,
+			Index:              round.PartyID().Index,
+			EDDSAPub:           pub,
+			D:                  round.temp.ds[k],
+			E:                  round.temp.es[k],
+			HidingCommitments:  make([]*crypto.ECPoint, len(hiding)),
+			BindingCommitments: make([]*crypto.ECPoint, len(binding)),
+		}
+		idList := append([]*big.Int{pub.X(), pub.Y()}, ks...)
+		for j := range hiding {
+			nonces[k].HidingCommitments[j] = hiding[j][k]
+			nonces[k].BindingCommitments[j] = binding[j][k]
+			idList = append(idList, hiding[j][k].X(), hiding[j][k].Y(), binding[j][k].X(), binding[j][k].Y())
+		}
+		nonces[k].ID = common.SHA512_256i(idList...).Bytes()
+	}
+
+	// clear the nonces from memory, lint ignore
+	round.temp.ds = nil
+	round.temp.es = nil
+
+	for j := range round.ok {
+		round.ok[j] = true
+	}
+	round.nonceEnd <- nonces
+
+	return nil
+}
+
+func (round *preprocessFinalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *preprocessFinalization) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *preprocessFinalization) NextRound() tss.Round {
+	return nil // finished!
+}
+
+// ValidateBasic checks that the nonces are complete
+func (nonces NonceData) ValidateBasic() bool {
+	return len(nonces.ID) != 0 && len(nonces.Ks) != 0 && nonces.EDDSAPub != nil && nonces.D != nil && nonces.E != nil &&
+		len(nonces.HidingCommitments) == len(nonces.Ks) && len(nonces.BindingCommitments) == len(nonces.Ks)
+}
+
+// validate checks that the nonces belong to this party, its signers and its key
+func (nonces NonceData) validate(params *tss.Parameters, pub *crypto.ECPoint) error {
+	if !nonces.ValidateBasic() {
+		return errors.New("the nonces are incomplete")
+	}
+	ks := params.Parties().IDs().Keys()
+	if len(ks) != len(nonces.Ks) {
+		return errors.New("the parties are not the signers of the nonces")
+	}
+	for j := range ks {
+		if ks[j].Cmp(nonces.Ks[j]) != 0 {
+			return errors.New("the parties are not the signers of the nonces")
+		}
+	}
+	if nonces.Index != params.PartyID().Index {
+		return errors.New("the nonces belong to another party")
+	}
+	if !nonces.EDDSAPub.Equals(pub) {
+		return errors.New("the nonces belong to another key")
+	}
+	ec := params.EC()
+	if !crypto.ScalarBaseMult(ec, nonces.D).Equals(nonces.HidingCommitments[nonces.Index]) ||
+		!crypto.ScalarBaseMult(ec, nonces.E).Equals(nonces.BindingCommitments[nonces.Index]) {
+		return errors.New("the nonces do not match their commitments")
+	}
+	return nil
+}
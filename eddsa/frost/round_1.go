@@ -0,0 +1,113 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package frost
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// round 1 represents round 1 of FROST signing (commit) and the whole of preprocessing
+func newRound1(params *tss.Parameters, key *keygen.LocalPartySaveData, data *common.SignatureData, temp *localTempData, out chan<- tss.Message, end chan<- *common.SignatureData, nonceCount int, nonceEnd chan<- []*NonceData) *round1 {
+	return &round1{
+		&base{params, key, data, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1, nonceCount, nonceEnd}}
+}
+
+func (round *round1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	// 1. draw a pair of nonces for every future signature
+	ec := round.Params().EC()
+	round.temp.ds = make([]*big.Int, round.nonceCount)
+	round.temp.es = make([]*big.Int, round.nonceCount)
+	hiding := make([]*crypto.ECPoint, round.nonceCount)
+	binding := make([]*crypto.ECPoint, round.nonceCount)
+	for k := 0; k < round.nonceCount; k++ {
+		var err error
+		if round.temp.ds[k], err = generateNonce(ec, round.key.Xi); err != nil {
+			return round.WrapError(err)
+		}
+		if round.temp.es[k], err = generateNonce(ec, round.key.Xi); err != nil {
+			return round.WrapError(err)
+		}
+		// 2. commit to them
+		hiding[k] = crypto.ScalarBaseMult(ec, round.temp.ds[k])
+		binding[k] = crypto.ScalarBaseMult(ec, round.temp.es[k])
+	}
+
+	i := round.PartyID().Index
+	round.ok[i] = true
+
+	// 3. broadcast the commitments
+	r1msg := NewSignRound1Message(round.PartyID(), hiding, binding)
+	round.temp.signRound1Messages[i] = r1msg
+	round.out <- r1msg
+
+	return nil
+}
+
+func (round *round1) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.signRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignRound1Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	if round.nonceEnd != nil {
+		return &preprocessFinalization{round}
+	}
+	return &round2{round}
+}
+
+// ----- //
+
+// unmarshalCommitments returns the commitments of every signer, by the index of the signer and then of the signature
+func (round *round1) unmarshalCommitments() (hiding, binding [][]*crypto.ECPoint, err *tss.Error) {
+	hiding = make([][]*crypto.ECPoint, len(round.temp.signRound1Messages))
+	binding = make([][]*crypto.ECPoint, len(round.temp.signRound1Messages))
+	for j, msg := range round.temp.signRound1Messages {
+		Pj := msg.GetFrom()
+		r1msg := msg.Content().(*SignRound1Message)
+		var uErr error
+		if hiding[j], binding[j], uErr = r1msg.UnmarshalCommitments(round.Params().EC()); uErr != nil {
+			return nil, nil, round.WrapError(fmt.Errorf("failed to unmarshal the nonce commitments: %v", uErr), Pj)
+		}
+		if len(hiding[j]) != round.nonceCount {
+			return nil, nil, round.WrapError(fmt.Errorf("expected %d nonce commitments, got %d", round.nonceCount, len(hiding[j])), Pj)
+		}
+	}
+	return
+}
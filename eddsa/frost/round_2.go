@@ -0,0 +1,101 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package frost
+
+import (
+	"errors"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func (round *round2) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	if round.temp.m == nil {
+		return round.WrapError(errors.New("the message is missing"))
+	}
+
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	ec := round.Params().EC()
+	i := round.PartyID().Index
+
+	// 1. collect the commitments, unless they come from preprocessing
+	if round.temp.hiding == nil {
+		hiding, binding, err := round.unmarshalCommitments()
+		if err != nil {
+			return err
+		}
+		round.temp.hiding = make([]*crypto.ECPoint, len(hiding))
+		round.temp.binding = make([]*crypto.ECPoint, len(binding))
+		for j := range hiding {
+			round.temp.hiding[j] = hiding[j][0]
+			round.temp.binding[j] = binding[j][0]
+		}
+	}
+
+	// 2-4. compute the binding factors, the group commitment R and the challenge c
+	round.temp.rhos = computeBindingFactors(ec, round.key.EDDSAPub, round.temp.ks, round.temp.hiding, round.temp.binding, round.temp.m)
+	R, err := computeGroupCommitment(round.temp.hiding, round.temp.binding, round.temp.rhos)
+	if err != nil {
+		return round.WrapError(err)
+	}
+	round.temp.bigR = R
+	round.temp.c = computeChallenge(ec, R, round.key.EDDSAPub, round.temp.m)
+
+	// 5. compute zi = di + ei*rhoi + lambdai*xi*c
+	lambdaI, err := deriveInterpolatingValue(ec, i, round.temp.ks)
+	if err != nil {
+		return round.WrapError(err)
+	}
+	zi := computeSignatureShare(ec, round.temp.ds[0], round.temp.es[0], round.temp.rhos[i], lambdaI, round.key.Xi, round.temp.c)
+	round.temp.zi = zi
+
+	// clear the nonces from memory, lint ignore
+	round.temp.ds = nil
+	round.temp.es = nil
+
+	round.ok[i] = true
+
+	// 6. broadcast the signature share
+	r2msg := NewSignRound2Message(round.PartyID(), zi)
+	round.temp.signRound2Messages[i] = r2msg
+	round.out <- r2msg
+
+	return nil
+}
+
+func (round *round2) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.signRound2Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round2) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignRound2Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round2) NextRound() tss.Round {
+	round.started = false
+	return &finalization{round}
+}
@@ -7,6 +7,7 @@
 package keygen
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math/big"
@@ -52,12 +53,16 @@ type (
 		shares        vss.Shares
 		deCommitPolyG cmt.HashDeCommitment
 
+		chainCodeCmts     []cmt.HashCommitment
+		deCommitChainCode cmt.HashDeCommitment
+
 		ssid      []byte
 		ssidNonce *big.Int
 	}
 )
 
 // Exported, used in `tss` client
+// The key also signs sr25519 with the Sr25519 variant of eddsa/signing; EDDSAPub is then encoded in ristretto255.
 func NewLocalParty(
 	params *tss.Parameters,
 	out chan<- tss.Message,
@@ -80,6 +85,7 @@ func NewLocalParty(
 	p.temp.kgRound3Messages = make([]tss.ParsedMessage, partyCount)
 	// temp data init
 	p.temp.KGCs = make([]cmt.HashCommitment, partyCount)
+	p.temp.chainCodeCmts = make([]cmt.HashCommitment, partyCount)
 	return p
 }
 
@@ -91,6 +97,10 @@ func (p *LocalParty) Start() *tss.Error {
 	return tss.BaseStart(p, TaskName)
 }
 
+func (p *LocalParty) StartWithContext(ctx context.Context, errCh chan<- *tss.Error) *tss.Error {
+	return tss.BaseStartWithContext(ctx, p, TaskName, errCh)
+}
+
 func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
 	return tss.BaseUpdate(p, msg, TaskName)
 }
@@ -7,6 +7,7 @@
 package keygen
 
 import (
+	"crypto/rand"
 	"encoding/json"
 	"fmt"
 	"math/big"
@@ -192,6 +193,12 @@ keygen:
 				}
 				t.Log("Public key distribution test done.")
 
+				// make sure everyone has the same chain code
+				assert.Len(t, save.ChainCode, ChainCodeLength)
+				for _, Pj := range parties {
+					assert.Equal(t, save.ChainCode, Pj.data.ChainCode)
+				}
+
 				// test sign/verify
 				data := make([]byte, 32)
 				for i := range data {
@@ -236,3 +243,88 @@ func tryWriteTestFixtureFile(t *testing.T, index int, data LocalPartySaveData) {
 	}
 	//
 }
+
+func TestE2ESnapshotAndRestore(t *testing.T) {
+	setUp("info")
+
+	threshold := testThreshold
+	pIDs := tss.GenerateTestPartyIDs(testParticipants)
+	p2pCtx := tss.NewPeerContext(pIDs)
+
+	params := make([]*tss.Parameters, 0, len(pIDs))
+	parties := make([]tss.Party, 0, len(pIDs))
+
+	// messages are routed synchronously below, so the channels must be able to hold everything that is produced
+	errCh := make(chan *tss.Error, len(pIDs)*len(pIDs)*4)
+	outCh := make(chan tss.Message, len(pIDs)*len(pIDs)*4)
+	endCh := make(chan *LocalPartySaveData, len(pIDs))
+
+	key := make([]byte, tss.SnapshotKeyLength)
+	_, err := rand.Read(key)
+	assert.NoError(t, err)
+
+	for i := 0; i < len(pIDs); i++ {
+		params = append(params, tss.NewParameters(tss.Edwards(), p2pCtx, pIDs[i], len(pIDs), threshold))
+		P := NewLocalParty(params[i], outCh, endCh)
+		parties = append(parties, P)
+		if err := P.Start(); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+	}
+
+	// snapshot and restore every party once the round 2 messages begin to arrive
+	snapshotAfter, routed := len(pIDs)+2, 0
+	saves := make([]*LocalPartySaveData, 0, len(pIDs))
+	for len(saves) < len(pIDs) {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case msg := <-outCh:
+			if dest := msg.GetTo(); dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index != msg.GetFrom().Index {
+						test.SharedPartyUpdater(P, msg, errCh)
+					}
+				}
+			} else {
+				test.SharedPartyUpdater(parties[dest[0].Index], msg, errCh)
+			}
+			if routed++; routed != snapshotAfter {
+				continue
+			}
+			for i, P := range parties {
+				snapshot, err := P.Snapshot(key)
+				if err != nil {
+					assert.FailNow(t, err.Error())
+				}
+				badKey := append([]byte{key[0] + 1}, key[1:]...)
+				_, err = RestoreLocalParty(params[i], snapshot, badKey, outCh, endCh)
+				assert.Error(t, err, "restoring with the wrong key should fail")
+
+				restored, err := RestoreLocalParty(params[i], snapshot, key, outCh, endCh)
+				if err != nil {
+					assert.FailNow(t, err.Error())
+				}
+				assert.Equal(t, P.WaitingFor(), restored.WaitingFor())
+				assert.Error(t, restored.Start(), "a restored party should not start again")
+				parties[i] = restored
+			}
+		case save := <-endCh:
+			saves = append(saves, save)
+		}
+	}
+
+	// make sure everyone has the same EdDSA public key
+	for _, save := range saves {
+		assert.True(t, save.EDDSAPub.Equals(saves[0].EDDSAPub))
+		assert.Equal(t, saves[0].ChainCode, save.ChainCode)
+	}
+	// reconstruct the secret from t+1 shares and compare with the public key
+	shares := make(vss.Shares, 0, threshold+1)
+	for _, save := range saves[:threshold+1] {
+		shares = append(shares, &vss.Share{Threshold: threshold, ID: save.ShareID, Share: save.Xi})
+	}
+	u, err := shares.ReConstruct(tss.Edwards())
+	assert.NoError(t, err)
+	assert.True(t, crypto.ScalarBaseMult(tss.Edwards(), u).Equals(saves[0].EDDSAPub))
+}
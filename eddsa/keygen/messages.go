@@ -32,26 +32,33 @@ var (
 
 // ----- //
 
-func NewKGRound1Message(from *tss.PartyID, ct cmt.HashCommitment) tss.ParsedMessage {
+func NewKGRound1Message(from *tss.PartyID, ct, chainCodeCt cmt.HashCommitment) tss.ParsedMessage {
 	meta := tss.MessageRouting{
 		From:        from,
 		IsBroadcast: true,
 	}
 	content := &KGRound1Message{
-		Commitment: ct.Bytes(),
+		Commitment:          ct.Bytes(),
+		ChainCodeCommitment: chainCodeCt.Bytes(),
 	}
 	msg := tss.NewMessageWrapper(meta, content)
 	return tss.NewMessage(meta, content, msg)
 }
 
 func (m *KGRound1Message) ValidateBasic() bool {
-	return m != nil && common.NonEmptyBytes(m.GetCommitment())
+	return m != nil &&
+		common.NonEmptyBytes(m.GetCommitment()) &&
+		common.NonEmptyBytes(m.GetChainCodeCommitment())
 }
 
 func (m *KGRound1Message) UnmarshalCommitment() *big.Int {
 	return new(big.Int).SetBytes(m.GetCommitment())
 }
 
+func (m *KGRound1Message) UnmarshalChainCodeCommitment() *big.Int {
+	return new(big.Int).SetBytes(m.GetChainCodeCommitment())
+}
+
 // ----- //
 
 func NewKGRound2Message1(
@@ -84,6 +91,7 @@ func (m *KGRound2Message1) UnmarshalShare() *big.Int {
 func NewKGRound2Message2(
 	from *tss.PartyID,
 	deCommitment cmt.HashDeCommitment,
+	chainCodeDeCommitment cmt.HashDeCommitment,
 	proof *schnorr.ZKProof,
 ) tss.ParsedMessage {
 	meta := tss.MessageRouting{
@@ -92,10 +100,11 @@ func NewKGRound2Message2(
 	}
 	dcBzs := common.BigIntsToBytes(deCommitment)
 	content := &KGRound2Message2{
-		DeCommitment: dcBzs,
-		ProofAlphaX:  proof.Alpha.X().Bytes(),
-		ProofAlphaY:  proof.Alpha.Y().Bytes(),
-		ProofT:       proof.T.Bytes(),
+		DeCommitment:          dcBzs,
+		ProofAlphaX:           proof.Alpha.X().Bytes(),
+		ProofAlphaY:           proof.Alpha.Y().Bytes(),
+		ProofT:                proof.T.Bytes(),
+		ChainCodeDeCommitment: common.BigIntsToBytes(chainCodeDeCommitment),
 	}
 	msg := tss.NewMessageWrapper(meta, content)
 	return tss.NewMessage(meta, content, msg)
@@ -103,7 +112,8 @@ func NewKGRound2Message2(
 
 func (m *KGRound2Message2) ValidateBasic() bool {
 	return m != nil &&
-		common.NonEmptyMultiBytes(m.GetDeCommitment())
+		common.NonEmptyMultiBytes(m.GetDeCommitment()) &&
+		common.NonEmptyMultiBytes(m.GetChainCodeDeCommitment(), 2)
 }
 
 func (m *KGRound2Message2) UnmarshalDeCommitment() []*big.Int {
@@ -111,6 +121,10 @@ func (m *KGRound2Message2) UnmarshalDeCommitment() []*big.Int {
 	return cmt.NewHashDeCommitmentFromBytes(deComBzs)
 }
 
+func (m *KGRound2Message2) UnmarshalChainCodeDeCommitment() []*big.Int {
+	return cmt.NewHashDeCommitmentFromBytes(m.GetChainCodeDeCommitment())
+}
+
 func (m *KGRound2Message2) UnmarshalZKProof(ec elliptic.Curve) (*schnorr.ZKProof, error) {
 	point, err := crypto.NewECPoint(
 		ec,
@@ -68,6 +68,9 @@ func (round *round1) Start() *tss.Error {
 	}
 	cmt := cmts.NewHashCommitment(pGFlat...)
 
+	// commit to this party's contribution to the chain code, which is revealed once all the commitments are received
+	chainCodeCmt := cmts.NewHashCommitment(common.MustGetRandomInt(ChainCodeLength * 8))
+
 	// for this P: SAVE
 	// - shareID
 	// and keep in temporary storage:
@@ -78,10 +81,12 @@ func (round *round1) Start() *tss.Error {
 	round.temp.shares = shares
 
 	round.temp.deCommitPolyG = cmt.D
+	round.temp.chainCodeCmts[i] = chainCodeCmt.C
+	round.temp.deCommitChainCode = chainCodeCmt.D
 
 	// BROADCAST commitments
 	{
-		msg := NewKGRound1Message(round.PartyID(), cmt.C)
+		msg := NewKGRound1Message(round.PartyID(), cmt.C, chainCodeCmt.C)
 		round.temp.kgRound1Messages[i] = msg
 		round.out <- msg
 	}
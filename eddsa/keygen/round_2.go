@@ -30,6 +30,7 @@ func (round *round2) Start() *tss.Error {
 	for j, msg := range round.temp.kgRound1Messages {
 		r1msg := msg.Content().(*KGRound1Message)
 		round.temp.KGCs[j] = r1msg.UnmarshalCommitment()
+		round.temp.chainCodeCmts[j] = r1msg.UnmarshalChainCodeCommitment()
 	}
 
 	// 3. p2p send share ij to Pj
@@ -53,7 +54,7 @@ func (round *round2) Start() *tss.Error {
 	}
 
 	// 5. BROADCAST de-commitments of Shamir poly*G and Schnorr prove
-	r2msg2 := NewKGRound2Message2(round.PartyID(), round.temp.deCommitPolyG, pii)
+	r2msg2 := NewKGRound2Message2(round.PartyID(), round.temp.deCommitPolyG, round.temp.deCommitChainCode, pii)
 	round.temp.kgRound2Message2s[i] = r2msg2
 	round.out <- r2msg2
 
@@ -193,6 +193,13 @@ func (round *round3) Start() *tss.Error {
 	}
 	round.save.EDDSAPub = eddsaPubKey
 
+	// compute and SAVE the chain code from the contributions of all parties
+	chainCode, culprits := round.computeChainCode()
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("chain code de-commitment verify failed"), culprits...)
+	}
+	round.save.ChainCode = chainCode
+
 	// PRINT public key & private share
 	common.Logger.Debugf("%s public key: %x", round.PartyID(), eddsaPubKey)
 
@@ -213,3 +220,21 @@ func (round *round3) Update() (bool, *tss.Error) {
 func (round *round3) NextRound() tss.Round {
 	return nil // finished!
 }
+
+// computeChainCode verifies the de-commitments of the chain code contributions of all parties and returns their XOR.
+// The contributions were committed to in round 1, so no party can choose its contribution after seeing the others.
+func (round *round3) computeChainCode() ([]byte, []*tss.PartyID) {
+	chainCode := new(big.Int)
+	culprits := make([]*tss.PartyID, 0, round.PartyCount())
+	for j, Pj := range round.Parties().IDs() {
+		r2msg2 := round.temp.kgRound2Message2s[j].Content().(*KGRound2Message2)
+		cmtDeCmt := commitments.HashCommitDecommit{C: round.temp.chainCodeCmts[j], D: r2msg2.UnmarshalChainCodeDeCommitment()}
+		ok, contribution := cmtDeCmt.DeCommit()
+		if !ok || len(contribution) != 1 || ChainCodeLength*8 < contribution[0].BitLen() {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		chainCode.Xor(chainCode, contribution[0])
+	}
+	return common.PadToLengthBytesInPlace(chainCode.Bytes(), ChainCodeLength), culprits
+}
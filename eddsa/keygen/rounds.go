@@ -15,6 +15,9 @@ import (
 
 const (
 	TaskName = "eddsa-keygen"
+
+	// ChainCodeLength is the length of the chain code that the parties generate jointly for HD key derivation
+	ChainCodeLength = 32
 )
 
 type (
@@ -7,13 +7,22 @@
 package keygen
 
 import (
+	"crypto/elliptic"
 	"encoding/hex"
+	"errors"
+	"fmt"
 	"math/big"
 
+	"github.com/hashicorp/go-multierror"
+
 	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 )
 
+// UnknownThreshold can be passed to LocalPartySaveData.Validate when the threshold of the keygen is not known
+const UnknownThreshold = -1
+
 type (
 	LocalSecrets struct {
 		// secret fields (not shared, but stored locally)
@@ -32,6 +41,9 @@ type (
 
 		// used for test assertions (may be discarded)
 		EDDSAPub *crypto.ECPoint // y
+
+		// chain code for HD key derivation, generated jointly by the parties
+		ChainCode []byte
 	}
 )
 
@@ -50,6 +62,7 @@ func BuildLocalSaveDataSubset(sourceData LocalPartySaveData, sortedIDs tss.Sorte
 	newData := NewLocalPartySaveData(sortedIDs.Len())
 	newData.LocalSecrets = sourceData.LocalSecrets
 	newData.EDDSAPub = sourceData.EDDSAPub
+	newData.ChainCode = sourceData.ChainCode
 	for j, id := range sortedIDs {
 		savedIdx, ok := keysToIndices[hex.EncodeToString(id.Key)]
 		if !ok {
@@ -60,3 +73,90 @@ func BuildLocalSaveDataSubset(sourceData LocalPartySaveData, sortedIDs tss.Sorte
 	}
 	return newData
 }
+
+// Validate checks that the save data is internally consistent, e.g. after loading it and before every signing session:
+// the party keys Ks are distinct and non-zero, there is one BigXj per party, Xi matches the BigXj of this party, and
+// the BigXj interpolate to EDDSAPub on the curve `ec`. Unless `threshold` is UnknownThreshold, it also checks
+// that every threshold+1 of the BigXj interpolate to EDDSAPub. The returned error lists every problem that was found.
+// Save data adjusted for a child key with signing.UpdatePublicKeyAndAdjustBigXj does not pass, as Xi is not adjusted.
+func (save LocalPartySaveData) Validate(ec elliptic.Curve, threshold int) error {
+	if ec == nil {
+		return errors.New("the curve is missing")
+	}
+	partyCount := len(save.Ks)
+	if partyCount == 0 {
+		return errors.New("the save data has no party keys")
+	}
+	var errs error
+	fail := func(format string, args ...interface{}) {
+		errs = multierror.Append(errs, fmt.Errorf(format, args...))
+	}
+	if len(save.BigXj) != partyCount {
+		return fmt.Errorf("the save data has %d party keys and %d BigXj", partyCount, len(save.BigXj))
+	}
+
+	ksOK, pointsOK := true, true
+	for j, kj := range save.Ks {
+		if kj == nil {
+			fail("the party key %d is missing", j)
+			ksOK = false
+		}
+	}
+	if ksOK {
+		if _, err := vss.CheckIndexes(ec, save.Ks); err != nil {
+			fail("the party keys are invalid: %v", err)
+			ksOK = false
+		}
+	}
+	if !isPointOnCurve(ec, save.EDDSAPub) {
+		fail("EDDSAPub is missing or not on the curve")
+		pointsOK = false
+	}
+	for j, bigXj := range save.BigXj {
+		if !isPointOnCurve(ec, bigXj) {
+			fail("BigXj[%d] is missing or not on the curve", j)
+			pointsOK = false
+		}
+	}
+	i := -1
+	if save.ShareID != nil && ksOK {
+		for j, kj := range save.Ks {
+			if kj.Cmp(save.ShareID) == 0 {
+				i = j
+			}
+		}
+	}
+	if i < 0 {
+		fail("ShareID is missing or not one of the party keys")
+	}
+	if save.Xi == nil || save.Xi.Sign() <= 0 || ec.Params().N.Cmp(save.Xi) <= 0 {
+		fail("Xi is missing or out of range")
+	} else if 0 <= i && pointsOK && !crypto.ScalarBaseMult(ec, save.Xi).Equals(save.BigXj[i]) {
+		fail("Xi does not match BigXj[%d]", i)
+	}
+	if ksOK && pointsOK {
+		if pub, err := vss.InterpolatePoint(ec, save.Ks, save.BigXj, big.NewInt(0)); err != nil || !pub.Equals(save.EDDSAPub) {
+			fail("the BigXj do not interpolate to EDDSAPub")
+		}
+		if t := threshold; t != UnknownThreshold {
+			if t < 0 || partyCount <= t {
+				fail("the threshold %d is invalid for %d parties", t, partyCount)
+			} else {
+				for j := t + 1; j < partyCount; j++ {
+					bigXj, err := vss.InterpolatePoint(ec, save.Ks[:t+1], save.BigXj[:t+1], save.Ks[j])
+					if err != nil || !bigXj.Equals(save.BigXj[j]) {
+						fail("BigXj[%d] is not on the polynomial of degree %d of the other BigXj", j, t)
+					}
+				}
+			}
+		}
+	}
+	if len(save.ChainCode) != 0 && len(save.ChainCode) != ChainCodeLength {
+		fail("the chain code must be %d bytes long", ChainCodeLength)
+	}
+	return errs
+}
+
+func isPointOnCurve(ec elliptic.Curve, point *crypto.ECPoint) bool {
+	return point != nil && point.X() != nil && point.Y() != nil && ec.IsOnCurve(point.X(), point.Y())
+}
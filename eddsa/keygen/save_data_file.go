@@ -0,0 +1,102 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	// SaveDataVersion is the version of the save data format written by Marshal(). Files of older versions are
+	// migrated by UnmarshalSaveData.
+	SaveDataVersion = 1
+
+	saveDataProtocol = "eddsa"
+)
+
+// Marshal serializes the save data into a versioned tss.SaveDataFile that records the curve, the `threshold` of the
+// keygen and the party keys, and carries a checksum. Load it with UnmarshalSaveData.
+func (save LocalPartySaveData) Marshal(threshold int) ([]byte, error) {
+	if save.EDDSAPub == nil || save.Xi == nil || save.ShareID == nil {
+		return nil, errors.New("the save data is incomplete")
+	}
+	bigXjFlat, err := crypto.FlattenECPoints(save.BigXj)
+	if err != nil {
+		return nil, err
+	}
+	data := &SaveData{
+		Xi:        save.Xi.Bytes(),
+		ShareId:   save.ShareID.Bytes(),
+		BigXj:     common.BigIntsToBytes(bigXjFlat),
+		EddsaPub:  common.BigIntsToBytes([]*big.Int{save.EDDSAPub.X(), save.EDDSAPub.Y()}),
+		ChainCode: save.ChainCode,
+	}
+	dataBz, err := proto.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return tss.MarshalSaveDataFile(SaveDataVersion, saveDataProtocol, save.EDDSAPub.Curve(), threshold, save.Ks, dataBz)
+}
+
+// UnmarshalSaveData loads save data written by Marshal() and returns it with the threshold of the keygen.
+// It fails when the file is corrupted, belongs to another protocol or was written by a newer version of tss-lib.
+func UnmarshalSaveData(bz []byte) (LocalPartySaveData, int, error) {
+	file, err := tss.UnmarshalSaveDataFile(bz, saveDataProtocol)
+	if err != nil {
+		return LocalPartySaveData{}, 0, err
+	}
+	// migrations of older versions go here
+	switch file.GetVersion() {
+	case SaveDataVersion:
+	default:
+		return LocalPartySaveData{}, 0, fmt.Errorf("the save data version %d is not supported; it might have been written by a newer version of tss-lib", file.GetVersion())
+	}
+	data := new(SaveData)
+	if err = proto.Unmarshal(file.GetData(), data); err != nil {
+		return LocalPartySaveData{}, 0, fmt.Errorf("the save data could not be parsed: %v", err)
+	}
+	ks := file.UnmarshalKs()
+	if len(data.GetBigXj()) != 2*len(ks) || len(data.GetEddsaPub()) != 2 {
+		return LocalPartySaveData{}, 0, errors.New("the save data does not match the number of parties")
+	}
+
+	ec := file.EC()
+	save := NewLocalPartySaveData(len(ks))
+	save.Ks = ks
+	save.Xi = new(big.Int).SetBytes(data.GetXi())
+	save.ShareID = new(big.Int).SetBytes(data.GetShareId())
+	if save.BigXj, err = crypto.UnFlattenECPoints(ec, common.MultiBytesToBigInts(data.GetBigXj())); err != nil {
+		return LocalPartySaveData{}, 0, err
+	}
+	pub := common.MultiBytesToBigInts(data.GetEddsaPub())
+	if save.EDDSAPub, err = crypto.NewECPoint(ec, pub[0], pub[1]); err != nil {
+		return LocalPartySaveData{}, 0, err
+	}
+	if len(data.GetChainCode()) != 0 {
+		save.ChainCode = data.GetChainCode()
+	}
+	return save, int(file.GetThreshold()), nil
+}
+
+// MigrateJSONSaveData converts save data that was stored as JSON by earlier versions of tss-lib, such as the test
+// fixtures, into the format written by Marshal(). The JSON format does not record the `threshold` of the keygen.
+func MigrateJSONSaveData(jsonBz []byte, threshold int) ([]byte, error) {
+	var save LocalPartySaveData
+	if err := json.Unmarshal(jsonBz, &save); err != nil {
+		return nil, fmt.Errorf("the JSON save data could not be parsed: %v", err)
+	}
+	return save.Marshal(threshold)
+}
@@ -0,0 +1,83 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func TestSaveDataMigrationAndRoundTrip(t *testing.T) {
+	jsonBz, err := ioutil.ReadFile(makeTestFixtureFilePath(0))
+	if !assert.NoError(t, err, "should read the keygen fixture") {
+		return
+	}
+	keys, _, err := LoadKeygenTestFixtures(1)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+
+	bz, err := MigrateJSONSaveData(jsonBz, test.TestThreshold)
+	if !assert.NoError(t, err, "should migrate the JSON fixture") {
+		return
+	}
+	save, threshold, err := UnmarshalSaveData(bz)
+	if !assert.NoError(t, err, "should load the migrated save data") {
+		return
+	}
+	assert.Equal(t, test.TestThreshold, threshold)
+	assert.Equal(t, keys[0], save)
+
+	bz2, err := save.Marshal(threshold)
+	assert.NoError(t, err)
+	assert.Equal(t, bz, bz2, "marshalling should be deterministic")
+}
+
+func TestSaveDataIntegrity(t *testing.T) {
+	keys, _, err := LoadKeygenTestFixtures(1)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	bz, err := keys[0].Marshal(test.TestThreshold)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// a flipped bit in the data is detected
+	file := new(tss.SaveDataFile)
+	assert.NoError(t, proto.Unmarshal(bz, file))
+	file.Data[len(file.Data)/2] ^= 0x01
+	corrupted, _ := proto.Marshal(file)
+	_, _, err = UnmarshalSaveData(corrupted)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "checksum")
+	}
+
+	// a file of a newer version is rejected
+	newer, err := tss.MarshalSaveDataFile(SaveDataVersion+1, saveDataProtocol, tss.Edwards(), test.TestThreshold, keys[0].Ks, nil)
+	assert.NoError(t, err)
+	_, _, err = UnmarshalSaveData(newer)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "newer version")
+	}
+
+	// save data of another protocol is rejected
+	other, err := tss.MarshalSaveDataFile(SaveDataVersion, "ecdsa", tss.S256(), test.TestThreshold, keys[0].Ks, nil)
+	assert.NoError(t, err)
+	_, _, err = UnmarshalSaveData(other)
+	assert.Error(t, err)
+
+	// the threshold must be valid for the parties
+	_, err = keys[0].Marshal(len(keys[0].Ks))
+	assert.Error(t, err)
+}
@@ -0,0 +1,67 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func TestSaveDataValidate(t *testing.T) {
+	keys, pIDs, err := LoadKeygenTestFixtures(test.TestParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	ec := tss.Edwards()
+	for _, key := range keys {
+		assert.NoError(t, key.Validate(ec, test.TestThreshold))
+	}
+	// the save data of a signing session
+	subset := BuildLocalSaveDataSubset(keys[0], pIDs[:test.TestThreshold+1])
+	assert.NoError(t, subset.Validate(ec, UnknownThreshold))
+	// the BigXj are not on a polynomial of a lower degree
+	assert.Error(t, keys[0].Validate(ec, test.TestThreshold-1))
+	// a threshold that is neither UnknownThreshold nor possible for the number of parties
+	assert.Error(t, keys[0].Validate(ec, -2))
+	assert.Error(t, keys[0].Validate(ec, test.TestParticipants))
+
+	corrupt := func(modify func(save *LocalPartySaveData)) error {
+		keys, _, _ := LoadKeygenTestFixtures(test.TestParticipants)
+		modify(&keys[0])
+		return keys[0].Validate(ec, test.TestThreshold)
+	}
+	one := big.NewInt(1)
+	err = corrupt(func(save *LocalPartySaveData) {
+		save.Xi = new(big.Int).Add(save.Xi, one)
+	})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "Xi does not match")
+	}
+	err = corrupt(func(save *LocalPartySaveData) {
+		save.BigXj[1] = crypto.ScalarBaseMult(ec, one)
+	})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "interpolate")
+	}
+	err = corrupt(func(save *LocalPartySaveData) {
+		save.Ks[1] = save.Ks[2]
+	})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "party keys are invalid")
+	}
+	err = corrupt(func(save *LocalPartySaveData) {
+		save.BigXj = save.BigXj[1:]
+	})
+	assert.Error(t, err)
+	assert.Error(t, keys[0].Validate(tss.S256(), UnknownThreshold), "the key is not on secp256k1")
+}
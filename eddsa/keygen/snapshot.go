@@ -0,0 +1,147 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	cmt "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+type snapshotState struct {
+	Save     LocalPartySaveData
+	OK       []bool
+	Messages [][]*tss.SnapshotMessage
+
+	UI            *big.Int
+	KGCs          []cmt.HashCommitment
+	Vs            vss.Vs
+	SSID          []byte
+	SSIDNonce     *big.Int
+	Shares        vss.Shares
+	DeCommitPolyG cmt.HashDeCommitment
+
+	ChainCodeCmts     []cmt.HashCommitment
+	DeCommitChainCode cmt.HashDeCommitment
+}
+
+// Snapshot exports the in-flight state of this party, encrypted with a key of tss.SnapshotKeyLength bytes.
+// The snapshot contains secret key material and should be stored with the same care as the final save data.
+func (p *LocalParty) Snapshot(key []byte) ([]byte, *tss.Error) {
+	return tss.BaseSnapshot(p, TaskName, key, p.snapshotState)
+}
+
+// RestoreLocalParty rebuilds a party from a snapshot taken with Snapshot(). The party continues from the round it was in,
+// so it must not be started again; feed it the messages it has not yet received through Update() or UpdateFromBytes().
+// `params` must describe the same party and peers as the ones used to construct the original party.
+func RestoreLocalParty(
+	params *tss.Parameters,
+	snapshot, key []byte,
+	out chan<- tss.Message,
+	end chan<- *LocalPartySaveData,
+) (tss.Party, *tss.Error) {
+	p := NewLocalParty(params, out, end).(*LocalParty)
+	if err := tss.BaseRestore(p, TaskName, snapshot, key, p.restoreState); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *LocalParty) messageStores() []*[]tss.ParsedMessage {
+	return []*[]tss.ParsedMessage{
+		&p.temp.kgRound1Messages,
+		&p.temp.kgRound2Message1s,
+		&p.temp.kgRound2Message2s,
+		&p.temp.kgRound3Messages,
+	}
+}
+
+func (p *LocalParty) snapshotState(rnd tss.Round) (interface{}, error) {
+	round, err := roundBase(rnd)
+	if err != nil {
+		return nil, err
+	}
+	state := &snapshotState{
+		Save:          p.data,
+		OK:            round.ok,
+		UI:            p.temp.ui,
+		KGCs:          p.temp.KGCs,
+		Vs:            p.temp.vs,
+		SSID:          p.temp.ssid,
+		SSIDNonce:     p.temp.ssidNonce,
+		Shares:        p.temp.shares,
+		DeCommitPolyG: p.temp.deCommitPolyG,
+
+		ChainCodeCmts:     p.temp.chainCodeCmts,
+		DeCommitChainCode: p.temp.deCommitChainCode,
+	}
+	for _, store := range p.messageStores() {
+		msgs, err := tss.NewSnapshotMessages(*store)
+		if err != nil {
+			return nil, err
+		}
+		state.Messages = append(state.Messages, msgs)
+	}
+	return state, nil
+}
+
+func (p *LocalParty) restoreState(stateBz []byte, number int) (tss.Round, error) {
+	state := new(snapshotState)
+	if err := json.Unmarshal(stateBz, state); err != nil {
+		return nil, err
+	}
+	partyCount := p.params.PartyCount()
+	stores := p.messageStores()
+	if len(state.OK) != partyCount || len(state.Messages) != len(stores) {
+		return nil, errors.New("the snapshot does not match the parties in params")
+	}
+	for k, store := range stores {
+		msgs, err := tss.ParseSnapshotMessages(state.Messages[k], partyCount)
+		if err != nil {
+			return nil, err
+		}
+		*store = msgs
+	}
+	p.data = state.Save
+	p.temp.ui = state.UI
+	p.temp.KGCs = state.KGCs
+	p.temp.vs = state.Vs
+	p.temp.ssid = state.SSID
+	p.temp.ssidNonce = state.SSIDNonce
+	p.temp.shares = state.Shares
+	p.temp.deCommitPolyG = state.DeCommitPolyG
+	p.temp.chainCodeCmts = state.ChainCodeCmts
+	p.temp.deCommitChainCode = state.DeCommitChainCode
+
+	r1 := newRound1(p.params, &p.data, &p.temp, p.out, p.end).(*round1)
+	copy(r1.ok, state.OK)
+	r1.started = true
+	r1.number = number
+	r2 := &round2{r1}
+	rounds := []tss.Round{r1, r2, &round3{r2}}
+	if number < 1 || len(rounds) < number {
+		return nil, fmt.Errorf("the snapshot has an unexpected round number %d", number)
+	}
+	return rounds[number-1], nil
+}
+
+func roundBase(rnd tss.Round) (*base, error) {
+	switch round := rnd.(type) {
+	case *round1:
+		return round.base, nil
+	case *round2:
+		return round.base, nil
+	case *round3:
+		return round.base, nil
+	}
+	return nil, fmt.Errorf("unexpected round type %T", rnd)
+}
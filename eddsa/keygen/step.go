@@ -0,0 +1,62 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const stepRounds = 3
+
+// StepParty runs a keygen party without channels or goroutines, which suits event loops, actors and deterministic tests.
+// Every call returns the messages that must be sent to the other parties and, once keygen has finished, the save data.
+type StepParty struct {
+	party *LocalParty
+	out   chan tss.Message
+	end   chan *LocalPartySaveData
+}
+
+// NewStepParty creates a keygen party driven by calls to Start and Update. The arguments are the same as for NewLocalParty.
+func NewStepParty(params *tss.Parameters) *StepParty {
+	out := make(chan tss.Message, tss.StepBufferSize(stepRounds, params.PartyCount()))
+	end := make(chan *LocalPartySaveData, 1)
+	return &StepParty{
+		party: NewLocalParty(params, out, end).(*LocalParty),
+		out:   out,
+		end:   end,
+	}
+}
+
+// Party returns the underlying party, e.g. to query WaitingFor() or take a Snapshot().
+func (p *StepParty) Party() tss.Party {
+	return p.party
+}
+
+func (p *StepParty) Start() ([]tss.Message, *LocalPartySaveData, *tss.Error) {
+	return p.collect(p.party.Start())
+}
+
+func (p *StepParty) Update(msg tss.ParsedMessage) ([]tss.Message, *LocalPartySaveData, *tss.Error) {
+	_, err := p.party.Update(msg)
+	return p.collect(err)
+}
+
+func (p *StepParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) ([]tss.Message, *LocalPartySaveData, *tss.Error) {
+	_, err := p.party.UpdateFromBytes(wireBytes, from, isBroadcast)
+	return p.collect(err)
+}
+
+// messages produced before an error are still returned
+func (p *StepParty) collect(err *tss.Error) ([]tss.Message, *LocalPartySaveData, *tss.Error) {
+	msgs := tss.DrainMessages(p.out)
+	select {
+	case save := <-p.end:
+		return msgs, save, err
+	default:
+		return msgs, nil, err
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package recovery
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// Implements Party
+// Implements Stringer
+var _ tss.Party = (*LocalParty)(nil)
+var _ fmt.Stringer = (*LocalParty)(nil)
+
+type (
+	LocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		key  keygen.LocalPartySaveData
+		lost *tss.PartyID
+		temp localTempData
+		data keygen.LocalPartySaveData
+
+		// outbound messaging
+		out chan<- tss.Message
+		end chan<- *keygen.LocalPartySaveData
+	}
+
+	localMessageStore struct {
+		rcRound1Message1s,
+		rcRound1Message2s,
+		rcRound2Messages []tss.ParsedMessage
+	}
+
+	localTempData struct {
+		localMessageStore
+	}
+)
+
+// NewHelperParty creates a party that helps the party `lost` to recover the share it has lost. `params` must contain
+// the lost party and at least threshold+1 other parties of the keygen, which each run a helper party with their `key`.
+// The helpers only learn random pieces of the lost share. A helper sends its unchanged `key` to `end` when it is done.
+// Exported, used in `tss` client
+func NewHelperParty(
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	lost *tss.PartyID,
+	out chan<- tss.Message,
+	end chan<- *keygen.LocalPartySaveData,
+) tss.Party {
+	p := newLocalParty(params, lost, out, end)
+	p.key = key
+	return p
+}
+
+// NewRecoveringParty creates the party that recovers its lost share with the help of the other parties in `params`.
+// The recovered save data, with the share Xi at the original ShareID, is sent to `end`.
+// Exported, used in `tss` client
+func NewRecoveringParty(
+	params *tss.Parameters,
+	out chan<- tss.Message,
+	end chan<- *keygen.LocalPartySaveData,
+) tss.Party {
+	return newLocalParty(params, params.PartyID(), out, end)
+}
+
+func newLocalParty(
+	params *tss.Parameters,
+	lost *tss.PartyID,
+	out chan<- tss.Message,
+	end chan<- *keygen.LocalPartySaveData,
+) *LocalParty {
+	partyCount := params.PartyCount()
+	p := &LocalParty{
+		BaseParty: new(tss.BaseParty),
+		params:    params,
+		lost:      lost,
+		temp:      localTempData{},
+		out:       out,
+		end:       end,
+	}
+	// msgs init
+	p.temp.rcRound1Message1s = make([]tss.ParsedMessage, partyCount)
+	p.temp.rcRound1Message2s = make([]tss.ParsedMessage, partyCount)
+	p.temp.rcRound2Messages = make([]tss.ParsedMessage, partyCount)
+	return p
+}
+
+func (p *LocalParty) FirstRound() tss.Round {
+	return newRound1(p.params, &p.key, &p.data, &p.temp, p.lost, p.out, p.end)
+}
+
+func (p *LocalParty) Start() *tss.Error {
+	return tss.BaseStart(p, TaskName)
+}
+
+func (p *LocalParty) StartWithContext(ctx context.Context, errCh chan<- *tss.Error) *tss.Error {
+	return tss.BaseStartWithContext(ctx, p, TaskName, errCh)
+}
+
+func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(p, msg, TaskName)
+}
+
+func (p *LocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *LocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if ok, err := p.BaseParty.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	// check that the message's "from index" will fit into the array
+	if maxFromIdx := p.params.PartyCount() - 1; maxFromIdx < msg.GetFrom().Index {
+		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+			p.params.PartyCount(), msg.GetFrom().Index), msg.GetFrom())
+	}
+	return true, nil
+}
+
+func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	fromPIdx := msg.GetFrom().Index
+
+	// switch/case is necessary to store any messages beyond current round
+	// this does not handle message replays. we expect the caller to apply replay and spoofing protection.
+	switch msg.Content().(type) {
+	case *RCRound1Message1:
+		p.temp.rcRound1Message1s[fromPIdx] = msg
+	case *RCRound1Message2:
+		p.temp.rcRound1Message2s[fromPIdx] = msg
+	case *RCRound2Message:
+		p.temp.rcRound2Messages[fromPIdx] = msg
+	default: // unrecognised message, just ignore!
+		common.Logger.Warningf("unrecognised message ignored: %v", msg)
+		return false, nil
+	}
+	return true, nil
+}
+
+func (p *LocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *LocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
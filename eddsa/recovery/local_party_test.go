@@ -0,0 +1,114 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package recovery
+
+import (
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	testThreshold = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+	tss.SetCurve(tss.Edwards())
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+
+	// the party at index 0 lost its share and threshold+1 helpers recover it
+	keys, pIDs, err := keygen.LoadKeygenTestFixtures(testThreshold + 2)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	lost := pIDs[0]
+
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]*LocalParty, 0, len(pIDs))
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan *keygen.LocalPartySaveData, len(pIDs))
+
+	for i := 0; i < len(pIDs); i++ {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, pIDs[i], len(pIDs), testThreshold)
+		var P *LocalParty
+		if i == lost.Index {
+			P = NewRecoveringParty(params, outCh, endCh).(*LocalParty)
+		} else {
+			P = NewHelperParty(params, keys[i], lost, outCh, endCh).(*LocalParty)
+		}
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	var recovered *keygen.LocalPartySaveData
+	for ended := 0; ended < len(pIDs); {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case msg := <-outCh:
+			if dest := msg.GetTo(); dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index != msg.GetFrom().Index {
+						go test.SharedPartyUpdater(P, msg, errCh)
+					}
+				}
+			} else {
+				go test.SharedPartyUpdater(parties[dest[0].Index], msg, errCh)
+			}
+		case save := <-endCh:
+			if save.ShareID.Cmp(lost.KeyInt()) == 0 {
+				recovered = save
+			}
+			ended++
+		}
+	}
+
+	if !assert.NotNil(t, recovered, "the lost party should recover its share") {
+		return
+	}
+	assert.Equal(t, keys[0].Xi, recovered.Xi, "the recovered share should be the lost one")
+	assert.Equal(t, keys[0].ShareID, recovered.ShareID)
+	assert.Equal(t, keys[0].Ks, recovered.Ks)
+	assert.Equal(t, keys[0].ChainCode, recovered.ChainCode)
+	assert.True(t, recovered.EDDSAPub.Equals(keys[0].EDDSAPub))
+	for j, bigXj := range recovered.BigXj {
+		assert.True(t, bigXj.Equals(keys[0].BigXj[j]))
+	}
+	assert.True(t, crypto.ScalarBaseMult(tss.Edwards(), recovered.Xi).Equals(keys[0].BigXj[0]))
+}
+
+func TestTooFewHelpers(t *testing.T) {
+	setUp("info")
+
+	keys, pIDs, err := keygen.LoadKeygenTestFixtures(testThreshold + 1)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	params := tss.NewParameters(tss.Edwards(), tss.NewPeerContext(pIDs), pIDs[1], len(pIDs), testThreshold)
+	P := NewHelperParty(params, keys[1], pIDs[0], make(chan tss.Message, len(pIDs)), nil)
+	if err := P.Start(); assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "helpers are needed")
+	}
+}
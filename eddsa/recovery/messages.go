@@ -0,0 +1,176 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package recovery
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"errors"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into eddsa-recovery.pb.go
+// The following messages are registered on the Protocol Buffers "wire"
+
+var (
+	// Ensure that recovery messages implement ValidateBasic
+	_ = []tss.MessageContent{
+		(*RCRound1Message1)(nil),
+		(*RCRound1Message2)(nil),
+		(*RCRound2Message)(nil),
+	}
+)
+
+// ----- //
+
+func NewRCRound1Message1(
+	to, from *tss.PartyID,
+	piece *big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	content := &RCRound1Message1{
+		Piece: piece.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *RCRound1Message1) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetPiece())
+}
+
+func (m *RCRound1Message1) UnmarshalPiece() *big.Int {
+	return new(big.Int).SetBytes(m.GetPiece())
+}
+
+// ----- //
+
+func NewRCRound1Message2(
+	from *tss.PartyID,
+	pieceCommitments []*crypto.ECPoint,
+	key *keygen.LocalPartySaveData,
+) (tss.ParsedMessage, error) {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	pieceCmtsFlat, err := crypto.FlattenECPoints(pieceCommitments)
+	if err != nil {
+		return nil, err
+	}
+	bigXjFlat, err := crypto.FlattenECPoints(key.BigXj)
+	if err != nil {
+		return nil, err
+	}
+	content := &RCRound1Message2{
+		PieceCommitments: common.BigIntsToBytes(pieceCmtsFlat),
+		Ks:               common.BigIntsToBytes(key.Ks),
+		BigXj:            common.BigIntsToBytes(bigXjFlat),
+		EddsaPub:         common.BigIntsToBytes([]*big.Int{key.EDDSAPub.X(), key.EDDSAPub.Y()}),
+		ChainCode:        key.ChainCode,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg), nil
+}
+
+func (m *RCRound1Message2) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyMultiBytes(m.GetPieceCommitments()) &&
+		len(m.GetPieceCommitments())%2 == 0 &&
+		common.NonEmptyMultiBytes(m.GetKs()) &&
+		common.NonEmptyMultiBytes(m.GetBigXj(), 2*len(m.GetKs())) &&
+		common.NonEmptyMultiBytes(m.GetEddsaPub(), 2)
+}
+
+func (m *RCRound1Message2) UnmarshalPieceCommitments(ec elliptic.Curve) ([]*crypto.ECPoint, error) {
+	points, err := crypto.UnFlattenECPoints(ec, common.MultiBytesToBigInts(m.GetPieceCommitments()))
+	if err != nil {
+		return nil, err
+	}
+	for i, point := range points {
+		points[i] = point.EightInvEight()
+	}
+	return points, nil
+}
+
+// UnmarshalPublicData returns the public key data of the sender's save data
+func (m *RCRound1Message2) UnmarshalPublicData(ec elliptic.Curve) (keygen.LocalPartySaveData, error) {
+	ks := common.MultiBytesToBigInts(m.GetKs())
+	data := keygen.NewLocalPartySaveData(len(ks))
+	data.Ks = ks
+	bigXj, err := crypto.UnFlattenECPoints(ec, common.MultiBytesToBigInts(m.GetBigXj()))
+	if err != nil {
+		return data, err
+	}
+	data.BigXj = bigXj
+	pub := common.MultiBytesToBigInts(m.GetEddsaPub())
+	if data.EDDSAPub, err = crypto.NewECPoint(ec, pub[0], pub[1]); err != nil {
+		return data, err
+	}
+	if len(m.GetChainCode()) != 0 && len(m.GetChainCode()) != keygen.ChainCodeLength {
+		return data, errors.New("the chain code has an invalid length")
+	}
+	data.ChainCode = m.GetChainCode()
+	return data, nil
+}
+
+// samePublicData reports whether two helpers sent the same public key data
+func (m *RCRound1Message2) samePublicData(other *RCRound1Message2) bool {
+	return equalMultiBytes(m.GetKs(), other.GetKs()) &&
+		equalMultiBytes(m.GetBigXj(), other.GetBigXj()) &&
+		equalMultiBytes(m.GetEddsaPub(), other.GetEddsaPub()) &&
+		bytes.Equal(m.GetChainCode(), other.GetChainCode())
+}
+
+// ----- //
+
+func NewRCRound2Message(
+	to, from *tss.PartyID,
+	pieceSum *big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	content := &RCRound2Message{
+		PieceSum: pieceSum.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *RCRound2Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetPieceSum())
+}
+
+func (m *RCRound2Message) UnmarshalPieceSum() *big.Int {
+	return new(big.Int).SetBytes(m.GetPieceSum())
+}
+
+func equalMultiBytes(a, b [][]byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !bytes.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,184 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package recovery
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
+	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func (round *round2) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	Pi := round.PartyID()
+	ec := round.Params().EC()
+	helpers := round.helpers()
+
+	// 5. check that all helpers sent the same public key data; a helper compares it with its own key
+	pub, culprits, err := round.publicData()
+	if err != nil {
+		return round.WrapError(err, culprits...)
+	}
+
+	// 6. check that the pieces of every helper Pj add up to its contribution lambda_j * X_j
+	for k, Pj := range helpers {
+		pieceCmts, err := round.pieceCommitments(Pj)
+		if err != nil {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		lambda, err := vss.LagrangeCoefficient(ec, helpers.Keys(), k, round.lost.KeyInt())
+		if err != nil {
+			return round.WrapError(err)
+		}
+		sum, err := sumPoints(pieceCmts)
+		if err != nil || !sum.Equals(pub.BigXj[keyIndex(pub.Ks, Pj.KeyInt())].ScalarMult(lambda)) {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		if round.isLost() {
+			continue
+		}
+		// 7. a helper checks the piece that it received from Pj against its commitment
+		r1msg1 := round.temp.rcRound1Message1s[Pj.Index].Content().(*RCRound1Message1)
+		if !crypto.ScalarBaseMult(ec, r1msg1.UnmarshalPiece()).Equals(pieceCmts[helperPosition(helpers, Pi)]) {
+			culprits = append(culprits, Pj)
+		}
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("the pieces of the lost share failed to verify"), culprits...)
+	}
+	if round.isLost() {
+		return nil
+	}
+
+	// 8. a helper sends the sum of the pieces that it received to the lost party and is done
+	modQ := common.ModInt(ec.Params().N)
+	pieceSum := big.NewInt(0)
+	for _, Pj := range helpers {
+		r1msg1 := round.temp.rcRound1Message1s[Pj.Index].Content().(*RCRound1Message1)
+		pieceSum = modQ.Add(pieceSum, r1msg1.UnmarshalPiece())
+	}
+	round.out <- NewRCRound2Message(round.lost, Pi, pieceSum)
+
+	round.end <- round.key
+	return nil
+}
+
+func (round *round2) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*RCRound2Message); ok {
+		return !msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round2) Update() (bool, *tss.Error) {
+	// only the lost party receives messages in this round
+	if !round.isLost() {
+		return false, nil
+	}
+	ret := true
+	for j, msg := range round.temp.rcRound2Messages {
+		if round.ok[j] {
+			continue
+		}
+		if j == round.lost.Index {
+			round.ok[j] = true
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round2) NextRound() tss.Round {
+	if !round.isLost() {
+		return nil // the helpers are done
+	}
+	round.started = false
+	return &round3{round}
+}
+
+// ----- //
+
+// publicData returns the public key data that all helpers agree on, or the helpers to blame
+func (round *round1) publicData() (*keygen.LocalPartySaveData, []*tss.PartyID, error) {
+	helpers := round.helpers()
+	ref := round.temp.rcRound1Message2s[helpers[0].Index].Content().(*RCRound1Message2)
+	if !round.isLost() {
+		ref = round.temp.rcRound1Message2s[round.PartyID().Index].Content().(*RCRound1Message2)
+	}
+	culprits := make([]*tss.PartyID, 0, len(helpers))
+	for _, Pj := range helpers {
+		r1msg2 := round.temp.rcRound1Message2s[Pj.Index].Content().(*RCRound1Message2)
+		if !ref.samePublicData(r1msg2) {
+			culprits = append(culprits, Pj)
+		}
+	}
+	if len(culprits) > 0 {
+		return nil, culprits, errors.New("the helpers sent different public key data")
+	}
+	pub, err := ref.UnmarshalPublicData(round.Params().EC())
+	if err != nil {
+		return nil, helpers, err
+	}
+	for _, Pj := range round.Parties().IDs() {
+		if keyIndex(pub.Ks, Pj.KeyInt()) < 0 {
+			return nil, helpers, fmt.Errorf("the party %s did not take part in the keygen", Pj)
+		}
+	}
+	return &pub, nil, nil
+}
+
+// pieceCommitments returns the commitments to the pieces that the helper Pj split its contribution into
+func (round *round1) pieceCommitments(Pj *tss.PartyID) ([]*crypto.ECPoint, error) {
+	r1msg2 := round.temp.rcRound1Message2s[Pj.Index].Content().(*RCRound1Message2)
+	pieceCmts, err := r1msg2.UnmarshalPieceCommitments(round.Params().EC())
+	if err != nil {
+		return nil, err
+	}
+	if len(pieceCmts) != len(round.helpers()) {
+		return nil, errors.New("the number of piece commitments does not match the number of helpers")
+	}
+	return pieceCmts, nil
+}
+
+func sumPoints(points []*crypto.ECPoint) (*crypto.ECPoint, error) {
+	sum := points[0]
+	for _, point := range points[1:] {
+		var err error
+		if sum, err = sum.Add(point); err != nil {
+			return nil, err
+		}
+	}
+	return sum, nil
+}
+
+func keyIndex(ks []*big.Int, key *big.Int) int {
+	for j, kj := range ks {
+		if kj.Cmp(key) == 0 {
+			return j
+		}
+	}
+	return -1
+}
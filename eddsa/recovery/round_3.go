@@ -0,0 +1,87 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package recovery
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func (round *round3) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 3
+	round.started = true
+	round.resetOK()
+
+	ec := round.Params().EC()
+	helpers := round.helpers()
+	pub, culprits, err := round.publicData()
+	if err != nil {
+		return round.WrapError(err, culprits...)
+	}
+
+	pieceCmts := make([][]*crypto.ECPoint, len(helpers))
+	for k, Pj := range helpers {
+		if pieceCmts[k], err = round.pieceCommitments(Pj); err != nil {
+			return round.WrapError(err, Pj)
+		}
+	}
+
+	// 9. check the sum of the pieces from every helper Pj against the commitments to the pieces that Pj received
+	modQ := common.ModInt(ec.Params().N)
+	xi := big.NewInt(0)
+	for k, Pj := range helpers {
+		r2msg := round.temp.rcRound2Messages[Pj.Index].Content().(*RCRound2Message)
+		pieceSum := r2msg.UnmarshalPieceSum()
+		column := make([]*crypto.ECPoint, len(helpers))
+		for l := range helpers {
+			column[l] = pieceCmts[l][k]
+		}
+		expected, err := sumPoints(column)
+		if err != nil || !crypto.ScalarBaseMult(ec, pieceSum).Equals(expected) {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		xi = modQ.Add(xi, pieceSum)
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("the sums of the pieces of the lost share failed to verify"), culprits...)
+	}
+
+	// 10. the sum of all pieces is the lost share; check it against its public commitment
+	shareID := round.lost.KeyInt()
+	if !crypto.ScalarBaseMult(ec, xi).Equals(pub.BigXj[keyIndex(pub.Ks, shareID)]) {
+		return round.WrapError(errors.New("the recovered share does not match its public commitment"))
+	}
+
+	*round.save = *pub
+	round.save.Xi = xi
+	round.save.ShareID = shareID
+
+	round.end <- round.save
+	return nil
+}
+
+func (round *round3) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *round3) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *round3) NextRound() tss.Round {
+	return nil // finished!
+}
@@ -0,0 +1,150 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	cmt "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
+	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// Implements Party
+// Implements Stringer
+var _ tss.Party = (*LocalParty)(nil)
+var _ fmt.Stringer = (*LocalParty)(nil)
+
+type (
+	LocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		key  keygen.LocalPartySaveData
+		temp localTempData
+		data keygen.LocalPartySaveData
+
+		// outbound messaging
+		out chan<- tss.Message
+		end chan<- *keygen.LocalPartySaveData
+	}
+
+	localMessageStore struct {
+		rfRound1Messages,
+		rfRound2Message1s,
+		rfRound2Message2s []tss.ParsedMessage
+	}
+
+	localTempData struct {
+		localMessageStore
+
+		// temp data (thrown away after refresh)
+		RFCs          []cmt.HashCommitment
+		vs            vss.Vs
+		shares        vss.Shares
+		deCommitPolyG cmt.HashDeCommitment
+	}
+)
+
+// NewLocalParty creates a party that refreshes the share in `key` together with the other parties of the keygen.
+// All the parties of the keygen must take part with the same threshold. The public key EDDSAPub stays the same,
+// while Xi and BigXj are re-randomized; the refreshed save data is sent to `end` and replaces `key`.
+// Exported, used in `tss` client
+func NewLocalParty(
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *keygen.LocalPartySaveData,
+) tss.Party {
+	partyCount := params.PartyCount()
+	p := &LocalParty{
+		BaseParty: new(tss.BaseParty),
+		params:    params,
+		key:       key,
+		temp:      localTempData{},
+		data:      keygen.NewLocalPartySaveData(partyCount),
+		out:       out,
+		end:       end,
+	}
+	// msgs init
+	p.temp.rfRound1Messages = make([]tss.ParsedMessage, partyCount)
+	p.temp.rfRound2Message1s = make([]tss.ParsedMessage, partyCount)
+	p.temp.rfRound2Message2s = make([]tss.ParsedMessage, partyCount)
+	// temp data init
+	p.temp.RFCs = make([]cmt.HashCommitment, partyCount)
+	return p
+}
+
+func (p *LocalParty) FirstRound() tss.Round {
+	return newRound1(p.params, &p.key, &p.data, &p.temp, p.out, p.end)
+}
+
+func (p *LocalParty) Start() *tss.Error {
+	return tss.BaseStart(p, TaskName)
+}
+
+func (p *LocalParty) StartWithContext(ctx context.Context, errCh chan<- *tss.Error) *tss.Error {
+	return tss.BaseStartWithContext(ctx, p, TaskName, errCh)
+}
+
+func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(p, msg, TaskName)
+}
+
+func (p *LocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *LocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if ok, err := p.BaseParty.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	// check that the message's "from index" will fit into the array
+	if maxFromIdx := p.params.PartyCount() - 1; maxFromIdx < msg.GetFrom().Index {
+		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+			p.params.PartyCount(), msg.GetFrom().Index), msg.GetFrom())
+	}
+	return true, nil
+}
+
+func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	fromPIdx := msg.GetFrom().Index
+
+	// switch/case is necessary to store any messages beyond current round
+	// this does not handle message replays. we expect the caller to apply replay and spoofing protection.
+	switch msg.Content().(type) {
+	case *RFRound1Message:
+		p.temp.rfRound1Messages[fromPIdx] = msg
+	case *RFRound2Message1:
+		p.temp.rfRound2Message1s[fromPIdx] = msg
+	case *RFRound2Message2:
+		p.temp.rfRound2Message2s[fromPIdx] = msg
+	default: // unrecognised message, just ignore!
+		common.Logger.Warningf("unrecognised message ignored: %v", msg)
+		return false, nil
+	}
+	return true, nil
+}
+
+func (p *LocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *LocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
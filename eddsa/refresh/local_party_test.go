@@ -0,0 +1,122 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh
+
+import (
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
+	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+	tss.SetCurve(tss.Edwards())
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+
+	keys, pIDs, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]*LocalParty, 0, len(pIDs))
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan *keygen.LocalPartySaveData, len(pIDs))
+
+	for i := 0; i < len(pIDs); i++ {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, pIDs[i], len(pIDs), testThreshold)
+		P := NewLocalParty(params, keys[i], outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	saves := make([]*keygen.LocalPartySaveData, len(pIDs))
+	for ended := 0; ended < len(pIDs); {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case msg := <-outCh:
+			if dest := msg.GetTo(); dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index != msg.GetFrom().Index {
+						go test.SharedPartyUpdater(P, msg, errCh)
+					}
+				}
+			} else {
+				go test.SharedPartyUpdater(parties[dest[0].Index], msg, errCh)
+			}
+		case save := <-endCh:
+			index, err := save.OriginalIndex()
+			assert.NoError(t, err, "should not be an error getting a party's index from save data")
+			saves[index] = save
+			ended++
+		}
+	}
+
+	ec := tss.Edwards()
+	for i, save := range saves {
+		// the public key stays the same while the shares change
+		assert.True(t, save.EDDSAPub.Equals(keys[i].EDDSAPub))
+		assert.NotEqual(t, keys[i].Xi, save.Xi)
+		assert.Equal(t, keys[i].ShareID, save.ShareID)
+		for j := range saves {
+			assert.True(t, crypto.ScalarBaseMult(ec, saves[j].Xi).Equals(save.BigXj[j]), "BigXj should match the refreshed shares")
+		}
+	}
+
+	// the refreshed shares reconstruct the same secret
+	shares := make(vss.Shares, 0, testThreshold+1)
+	for _, save := range saves[:testThreshold+1] {
+		shares = append(shares, &vss.Share{Threshold: testThreshold, ID: save.ShareID, Share: save.Xi})
+	}
+	u, err := shares.ReConstruct(ec)
+	assert.NoError(t, err)
+	assert.True(t, crypto.ScalarBaseMult(ec, u).Equals(keys[0].EDDSAPub))
+
+	// a mix of old and refreshed shares does not
+	shares[0] = &vss.Share{Threshold: testThreshold, ID: keys[0].ShareID, Share: keys[0].Xi}
+	u, err = shares.ReConstruct(ec)
+	assert.NoError(t, err)
+	assert.False(t, crypto.ScalarBaseMult(ec, u).Equals(keys[0].EDDSAPub))
+}
+
+func TestMissingParties(t *testing.T) {
+	setUp("info")
+
+	keys, pIDs, err := keygen.LoadKeygenTestFixtures(testThreshold + 1)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	params := tss.NewParameters(tss.Edwards(), tss.NewPeerContext(pIDs), pIDs[0], len(pIDs), testThreshold)
+	P := NewLocalParty(params, keys[0], make(chan tss.Message, len(pIDs)), nil)
+	if err := P.Start(); assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "all the")
+	}
+}
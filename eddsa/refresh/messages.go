@@ -0,0 +1,105 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh
+
+import (
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	cmt "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into eddsa-refresh.pb.go
+// The following messages are registered on the Protocol Buffers "wire"
+
+var (
+	// Ensure that refresh messages implement ValidateBasic
+	_ = []tss.MessageContent{
+		(*RFRound1Message)(nil),
+		(*RFRound2Message1)(nil),
+		(*RFRound2Message2)(nil),
+	}
+)
+
+// ----- //
+
+func NewRFRound1Message(from *tss.PartyID, ct cmt.HashCommitment) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &RFRound1Message{
+		Commitment: ct.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *RFRound1Message) ValidateBasic() bool {
+	return m != nil && common.NonEmptyBytes(m.GetCommitment())
+}
+
+func (m *RFRound1Message) UnmarshalCommitment() *big.Int {
+	return new(big.Int).SetBytes(m.GetCommitment())
+}
+
+// ----- //
+
+func NewRFRound2Message1(
+	to, from *tss.PartyID,
+	share *vss.Share,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	content := &RFRound2Message1{
+		Share: share.Share.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *RFRound2Message1) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetShare())
+}
+
+func (m *RFRound2Message1) UnmarshalShare() *big.Int {
+	return new(big.Int).SetBytes(m.Share)
+}
+
+// ----- //
+
+func NewRFRound2Message2(
+	from *tss.PartyID,
+	deCommitment cmt.HashDeCommitment,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	dcBzs := common.BigIntsToBytes(deCommitment)
+	content := &RFRound2Message2{
+		DeCommitment: dcBzs,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *RFRound2Message2) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyMultiBytes(m.GetDeCommitment())
+}
+
+func (m *RFRound2Message2) UnmarshalDeCommitment() []*big.Int {
+	deComBzs := m.GetDeCommitment()
+	return cmt.NewHashDeCommitmentFromBytes(deComBzs)
+}
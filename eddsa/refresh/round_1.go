@@ -0,0 +1,120 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	cmts "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
+	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// round 1 represents round 1 of the share refresh: every party creates shares of zero and commits to them
+func newRound1(params *tss.Parameters, key, save *keygen.LocalPartySaveData, temp *localTempData, out chan<- tss.Message, end chan<- *keygen.LocalPartySaveData) tss.Round {
+	return &round1{
+		&base{params, key, save, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1}}
+}
+
+func (round *round1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	Pi := round.PartyID()
+	i := Pi.Index
+
+	if err := round.prepare(); err != nil {
+		return round.WrapError(err, Pi)
+	}
+
+	// 1. compute the vss shares of zero
+	ids := round.Parties().IDs().Keys()
+	vs, shares, err := vss.CreateZeroSharing(round.Params().EC(), round.Threshold(), ids)
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+
+	// 2. make commitment -> (C, D)
+	pGFlat, err := crypto.FlattenECPoints(vs)
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+	cmt := cmts.NewHashCommitment(pGFlat...)
+
+	round.temp.vs = vs
+	round.temp.shares = shares
+	round.temp.deCommitPolyG = cmt.D
+
+	// BROADCAST commitments
+	{
+		msg := NewRFRound1Message(round.PartyID(), cmt.C)
+		round.temp.rfRound1Messages[i] = msg
+		round.out <- msg
+	}
+	return nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*RFRound1Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.rfRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		// vss check is in round 3
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &round2{round}
+}
+
+// prepare checks that the parties are the ones of the keygen and copies the key into the save data, ordered like the parties
+func (round *round1) prepare() error {
+	key := round.key
+	if key.EDDSAPub == nil || key.Xi == nil || key.ShareID == nil {
+		return errors.New("the key to refresh is incomplete")
+	}
+	if len(key.Ks) != round.PartyCount() || len(key.BigXj) != len(key.Ks) {
+		return fmt.Errorf("all the %d parties of the keygen must take part in the refresh", len(key.Ks))
+	}
+	if key.ShareID.Cmp(round.PartyID().KeyInt()) != 0 {
+		return errors.New("the key does not belong to this party")
+	}
+	ks := make(map[string]struct{}, len(key.Ks))
+	for _, kj := range key.Ks {
+		ks[hex.EncodeToString(kj.Bytes())] = struct{}{}
+	}
+	for _, Pj := range round.Parties().IDs() {
+		if _, ok := ks[hex.EncodeToString(Pj.Key)]; !ok {
+			return fmt.Errorf("the party %s did not take part in the keygen", Pj)
+		}
+	}
+	*round.save = keygen.BuildLocalSaveDataSubset(*key, round.Parties().IDs())
+	return nil
+}
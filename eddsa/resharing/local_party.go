@@ -7,6 +7,7 @@
 package resharing
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 
@@ -63,6 +64,7 @@ type (
 // The `key` is read from and/or written to depending on whether this party is part of the old or the new committee.
 // You may optionally generate and set the LocalPreParams if you would like to use pre-generated safe primes and Paillier secret.
 // (This is similar to providing the `optionalPreParams` to `keygen.LocalParty`).
+// Keys that sign sr25519 are re-shared the same way, since they are the same Ed25519 key shares.
 func NewLocalParty(
 	params *tss.ReSharingParameters,
 	key keygen.LocalPartySaveData,
@@ -101,6 +103,10 @@ func (p *LocalParty) Start() *tss.Error {
 	return tss.BaseStart(p, TaskName)
 }
 
+func (p *LocalParty) StartWithContext(ctx context.Context, errCh chan<- *tss.Error) *tss.Error {
+	return tss.BaseStartWithContext(ctx, p, TaskName, errCh)
+}
+
 func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
 	return tss.BaseUpdate(p, msg, TaskName)
 }
@@ -7,6 +7,7 @@
 package resharing_test
 
 import (
+	"crypto/rand"
 	"math/big"
 	"sync/atomic"
 	"testing"
@@ -17,6 +18,7 @@ import (
 
 	"github.com/bnb-chain/tss-lib/v2/common"
 	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
 	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
 	. "github.com/bnb-chain/tss-lib/v2/eddsa/resharing"
 	"github.com/bnb-chain/tss-lib/v2/eddsa/signing"
@@ -47,6 +49,13 @@ func TestE2EConcurrent(t *testing.T) {
 	firstPartyIdx, extraParties := 1, 1 // // extra can be 0 to N-first
 	oldKeys, oldPIDs, err := keygen.LoadKeygenTestFixtures(testThreshold+1+extraParties+firstPartyIdx, firstPartyIdx)
 	assert.NoError(t, err, "should load keygen fixtures")
+	// the fixtures predate the chain code; give the key one to check that it is carried over to the new committee
+	chainCode := make([]byte, keygen.ChainCodeLength)
+	_, err = rand.Read(chainCode)
+	assert.NoError(t, err)
+	for j := range oldKeys {
+		oldKeys[j].ChainCode = chainCode
+	}
 
 	// PHASE: resharing
 	oldP2PCtx := tss.NewPeerContext(oldPIDs)
@@ -145,6 +154,7 @@ func TestE2EConcurrent(t *testing.T) {
 					gXj := crypto.ScalarBaseMult(tss.Edwards(), xj)
 					BigXj := key.BigXj[j]
 					assert.True(t, BigXj.Equals(gXj), "ensure BigX_j == g^x_j")
+					assert.Equal(t, chainCode, key.ChainCode, "the chain code should be carried over")
 				}
 
 				// more verification of signing is implemented within local_party_test.go of keygen package
@@ -228,3 +238,222 @@ signing:
 		}
 	}
 }
+
+func TestE2ESnapshotAndRestore(t *testing.T) {
+	setUp("info")
+
+	threshold, newThreshold := testThreshold, testThreshold
+
+	// PHASE: load keygen fixtures
+	oldKeys, oldPIDs, err := keygen.LoadKeygenTestFixtures(testThreshold + 1)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	// PHASE: resharing
+	oldP2PCtx := tss.NewPeerContext(oldPIDs)
+	newPIDs := tss.GenerateTestPartyIDs(testParticipants)
+	newP2PCtx := tss.NewPeerContext(newPIDs)
+	newPCount := len(newPIDs)
+
+	oldParams := make([]*tss.ReSharingParameters, 0, len(oldPIDs))
+	newParams := make([]*tss.ReSharingParameters, 0, newPCount)
+	oldCommittee := make([]tss.Party, 0, len(oldPIDs))
+	newCommittee := make([]tss.Party, 0, newPCount)
+
+	// messages are routed synchronously below, so the channels must be able to hold everything that is produced
+	bothCommitteesPax := len(oldPIDs) + newPCount
+	errCh := make(chan *tss.Error, bothCommitteesPax*bothCommitteesPax*4)
+	outCh := make(chan tss.Message, bothCommitteesPax*bothCommitteesPax*4)
+	endCh := make(chan *keygen.LocalPartySaveData, bothCommitteesPax)
+
+	key := make([]byte, tss.SnapshotKeyLength)
+	_, err = rand.Read(key)
+	assert.NoError(t, err)
+
+	for j, pID := range oldPIDs {
+		params := tss.NewReSharingParameters(tss.Edwards(), oldP2PCtx, newP2PCtx, pID, testParticipants, threshold, newPCount, newThreshold)
+		oldParams = append(oldParams, params)
+		oldCommittee = append(oldCommittee, NewLocalParty(params, oldKeys[j], outCh, endCh))
+	}
+	for _, pID := range newPIDs {
+		params := tss.NewReSharingParameters(tss.Edwards(), oldP2PCtx, newP2PCtx, pID, testParticipants, threshold, newPCount, newThreshold)
+		newParams = append(newParams, params)
+		newCommittee = append(newCommittee, NewLocalParty(params, keygen.NewLocalPartySaveData(newPCount), outCh, endCh))
+	}
+	for _, P := range append(newCommittee, oldCommittee...) {
+		if err := P.Start(); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+	}
+
+	restore := func(committee []tss.Party, params []*tss.ReSharingParameters) {
+		for i, P := range committee {
+			if !P.Running() {
+				continue // finished
+			}
+			snapshot, err := P.Snapshot(key)
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			restored, err := RestoreLocalParty(params[i], snapshot, key, outCh, endCh)
+			if err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			committee[i] = restored
+		}
+	}
+
+	// snapshot and restore every party regularly, so that the state of each round goes through a snapshot
+	routed, ended := 0, 0
+	newKeys := make([]keygen.LocalPartySaveData, newPCount)
+	for ended < bothCommitteesPax {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if msg.IsToOldCommittee() || msg.IsToOldAndNewCommittees() {
+				for _, destP := range dest[:len(oldCommittee)] {
+					test.SharedPartyUpdater(oldCommittee[destP.Index], msg, errCh)
+				}
+			}
+			if !msg.IsToOldCommittee() || msg.IsToOldAndNewCommittees() {
+				for _, destP := range dest {
+					test.SharedPartyUpdater(newCommittee[destP.Index], msg, errCh)
+				}
+			}
+			if routed++; routed%3 == 0 {
+				restore(oldCommittee, oldParams)
+				restore(newCommittee, newParams)
+			}
+		case save := <-endCh:
+			if save.Xi != nil {
+				index, err := save.OriginalIndex()
+				assert.NoErrorf(t, err, "should not be an error getting a party's index from save data")
+				newKeys[index] = *save
+			}
+			ended++
+		}
+	}
+
+	// the new shares must still combine to the original public key
+	shares := make(vss.Shares, 0, newThreshold+1)
+	for j, key := range newKeys {
+		assert.True(t, key.BigXj[j].Equals(crypto.ScalarBaseMult(tss.Edwards(), key.Xi)), "ensure BigX_j == g^x_j")
+		if j <= newThreshold {
+			shares = append(shares, &vss.Share{Threshold: newThreshold, ID: key.ShareID, Share: key.Xi})
+		}
+	}
+	u, err := shares.ReConstruct(tss.Edwards())
+	assert.NoError(t, err)
+	assert.True(t, crypto.ScalarBaseMult(tss.Edwards(), u).Equals(oldKeys[0].EDDSAPub))
+}
+
+func TestE2ESr25519(t *testing.T) {
+	setUp("info")
+
+	threshold, newThreshold := testThreshold, testThreshold
+
+	// PHASE: load keygen fixtures
+	oldKeys, oldPIDs, err := keygen.LoadKeygenTestFixtures(testThreshold + 1)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	// PHASE: resharing
+	oldP2PCtx := tss.NewPeerContext(oldPIDs)
+	newPIDs := tss.GenerateTestPartyIDs(testParticipants)
+	newP2PCtx := tss.NewPeerContext(newPIDs)
+	newPCount := len(newPIDs)
+
+	oldCommittee := make([]tss.Party, 0, len(oldPIDs))
+	newCommittee := make([]tss.Party, 0, newPCount)
+
+	// messages are routed synchronously below, so the channels must be able to hold everything that is produced
+	bothCommitteesPax := len(oldPIDs) + newPCount
+	errCh := make(chan *tss.Error, bothCommitteesPax*bothCommitteesPax*4)
+	outCh := make(chan tss.Message, bothCommitteesPax*bothCommitteesPax*4)
+	endCh := make(chan *keygen.LocalPartySaveData, bothCommitteesPax)
+
+	for j, pID := range oldPIDs {
+		params := tss.NewReSharingParameters(tss.Edwards(), oldP2PCtx, newP2PCtx, pID, testParticipants, threshold, newPCount, newThreshold)
+		oldCommittee = append(oldCommittee, NewLocalParty(params, oldKeys[j], outCh, endCh))
+	}
+	for _, pID := range newPIDs {
+		params := tss.NewReSharingParameters(tss.Edwards(), oldP2PCtx, newP2PCtx, pID, testParticipants, threshold, newPCount, newThreshold)
+		newCommittee = append(newCommittee, NewLocalParty(params, keygen.NewLocalPartySaveData(newPCount), outCh, endCh))
+	}
+	for _, P := range append(newCommittee, oldCommittee...) {
+		if err := P.Start(); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+	}
+
+	ended := 0
+	newKeys := make([]keygen.LocalPartySaveData, newPCount)
+	for ended < bothCommitteesPax {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if msg.IsToOldCommittee() || msg.IsToOldAndNewCommittees() {
+				for _, destP := range dest[:len(oldCommittee)] {
+					test.SharedPartyUpdater(oldCommittee[destP.Index], msg, errCh)
+				}
+			}
+			if !msg.IsToOldCommittee() || msg.IsToOldAndNewCommittees() {
+				for _, destP := range dest {
+					test.SharedPartyUpdater(newCommittee[destP.Index], msg, errCh)
+				}
+			}
+		case save := <-endCh:
+			if save.Xi != nil {
+				index, err := save.OriginalIndex()
+				assert.NoErrorf(t, err, "should not be an error getting a party's index from save data")
+				newKeys[index] = *save
+			}
+			ended++
+		}
+	}
+
+	// PHASE: signing
+	// the reshared key signs sr25519 as well, since it is the same Ed25519 key
+	opts := signing.Options{Variant: signing.Sr25519, Context: []byte("substrate")}
+	msg := big.NewInt(42).Bytes()
+	signP2pCtx := tss.NewPeerContext(newPIDs)
+	signParties := make([]tss.Party, 0, newPCount)
+
+	signErrCh := make(chan *tss.Error, newPCount*newPCount*4)
+	signOutCh := make(chan tss.Message, newPCount*newPCount*4)
+	signEndCh := make(chan *common.SignatureData, newPCount)
+
+	for j, signPID := range newPIDs {
+		params := tss.NewParameters(tss.Edwards(), signP2pCtx, signPID, newPCount, newThreshold)
+		P := signing.NewLocalPartyWithOptions(msg, params, newKeys[j], nil, opts, signOutCh, signEndCh)
+		signParties = append(signParties, P)
+		if err := P.Start(); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+	}
+
+	sigs := make([]*common.SignatureData, 0, newPCount)
+	for len(sigs) < newPCount {
+		select {
+		case err := <-signErrCh:
+			assert.FailNow(t, err.Error())
+		case m := <-signOutCh:
+			if dest := m.GetTo(); dest == nil {
+				for _, P := range signParties {
+					if P.PartyID().Index != m.GetFrom().Index {
+						test.SharedPartyUpdater(P, m, signErrCh)
+					}
+				}
+			} else {
+				test.SharedPartyUpdater(signParties[dest[0].Index], m, signErrCh)
+			}
+		case sig := <-signEndCh:
+			sigs = append(sigs, sig)
+		}
+	}
+	for _, sig := range sigs {
+		assert.True(t, opts.Verify(oldKeys[0].EDDSAPub, msg, sig.Signature), "sr25519 verify must pass")
+	}
+}
@@ -37,6 +37,7 @@ func NewDGRound1Message(
 	from *tss.PartyID,
 	eddsaPub *crypto.ECPoint,
 	vct cmt.HashCommitment,
+	chainCode []byte,
 ) tss.ParsedMessage {
 	meta := tss.MessageRouting{
 		From:             from,
@@ -48,6 +49,7 @@ func NewDGRound1Message(
 		EddsaPubX:   eddsaPub.X().Bytes(),
 		EddsaPubY:   eddsaPub.Y().Bytes(),
 		VCommitment: vct.Bytes(),
+		ChainCode:   chainCode,
 	}
 	msg := tss.NewMessageWrapper(meta, content)
 	return tss.NewMessage(meta, content, msg)
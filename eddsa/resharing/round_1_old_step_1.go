@@ -7,6 +7,7 @@
 package resharing
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 
@@ -69,7 +70,7 @@ func (round *round1) Start() *tss.Error {
 	// 5. "broadcast" C_i to members of the NEW committee
 	r1msg := NewDGRound1Message(
 		round.NewParties().IDs().Exclude(round.PartyID()), round.PartyID(),
-		round.input.EDDSAPub, vCmt.C)
+		round.input.EDDSAPub, vCmt.C, round.input.ChainCode)
 	round.temp.dgRound1Messages[i] = r1msg
 	round.out <- r1msg
 
@@ -118,9 +119,34 @@ func (round *round1) Update() (bool, *tss.Error) {
 		}
 		round.save.EDDSAPub = candidate
 	}
+	if ret {
+		// the new committee keeps the chain code of the key for HD derivation; the old committee must agree on it
+		chainCode, culprits := round.chainCode()
+		if len(culprits) > 0 {
+			return false, round.WrapError(errors.New("the chain code did not match what the old committee sent"), culprits...)
+		}
+		round.save.ChainCode = chainCode
+	}
 	return ret, nil
 }
 
+// chainCode returns the chain code sent by the first party of the old committee, and the old parties that sent an
+// invalid or a different one
+func (round *round1) chainCode() ([]byte, []*tss.PartyID) {
+	chainCode := round.temp.dgRound1Messages[0].Content().(*DGRound1Message).GetChainCode()
+	culprits := make([]*tss.PartyID, 0)
+	for _, msg := range round.temp.dgRound1Messages {
+		candidate := msg.Content().(*DGRound1Message).GetChainCode()
+		if (len(candidate) != 0 && len(candidate) != keygen.ChainCodeLength) || !bytes.Equal(candidate, chainCode) {
+			culprits = append(culprits, msg.GetFrom())
+		}
+	}
+	if len(chainCode) == 0 {
+		return nil, culprits
+	}
+	return chainCode, culprits
+}
+
 func (round *round1) NextRound() tss.Round {
 	round.started = false
 	return &round2{round}
@@ -0,0 +1,158 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package resharing
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	cmt "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
+	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+type snapshotState struct {
+	Input, Save  keygen.LocalPartySaveData
+	OldOK, NewOK []bool
+	Messages     [][]*tss.SnapshotMessage
+
+	NewVs     vss.Vs
+	NewShares vss.Shares
+	VD        cmt.HashDeCommitment
+
+	NewXi     *big.Int
+	NewKs     []*big.Int
+	NewBigXjs []*crypto.ECPoint
+}
+
+// Snapshot exports the in-flight state of this party, encrypted with a key of tss.SnapshotKeyLength bytes.
+// The snapshot contains secret key material and should be stored with the same care as the key share itself.
+func (p *LocalParty) Snapshot(key []byte) ([]byte, *tss.Error) {
+	return tss.BaseSnapshot(p, TaskName, key, p.snapshotState)
+}
+
+// RestoreLocalParty rebuilds a party from a snapshot taken with Snapshot(). The party continues from the round it was in,
+// so it must not be started again; feed it the messages it has not yet received through Update() or UpdateFromBytes().
+// `params` must describe the same party and committees as the ones used to construct the original party.
+func RestoreLocalParty(
+	params *tss.ReSharingParameters,
+	snapshot, key []byte,
+	out chan<- tss.Message,
+	end chan<- *keygen.LocalPartySaveData,
+) (tss.Party, *tss.Error) {
+	p := &LocalParty{
+		BaseParty: new(tss.BaseParty),
+		params:    params,
+		temp:      localTempData{},
+		out:       out,
+		end:       end,
+	}
+	if err := tss.BaseRestore(p, TaskName, snapshot, key, p.restoreState); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *LocalParty) messageStores() []*[]tss.ParsedMessage {
+	return []*[]tss.ParsedMessage{
+		&p.temp.dgRound1Messages,
+		&p.temp.dgRound2Messages,
+		&p.temp.dgRound3Message1s,
+		&p.temp.dgRound3Message2s,
+		&p.temp.dgRound4Messages,
+	}
+}
+
+func (p *LocalParty) snapshotState(rnd tss.Round) (interface{}, error) {
+	round, err := roundBase(rnd)
+	if err != nil {
+		return nil, err
+	}
+	state := &snapshotState{
+		Input:     p.input,
+		Save:      p.save,
+		OldOK:     round.oldOK,
+		NewOK:     round.newOK,
+		NewVs:     p.temp.NewVs,
+		NewShares: p.temp.NewShares,
+		VD:        p.temp.VD,
+		NewXi:     p.temp.newXi,
+		NewKs:     p.temp.newKs,
+		NewBigXjs: p.temp.newBigXjs,
+	}
+	for _, store := range p.messageStores() {
+		msgs, err := tss.NewSnapshotMessages(*store)
+		if err != nil {
+			return nil, err
+		}
+		state.Messages = append(state.Messages, msgs)
+	}
+	return state, nil
+}
+
+func (p *LocalParty) restoreState(stateBz []byte, number int) (tss.Round, error) {
+	state := new(snapshotState)
+	if err := json.Unmarshal(stateBz, state); err != nil {
+		return nil, err
+	}
+	oldPartyCount, newPartyCount := len(p.params.OldParties().IDs()), p.params.NewPartyCount()
+	// the expected length of each message store; old committee messages are indexed by old party
+	counts := []int{oldPartyCount, newPartyCount, oldPartyCount, oldPartyCount, newPartyCount}
+	stores := p.messageStores()
+	if len(state.OldOK) != oldPartyCount || len(state.NewOK) != newPartyCount || len(state.Messages) != len(stores) {
+		return nil, errors.New("the snapshot does not match the committees in params")
+	}
+	for k, store := range stores {
+		msgs, err := tss.ParseSnapshotMessages(state.Messages[k], counts[k])
+		if err != nil {
+			return nil, err
+		}
+		*store = msgs
+	}
+	p.input = state.Input
+	p.save = state.Save
+	p.temp.NewVs = state.NewVs
+	p.temp.NewShares = state.NewShares
+	p.temp.VD = state.VD
+	p.temp.newXi = state.NewXi
+	p.temp.newKs = state.NewKs
+	p.temp.newBigXjs = state.NewBigXjs
+
+	r1 := newRound1(p.params, &p.input, &p.save, &p.temp, p.out, p.end).(*round1)
+	copy(r1.oldOK, state.OldOK)
+	copy(r1.newOK, state.NewOK)
+	r1.started = true
+	r1.number = number
+	r2 := &round2{r1}
+	r3 := &round3{r2}
+	r4 := &round4{r3}
+	rounds := []tss.Round{r1, r2, r3, r4, &round5{r4}}
+	if number < 1 || len(rounds) < number {
+		return nil, fmt.Errorf("the snapshot has an unexpected round number %d", number)
+	}
+	return rounds[number-1], nil
+}
+
+func roundBase(rnd tss.Round) (*base, error) {
+	switch round := rnd.(type) {
+	case *round1:
+		return round.base, nil
+	case *round2:
+		return round.base, nil
+	case *round3:
+		return round.base, nil
+	case *round4:
+		return round.base, nil
+	case *round5:
+		return round.base, nil
+	}
+	return nil, fmt.Errorf("unexpected round type %T", rnd)
+}
@@ -12,7 +12,6 @@ import (
 	"math/big"
 
 	"github.com/agl/ed25519/edwards25519"
-	"github.com/decred/dcrd/dcrec/edwards/v2"
 
 	"github.com/bnb-chain/tss-lib/v2/tss"
 )
@@ -42,16 +41,21 @@ func (round *finalization) Start() *tss.Error {
 	// save the signature for final output
 	round.data.Signature = append(bigIntToEncodedBytes(round.temp.r)[:], sumS[:]...)
 	round.data.R = round.temp.r.Bytes()
-	round.data.S = s.Bytes()
-	round.data.M = round.temp.m.Bytes()
-
-	pk := edwards.PublicKey{
-		Curve: round.Params().EC(),
-		X:     round.key.EDDSAPub.X(),
-		Y:     round.key.EDDSAPub.Y(),
+	if round.temp.opts.Variant == Sr25519 {
+		sig, err := sr25519Signature(bigIntToEncodedBytes(round.temp.r), sumS)
+		if err != nil {
+			return round.WrapError(err)
+		}
+		var encodedR [32]byte
+		copy(encodedR[:], sig[:32])
+		round.data.Signature = sig
+		round.data.R = encodedBytesToBigInt(&encodedR).Bytes()
 	}
+	round.data.S = s.Bytes()
+	round.data.M = round.temp.m
 
-	ok := edwards.Verify(&pk, round.temp.m.Bytes(), round.temp.r, s)
+	encodedPubKey := ecPointToEncodedBytes(round.key.EDDSAPub.X(), round.key.EDDSAPub.Y())
+	ok := round.temp.opts.verify(encodedPubKey, round.temp.m, round.data.Signature)
 	if !ok {
 		return round.WrapError(fmt.Errorf("signature verification failed"))
 	}
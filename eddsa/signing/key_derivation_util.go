@@ -0,0 +1,61 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/ckd"
+	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// UpdatePublicKeyAndAdjustBigXj sets the derived child key as EDDSAPub of `keys` and adds the key derivation delta to
+// their BigXj, so that they can be used with NewLocalPartyWithKDD. Derive the child key with
+// ckd.DeriveEd25519ChildKeyFromHierarchy.
+func UpdatePublicKeyAndAdjustBigXj(keyDerivationDelta *big.Int, keys []keygen.LocalPartySaveData, extendedChildPk *ecdsa.PublicKey, ec elliptic.Curve) error {
+	var err error
+	gDelta := crypto.ScalarBaseMult(ec, keyDerivationDelta)
+	for k := range keys {
+		keys[k].EDDSAPub, err = crypto.NewECPoint(ec, extendedChildPk.X, extendedChildPk.Y)
+		if err != nil {
+			common.Logger.Errorf("error creating new extended child public key")
+			return err
+		}
+		// Suppose X_j has shamir shares X_j0,     X_j1,     ..., X_jn
+		// So X_j + D has shamir shares  X_j0 + D, X_j1 + D, ..., X_jn + D
+		for j := range keys[k].BigXj {
+			keys[k].BigXj[j], err = keys[k].BigXj[j].Add(gDelta)
+			if err != nil {
+				common.Logger.Errorf("error in delta operation")
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// DerivingPubkeyFromPath derives the child key at `path` from the master public key of a threshold key and its chain
+// code. It returns the key derivation delta to give to NewLocalPartyWithKDD and the child key, which is serialized with
+// the version bytes of `net`, e.g. &chaincfg.TestNet3Params; the delta is the same on every network.
+func DerivingPubkeyFromPath(masterPub *crypto.ECPoint, chainCode []byte, path []uint32, net *chaincfg.Params) (*big.Int, *ckd.ExtendedKey, error) {
+	version, err := ckd.PublicKeyVersion(net, ckd.FormatLegacy)
+	if err != nil {
+		return nil, nil, err
+	}
+	extendedParentPk, err := ckd.NewExtendedPublicKey(masterPub, chainCode, version)
+	if err != nil {
+		return nil, nil, err
+	}
+	ec := masterPub.Curve()
+	return ckd.DeriveEd25519ChildKeyFromHierarchy(path, extendedParentPk, ec.Params().N, ec)
+}
@@ -7,6 +7,7 @@
 package signing
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math/big"
@@ -47,9 +48,11 @@ type (
 		localMessageStore
 
 		// temp data (thrown away after sign) / round 1
+		m    []byte
+		opts Options
 		wi,
-		m,
-		ri *big.Int
+		ri,
+		keyDerivationDelta *big.Int
 		pointRi  *crypto.ECPoint
 		deCommit cmt.HashDeCommitment
 
@@ -65,12 +68,65 @@ type (
 	}
 )
 
+// NewLocalParty returns a party that signs the big-endian bytes of `msg`. Leading zero bytes cannot be represented in a
+// *big.Int, so use NewLocalPartyFromBytes to sign messages that may start with 0x00, e.g. serialized transactions.
 func NewLocalParty(
 	msg *big.Int,
 	params *tss.Parameters,
 	key keygen.LocalPartySaveData,
 	out chan<- tss.Message,
 	end chan<- *common.SignatureData,
+) tss.Party {
+	return NewLocalPartyWithKDD(msg, params, key, nil, out, end)
+}
+
+// NewLocalPartyWithKDD returns a party with key derivation delta for HD support
+func NewLocalPartyWithKDD(
+	msg *big.Int,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	keyDerivationDelta *big.Int,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+) tss.Party {
+	return NewLocalPartyFromBytesWithKDD(msg.Bytes(), params, key, keyDerivationDelta, out, end)
+}
+
+// NewLocalPartyFromBytes returns a party that signs `msg` exactly as given, as in RFC 8032: the message of any length is
+// hashed into the challenge as is, and SignatureData.M holds the same bytes.
+func NewLocalPartyFromBytes(
+	msg []byte,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+) tss.Party {
+	return NewLocalPartyFromBytesWithKDD(msg, params, key, nil, out, end)
+}
+
+// NewLocalPartyFromBytesWithKDD returns a party that signs `msg` exactly as given, with key derivation delta for HD support
+func NewLocalPartyFromBytesWithKDD(
+	msg []byte,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	keyDerivationDelta *big.Int,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+) tss.Party {
+	return NewLocalPartyWithOptions(msg, params, key, keyDerivationDelta, Options{}, out, end)
+}
+
+// NewLocalPartyWithOptions returns a party that signs `msg` with the scheme selected in `opts`, e.g. Ed25519ctx with a
+// context string, Ed25519ph or Sr25519. `msg` is the message itself, also for Ed25519ph: the parties hash it with SHA-512.
+// All the parties must use the same options. `keyDerivationDelta` may be nil.
+func NewLocalPartyWithOptions(
+	msg []byte,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	keyDerivationDelta *big.Int,
+	opts Options,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
 ) tss.Party {
 	partyCount := len(params.Parties().IDs())
 	p := &LocalParty{
@@ -88,7 +144,9 @@ func NewLocalParty(
 	p.temp.signRound3Messages = make([]tss.ParsedMessage, partyCount)
 
 	// temp data init
-	p.temp.m = msg
+	p.temp.m = append([]byte{}, msg...)
+	p.temp.opts = Options{Variant: opts.Variant, Context: append([]byte{}, opts.Context...)}
+	p.temp.keyDerivationDelta = keyDerivationDelta
 	p.temp.cjs = make([]*big.Int, partyCount)
 	return p
 }
@@ -98,16 +156,22 @@ func (p *LocalParty) FirstRound() tss.Round {
 }
 
 func (p *LocalParty) Start() *tss.Error {
-	return tss.BaseStart(p, TaskName, func(round tss.Round) *tss.Error {
-		round1, ok := round.(*round1)
-		if !ok {
-			return round.WrapError(errors.New("unable to Start(). party is in an unexpected round"))
-		}
-		if err := round1.prepare(); err != nil {
-			return round.WrapError(err)
-		}
-		return nil
-	})
+	return tss.BaseStart(p, TaskName, p.prepare)
+}
+
+func (p *LocalParty) StartWithContext(ctx context.Context, errCh chan<- *tss.Error) *tss.Error {
+	return tss.BaseStartWithContext(ctx, p, TaskName, errCh, p.prepare)
+}
+
+func (p *LocalParty) prepare(round tss.Round) *tss.Error {
+	round1, ok := round.(*round1)
+	if !ok {
+		return round.WrapError(errors.New("unable to Start(). party is in an unexpected round"))
+	}
+	if err := round1.prepare(); err != nil {
+		return round.WrapError(err)
+	}
+	return nil
 }
 
 func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
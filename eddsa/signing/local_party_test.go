@@ -7,17 +7,27 @@
 package signing
 
 import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"math/big"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/agl/ed25519/edwards25519"
+	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/decred/dcrd/dcrec/edwards/v2"
 	"github.com/ipfs/go-log"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto/ristretto255"
 	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
 	"github.com/bnb-chain/tss-lib/v2/test"
 	"github.com/bnb-chain/tss-lib/v2/tss"
@@ -142,3 +152,603 @@ signing:
 		}
 	}
 }
+
+func TestE2EMessageBytes(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	// a message that starts with zero bytes, like a serialized Solana transaction, and is longer than a scalar
+	msg := make([]byte, 100)
+	_, err = rand.Read(msg[2:])
+	assert.NoError(t, err)
+
+	sigs := signConcurrently(t, signPIDs, func(params *tss.Parameters, i int, outCh chan tss.Message, endCh chan *common.SignatureData) *LocalParty {
+		return NewLocalPartyFromBytes(msg, params, keys[i], outCh, endCh).(*LocalParty)
+	})
+
+	// the signature is a standard Ed25519 signature of the exact message bytes
+	pk := ecPointToEncodedBytes(keys[0].EDDSAPub.X(), keys[0].EDDSAPub.Y())
+	for _, sig := range sigs {
+		assert.Equal(t, msg, sig.M)
+		assert.True(t, ed25519.Verify(pk[:], msg, sig.Signature), "ed25519 verify must pass")
+	}
+	assert.False(t, ed25519.Verify(pk[:], msg[2:], sigs[0].Signature), "the leading zero bytes must be signed")
+}
+
+func TestE2EVariants(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	pk := ecPointToEncodedBytes(keys[0].EDDSAPub.X(), keys[0].EDDSAPub.Y())
+	msg := []byte("a message for a partner protocol")
+
+	for _, tc := range []struct {
+		opts    Options
+		stdOpts ed25519.Options
+	}{
+		{Options{Variant: Ed25519ctx, Context: []byte("ctx")}, ed25519.Options{Context: "ctx"}},
+		{Options{Variant: Ed25519ph}, ed25519.Options{Hash: crypto.SHA512}},
+		{Options{Variant: Ed25519ph, Context: []byte("ctx")}, ed25519.Options{Hash: crypto.SHA512, Context: "ctx"}},
+	} {
+		opts := tc.opts
+		sigs := signConcurrently(t, signPIDs, func(params *tss.Parameters, i int, outCh chan tss.Message, endCh chan *common.SignatureData) *LocalParty {
+			return NewLocalPartyWithOptions(msg, params, keys[i], nil, opts, outCh, endCh).(*LocalParty)
+		})
+
+		// Ed25519ph is verified against the SHA-512 hash of the message
+		stdMsg := msg
+		if opts.Variant == Ed25519ph {
+			ph := sha512.Sum512(msg)
+			stdMsg = ph[:]
+		}
+		for _, sig := range sigs {
+			assert.Equal(t, msg, sig.M)
+			assert.NoError(t, ed25519.VerifyWithOptions(pk[:], stdMsg, sig.Signature, &tc.stdOpts), "%s verify must pass", opts.Variant)
+			assert.False(t, ed25519.Verify(pk[:], msg, sig.Signature), "%s must not verify as pure Ed25519", opts.Variant)
+		}
+	}
+
+	// invalid options are rejected before round 1
+	for _, opts := range []Options{
+		{Variant: Ed25519, Context: []byte("ctx")},
+		{Variant: Ed25519ctx},
+		{Variant: Ed25519ph, Context: make([]byte, MaxContextLength+1)},
+		{Variant: Variant(4)},
+	} {
+		params := tss.NewParameters(tss.Edwards(), tss.NewPeerContext(signPIDs), signPIDs[0], len(signPIDs), testThreshold)
+		P := NewLocalPartyWithOptions(msg, params, keys[0], nil, opts, make(chan tss.Message, len(signPIDs)), nil)
+		assert.Error(t, P.Start(), "%s with a %d-byte context should be rejected", opts.Variant, len(opts.Context))
+	}
+}
+
+func TestE2ESr25519(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	pk := ecPointToEncodedBytes(keys[0].EDDSAPub.X(), keys[0].EDDSAPub.Y())
+	msg := []byte("a polkadot extrinsic payload")
+	opts := Options{Variant: Sr25519, Context: []byte("substrate")}
+
+	sigs := signConcurrently(t, signPIDs, func(params *tss.Parameters, i int, outCh chan tss.Message, endCh chan *common.SignatureData) *LocalParty {
+		return NewLocalPartyWithOptions(msg, params, keys[i], nil, opts, outCh, endCh).(*LocalParty)
+	})
+	for _, sig := range sigs {
+		assert.Len(t, sig.Signature, 64)
+		assert.NotZero(t, sig.Signature[63]&sr25519Marker, "the sr25519 marker must be set")
+		assert.True(t, opts.Verify(keys[0].EDDSAPub, msg, sig.Signature), "sr25519 verify must pass")
+		assert.False(t, ed25519.Verify(pk[:], msg, sig.Signature), "sr25519 must not verify as Ed25519")
+		assert.False(t, Options{Variant: Sr25519, Context: []byte("other")}.Verify(keys[0].EDDSAPub, msg, sig.Signature))
+		assert.False(t, opts.Verify(keys[0].EDDSAPub, []byte("another payload"), sig.Signature))
+
+		unmarked := append([]byte{}, sig.Signature...)
+		unmarked[63] &^= sr25519Marker
+		assert.False(t, opts.Verify(keys[0].EDDSAPub, msg, unmarked), "a signature without the marker must be rejected")
+		nonCanonical := append([]byte{}, sig.Signature...)
+		nonCanonical[63] |= 0x70
+		assert.False(t, opts.Verify(keys[0].EDDSAPub, msg, nonCanonical), "S >= L must be rejected")
+	}
+}
+
+func TestSr25519KnownAnswer(t *testing.T) {
+	// a schnorrkel signature in the "substrate" signing context, from the test vectors of sr25519-crust
+	// (https://github.com/Warchant/sr25519-crust/blob/master/test/ds.cpp) that are also used by go-schnorrkel
+	pkBytes, _ := hex.DecodeString("46ebddef8cd9bb167dc30878d7113b7e168e6f0646beffd77d69d39bad76b47a")
+	sig, _ := hex.DecodeString("4e172314444b8f820bb54c22e95076f220ed25373e5c178234aa6c211d29271244b947e3ff3418ff6b45fd1df1140c8cbff69fc58ee6dc96df70936a2bb74b82")
+	msg := []byte("this is a message")
+	pub, err := ristretto255.Decode(pkBytes)
+	assert.NoError(t, err)
+	opts := Options{Variant: Sr25519, Context: []byte("substrate")}
+
+	assert.True(t, opts.Verify(pub, msg, sig), "the schnorrkel signature must verify")
+	assert.False(t, opts.Verify(pub, []byte("this is another message"), sig))
+	assert.False(t, Options{Variant: Sr25519, Context: []byte("polkadot")}.Verify(pub, msg, sig))
+}
+
+func TestSr25519ChallengeInvalidPoint(t *testing.T) {
+	setUp("info")
+
+	keys, _, err := keygen.LoadKeygenTestFixtures(1)
+	assert.NoError(t, err, "should load keygen fixtures")
+	pk := ecPointToEncodedBytes(keys[0].EDDSAPub.X(), keys[0].EDDSAPub.Y())
+	// there is no point on edwards25519 with y = 2
+	invalidR := [32]byte{2}
+	_, err = Options{Variant: Sr25519, Context: []byte("substrate")}.challenge(&invalidR, pk, []byte("msg"))
+	assert.Error(t, err, "the challenge of an invalid point must fail")
+}
+
+// signConcurrently runs a signing session with the parties created by newParty and returns the signature data of each
+func signConcurrently(
+	t *testing.T,
+	signPIDs tss.SortedPartyIDs,
+	newParty func(params *tss.Parameters, i int, outCh chan tss.Message, endCh chan *common.SignatureData) *LocalParty,
+) []*common.SignatureData {
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		P := newParty(params, i, outCh, endCh)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	sigs := make([]*common.SignatureData, 0, len(signPIDs))
+	for len(sigs) < len(signPIDs) {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go test.SharedPartyUpdater(P, msg, errCh)
+				}
+			} else {
+				go test.SharedPartyUpdater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case sig := <-endCh:
+			sigs = append(sigs, sig)
+		}
+	}
+	return sigs
+}
+
+func TestE2EWithHDKeyDerivation(t *testing.T) {
+	setUp("info")
+
+	// PHASE: load keygen fixtures
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	chainCode := make([]byte, 32)
+	_, err = rand.Read(chainCode)
+	assert.NoError(t, err)
+
+	keyDerivationDelta, extendedChildPk, err := DerivingPubkeyFromPath(keys[0].EDDSAPub, chainCode, []uint32{12, 209, 3}, &chaincfg.MainNetParams)
+	assert.NoErrorf(t, err, "there should not be an error deriving the child public key")
+
+	err = UpdatePublicKeyAndAdjustBigXj(keyDerivationDelta, keys, &extendedChildPk.PublicKey, tss.Edwards())
+	assert.NoErrorf(t, err, "there should not be an error setting the derived keys")
+
+	// PHASE: signing
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+
+	updater := test.SharedPartyUpdater
+
+	msg := big.NewInt(200)
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+
+		P := NewLocalPartyWithKDD(msg, params, keys[i], keyDerivationDelta, outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	var ended int32
+signing:
+	for {
+		select {
+		case err := <-errCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+			break signing
+
+		case msg := <-outCh:
+			for _, P := range parties {
+				if P.PartyID().Index == msg.GetFrom().Index {
+					continue
+				}
+				go updater(P, msg, errCh)
+			}
+
+		case data := <-endCh:
+			atomic.AddInt32(&ended, 1)
+			if atomic.LoadInt32(&ended) == int32(len(signPIDs)) {
+				// BEGIN EDDSA verify with the child key
+				pk := edwards.PublicKey{
+					Curve: tss.Edwards(),
+					X:     extendedChildPk.X,
+					Y:     extendedChildPk.Y,
+				}
+				newSig, err := edwards.ParseSignature(data.Signature)
+				if !assert.NoError(t, err) {
+					break signing
+				}
+				ok := edwards.Verify(&pk, msg.Bytes(), newSig.R, newSig.S)
+				assert.True(t, ok, "eddsa verify must pass")
+				// END EDDSA verify
+
+				break signing
+			}
+		}
+	}
+}
+
+func TestE2ERoundTimeout(t *testing.T) {
+	setUp("info")
+
+	threshold := testThreshold
+
+	// PHASE: load keygen fixtures
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	// PHASE: signing
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs)*len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		params.SetRoundTimeout(500 * time.Millisecond)
+		P := NewLocalParty(big.NewInt(42), params, keys[i], outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+	}
+	for _, P := range parties {
+		if err := P.StartWithContext(context.Background(), errCh); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+	}
+
+	// the messages of the silent party are never delivered, so everyone else times out waiting for it in round 1
+	silent := parties[len(parties)-1]
+	aborted := make(map[int]*tss.Error, len(parties))
+	for len(aborted) < len(parties) {
+		select {
+		case msg := <-outCh:
+			if msg.GetFrom().Index == silent.PartyID().Index {
+				continue
+			}
+			for _, P := range parties {
+				if P.PartyID().Index != msg.GetFrom().Index {
+					test.SharedPartyUpdater(P, msg, errCh)
+				}
+			}
+		case err := <-errCh:
+			assert.True(t, errors.Is(err, tss.ErrRoundTimeout), "expected a round timeout, got: %s", err)
+			aborted[err.Victim().Index] = err
+		case <-endCh:
+			assert.FailNow(t, "signing should not have finished")
+		}
+	}
+	for _, P := range parties {
+		err := aborted[P.PartyID().Index]
+		assert.False(t, P.Running())
+		if P == silent {
+			// the silent party received everyone's round 1 messages and then waited in round 2
+			assert.Equal(t, 2, err.Round())
+			assert.Equal(t, len(parties)-1, len(err.Culprits()))
+			continue
+		}
+		assert.Equal(t, 1, err.Round())
+		assert.Equal(t, []*tss.PartyID{silent.PartyID()}, err.Culprits())
+	}
+}
+
+func TestRejectedMessageKeepsRoundTimeout(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	params := tss.NewParameters(tss.Edwards(), tss.NewPeerContext(signPIDs), signPIDs[0], len(signPIDs), testThreshold)
+	params.SetRoundTimeout(200 * time.Millisecond)
+	P := NewLocalParty(big.NewInt(42), params, keys[0], make(chan tss.Message, len(signPIDs)), nil)
+	if err := P.StartWithContext(context.Background(), errCh); err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	// a message that fails ValidateBasic is reported to the caller, and the party keeps waiting for its peers
+	bad := NewSignRound1Message(signPIDs[1], big.NewInt(0))
+	_, updateErr := P.Update(bad)
+	assert.NotNil(t, updateErr)
+	assert.True(t, P.Running())
+
+	// so the deadline of the round still fires
+	select {
+	case err := <-errCh:
+		assert.True(t, errors.Is(err, tss.ErrRoundTimeout), "expected a round timeout, got: %s", err)
+		assert.Equal(t, signPIDs[1:], tss.SortedPartyIDs(err.Culprits()))
+	case <-time.After(5 * time.Second):
+		assert.FailNow(t, "the round should have timed out")
+	}
+	assert.False(t, P.Running())
+}
+
+func TestE2EContextCancel(t *testing.T) {
+	setUp("info")
+
+	threshold := testThreshold
+
+	// PHASE: load keygen fixtures
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	// PHASE: signing
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs)*len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[0], len(signPIDs), threshold)
+	P := NewLocalParty(big.NewInt(42), params, keys[0], outCh, endCh)
+	if err := P.StartWithContext(ctx, errCh); err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	assert.True(t, P.Running())
+
+	// nobody else is running, so the party is waiting for all of its peers when the context is cancelled
+	cancel()
+	abortErr := <-errCh
+	assert.True(t, errors.Is(abortErr, context.Canceled), "expected the context error, got: %s", abortErr)
+	assert.Equal(t, signPIDs[1:], tss.SortedPartyIDs(abortErr.Culprits()))
+	assert.False(t, P.Running())
+
+	// any later message is rejected with the abort error
+	msg := <-outCh
+	_, updateErr := P.Update(msg.(tss.ParsedMessage))
+	assert.Equal(t, abortErr, updateErr)
+
+	// a party cannot be started with a context that is already done
+	P2 := NewLocalParty(big.NewInt(42), params, keys[0], outCh, endCh)
+	assert.Error(t, P2.StartWithContext(ctx, errCh))
+}
+
+func TestE2ESnapshotAndRestore(t *testing.T) {
+	setUp("info")
+
+	threshold := testThreshold
+
+	// PHASE: load keygen fixtures
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	// PHASE: signing
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	params := make([]*tss.Parameters, 0, len(signPIDs))
+	parties := make([]tss.Party, 0, len(signPIDs))
+
+	// messages are routed synchronously below, so the channels must be able to hold everything that is produced
+	errCh := make(chan *tss.Error, len(signPIDs)*len(signPIDs)*4)
+	outCh := make(chan tss.Message, len(signPIDs)*len(signPIDs)*4)
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+
+	key := make([]byte, tss.SnapshotKeyLength)
+	_, err = rand.Read(key)
+	assert.NoError(t, err)
+
+	msg := big.NewInt(200)
+	for i := 0; i < len(signPIDs); i++ {
+		params = append(params, tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[i], len(signPIDs), threshold))
+		P := NewLocalParty(msg, params[i], keys[i], outCh, endCh)
+		parties = append(parties, P)
+		if err := P.Start(); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+	}
+
+	// snapshot and restore every party regularly, so that the state of each round goes through a snapshot
+	routed := 0
+	sigs := make([]*common.SignatureData, 0, len(signPIDs))
+	for len(sigs) < len(signPIDs) {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case m := <-outCh:
+			if dest := m.GetTo(); dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index != m.GetFrom().Index {
+						test.SharedPartyUpdater(P, m, errCh)
+					}
+				}
+			} else {
+				test.SharedPartyUpdater(parties[dest[0].Index], m, errCh)
+			}
+			if routed++; routed%3 != 0 {
+				continue
+			}
+			for i, P := range parties {
+				if !P.Running() {
+					continue // finished
+				}
+				snapshot, err := P.Snapshot(key)
+				if err != nil {
+					assert.FailNow(t, err.Error())
+				}
+				badKey := append([]byte{key[0] + 1}, key[1:]...)
+				_, err = RestoreLocalParty(params[i], snapshot, badKey, outCh, endCh)
+				assert.Error(t, err, "restoring with the wrong key should fail")
+
+				restored, err := RestoreLocalParty(params[i], snapshot, key, outCh, endCh)
+				if err != nil {
+					assert.FailNow(t, err.Error())
+				}
+				assert.Equal(t, P.WaitingFor(), restored.WaitingFor())
+				assert.Error(t, restored.Start(), "a restored party should not start again")
+				parties[i] = restored
+			}
+		case sig := <-endCh:
+			sigs = append(sigs, sig)
+		}
+	}
+
+	pk := edwards.PublicKey{
+		Curve: tss.Edwards(),
+		X:     keys[0].EDDSAPub.X(),
+		Y:     keys[0].EDDSAPub.Y(),
+	}
+	for _, sig := range sigs {
+		assert.Equal(t, sigs[0].Signature, sig.Signature)
+		newSig, err := edwards.ParseSignature(sig.Signature)
+		assert.NoError(t, err)
+		assert.True(t, edwards.Verify(&pk, msg.Bytes(), newSig.R, newSig.S), "eddsa verify must pass")
+	}
+}
+
+func TestResumeWithContext(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	params := tss.NewParameters(tss.Edwards(), tss.NewPeerContext(signPIDs), signPIDs[0], len(signPIDs), testThreshold)
+	P := NewLocalParty(big.NewInt(42), params, keys[0], outCh, nil)
+	if err := P.Start(); err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	// only a restored party can be resumed
+	assert.Error(t, P.ResumeWithContext(context.Background(), errCh))
+
+	key := make([]byte, tss.SnapshotKeyLength)
+	_, err = rand.Read(key)
+	assert.NoError(t, err)
+	snapshot, tErr := P.Snapshot(key)
+	if tErr != nil {
+		assert.FailNow(t, tErr.Error())
+	}
+	restored, tErr := RestoreLocalParty(params, snapshot, key, outCh, nil)
+	if tErr != nil {
+		assert.FailNow(t, tErr.Error())
+	}
+
+	// a party cannot be resumed with a context that is already done
+	done, cancelDone := context.WithCancel(context.Background())
+	cancelDone()
+	assert.Error(t, restored.ResumeWithContext(done, errCh))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := restored.ResumeWithContext(ctx, errCh); err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	assert.Error(t, restored.ResumeWithContext(ctx, errCh), "a party should not be resumed twice")
+
+	// nobody else is running, so the restored party is waiting for all of its peers when the context is cancelled
+	cancel()
+	abortErr := <-errCh
+	assert.True(t, errors.Is(abortErr, context.Canceled), "expected the context error, got: %s", abortErr)
+	assert.Equal(t, signPIDs[1:], tss.SortedPartyIDs(abortErr.Culprits()))
+	assert.False(t, restored.Running())
+}
+
+func TestE2EStepParties(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*StepParty, 0, len(signPIDs))
+	sigs := make([]*common.SignatureData, len(signPIDs))
+
+	// messages are delivered one at a time in FIFO order, so the run is fully deterministic
+	var queue []tss.Message
+	msg := big.NewInt(200)
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		P := NewStepParty(msg, params, keys[i])
+		parties = append(parties, P)
+		msgs, sig, err := P.Start()
+		if !assert.Nil(t, err, "should start") {
+			return
+		}
+		assert.Nil(t, sig)
+		queue = append(queue, msgs...)
+	}
+
+	deliver := func(P *StepParty, m tss.Message) {
+		bz, _, err := m.WireBytes()
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		msgs, sig, tErr := P.UpdateFromBytes(bz, m.GetFrom(), m.IsBroadcast())
+		if !assert.Nil(t, tErr, "should update") {
+			t.FailNow()
+		}
+		queue = append(queue, msgs...)
+		if sig != nil {
+			sigs[P.Party().PartyID().Index] = sig
+		}
+	}
+	for 0 < len(queue) {
+		m := queue[0]
+		queue = queue[1:]
+		if dest := m.GetTo(); dest != nil {
+			deliver(parties[dest[0].Index], m)
+			continue
+		}
+		for _, P := range parties {
+			if P.Party().PartyID().Index != m.GetFrom().Index {
+				deliver(P, m)
+			}
+		}
+	}
+
+	pk := edwards.PublicKey{
+		Curve: tss.Edwards(),
+		X:     keys[0].EDDSAPub.X(),
+		Y:     keys[0].EDDSAPub.Y(),
+	}
+	for i, sig := range sigs {
+		if !assert.NotNil(t, sig, "party %d should have finished", i) {
+			continue
+		}
+		assert.False(t, parties[i].Party().Running())
+		assert.Equal(t, sigs[0].Signature, sig.Signature)
+		newSig, err := edwards.ParseSignature(sig.Signature)
+		assert.NoError(t, err)
+		assert.True(t, edwards.Verify(&pk, msg.Bytes(), newSig.R, newSig.S), "eddsa verify must pass")
+	}
+}
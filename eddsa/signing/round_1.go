@@ -95,9 +95,22 @@ func (round *round1) NextRound() tss.Round {
 func (round *round1) prepare() error {
 	i := round.PartyID().Index
 
+	if err := round.temp.opts.validate(); err != nil {
+		return err
+	}
+
 	xi := round.key.Xi
 	ks := round.key.Ks
 
+	if round.temp.keyDerivationDelta != nil {
+		// adding the key derivation delta to the xi's
+		// Suppose x has shamir shares x_0,     x_1,     ..., x_n
+		// So x + D has shamir shares  x_0 + D, x_1 + D, ..., x_n + D
+		mod := common.ModInt(round.Params().EC().Params().N)
+		xi = mod.Add(round.temp.keyDerivationDelta, xi)
+		round.key.Xi = xi
+	}
+
 	if round.Threshold()+1 > len(ks) {
 		return fmt.Errorf("t+1=%d is not satisfied by the key count of %d", round.Threshold()+1, len(ks))
 	}
@@ -7,7 +7,6 @@
 package signing
 
 import (
-	"crypto/sha512"
 	"math/big"
 
 	"github.com/agl/ed25519/edwards25519"
@@ -75,21 +74,15 @@ func (round *round3) Start() *tss.Error {
 	R.ToBytes(&encodedR)
 	encodedPubKey := ecPointToEncodedBytes(round.key.EDDSAPub.X(), round.key.EDDSAPub.Y())
 
-	// h = hash512(k || A || M)
-	h := sha512.New()
-	h.Reset()
-	h.Write(encodedR[:])
-	h.Write(encodedPubKey[:])
-	h.Write(round.temp.m.Bytes())
-
-	var lambda [64]byte
-	h.Sum(lambda[:0])
-	var lambdaReduced [32]byte
-	edwards25519.ScReduce(&lambdaReduced, &lambda)
+	// h = hash512(dom2(F, C) || k || A || PH(M))
+	lambdaReduced, err := round.temp.opts.challenge(&encodedR, encodedPubKey, round.temp.m)
+	if err != nil {
+		return round.WrapError(err)
+	}
 
 	// 8. compute si
 	var localS [32]byte
-	edwards25519.ScMulAdd(&localS, &lambdaReduced, bigIntToEncodedBytes(round.temp.wi), riBytes)
+	edwards25519.ScMulAdd(&localS, lambdaReduced, bigIntToEncodedBytes(round.temp.wi), riBytes)
 
 	// 9. store r3 message pieces
 	round.temp.si = &localS
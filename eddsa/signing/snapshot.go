@@ -0,0 +1,172 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	cmt "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+type snapshotState struct {
+	Keys     keygen.LocalPartySaveData
+	Data     *common.SignatureData
+	OK       []bool
+	Messages [][]*tss.SnapshotMessage
+
+	M                  []byte
+	Opts               Options
+	Wi, Ri             *big.Int
+	KeyDerivationDelta *big.Int
+	PointRi            *crypto.ECPoint
+	DeCommit           cmt.HashDeCommitment
+
+	Cjs []*big.Int
+	Si  *[32]byte
+
+	R *big.Int
+
+	SSID      []byte
+	SSIDNonce *big.Int
+}
+
+// Snapshot exports the in-flight state of this party, encrypted with a key of tss.SnapshotKeyLength bytes.
+// The snapshot contains secret key material and should be stored with the same care as the key share itself.
+func (p *LocalParty) Snapshot(key []byte) ([]byte, *tss.Error) {
+	return tss.BaseSnapshot(p, TaskName, key, p.snapshotState)
+}
+
+// RestoreLocalParty rebuilds a party from a snapshot taken with Snapshot(). The party continues from the round it was in,
+// so it must not be started again; feed it the messages it has not yet received through Update() or UpdateFromBytes().
+// `params` must describe the same party and peers as the ones used to construct the original party.
+func RestoreLocalParty(
+	params *tss.Parameters,
+	snapshot, key []byte,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+) (tss.Party, *tss.Error) {
+	p := &LocalParty{
+		BaseParty: new(tss.BaseParty),
+		params:    params,
+		temp:      localTempData{},
+		data:      &common.SignatureData{},
+		out:       out,
+		end:       end,
+	}
+	if err := tss.BaseRestore(p, TaskName, snapshot, key, p.restoreState); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *LocalParty) messageStores() []*[]tss.ParsedMessage {
+	return []*[]tss.ParsedMessage{
+		&p.temp.signRound1Messages,
+		&p.temp.signRound2Messages,
+		&p.temp.signRound3Messages,
+	}
+}
+
+func (p *LocalParty) snapshotState(rnd tss.Round) (interface{}, error) {
+	round, err := roundBase(rnd)
+	if err != nil {
+		return nil, err
+	}
+	state := &snapshotState{
+		Keys:               p.keys,
+		Data:               p.data,
+		OK:                 round.ok,
+		Wi:                 p.temp.wi,
+		M:                  p.temp.m,
+		Opts:               p.temp.opts,
+		Ri:                 p.temp.ri,
+		KeyDerivationDelta: p.temp.keyDerivationDelta,
+		PointRi:            p.temp.pointRi,
+		DeCommit:           p.temp.deCommit,
+		Cjs:                p.temp.cjs,
+		Si:                 p.temp.si,
+		R:                  p.temp.r,
+		SSID:               p.temp.ssid,
+		SSIDNonce:          p.temp.ssidNonce,
+	}
+	for _, store := range p.messageStores() {
+		msgs, err := tss.NewSnapshotMessages(*store)
+		if err != nil {
+			return nil, err
+		}
+		state.Messages = append(state.Messages, msgs)
+	}
+	return state, nil
+}
+
+func (p *LocalParty) restoreState(stateBz []byte, number int) (tss.Round, error) {
+	state := new(snapshotState)
+	if err := json.Unmarshal(stateBz, state); err != nil {
+		return nil, err
+	}
+	partyCount := len(p.params.Parties().IDs())
+	stores := p.messageStores()
+	if len(state.OK) != partyCount || len(state.Messages) != len(stores) {
+		return nil, errors.New("the snapshot does not match the parties in params")
+	}
+	for k, store := range stores {
+		msgs, err := tss.ParseSnapshotMessages(state.Messages[k], partyCount)
+		if err != nil {
+			return nil, err
+		}
+		*store = msgs
+	}
+	p.keys = state.Keys
+	if state.Data != nil {
+		p.data = state.Data
+	}
+	p.temp.wi = state.Wi
+	p.temp.m = state.M
+	p.temp.opts = state.Opts
+	p.temp.ri = state.Ri
+	p.temp.keyDerivationDelta = state.KeyDerivationDelta
+	p.temp.pointRi = state.PointRi
+	p.temp.deCommit = state.DeCommit
+	p.temp.cjs = state.Cjs
+	p.temp.si = state.Si
+	p.temp.r = state.R
+	p.temp.ssid = state.SSID
+	p.temp.ssidNonce = state.SSIDNonce
+
+	r1 := newRound1(p.params, &p.keys, p.data, &p.temp, p.out, p.end).(*round1)
+	copy(r1.ok, state.OK)
+	r1.started = true
+	r1.number = number
+	r2 := &round2{r1}
+	r3 := &round3{r2}
+	rounds := []tss.Round{r1, r2, r3, &finalization{r3}}
+	if number < 1 || len(rounds) < number {
+		return nil, fmt.Errorf("the snapshot has an unexpected round number %d", number)
+	}
+	return rounds[number-1], nil
+}
+
+func roundBase(rnd tss.Round) (*base, error) {
+	switch round := rnd.(type) {
+	case *round1:
+		return round.base, nil
+	case *round2:
+		return round.base, nil
+	case *round3:
+		return round.base, nil
+	case *finalization:
+		return round.base, nil
+	}
+	return nil, fmt.Errorf("unexpected round type %T", rnd)
+}
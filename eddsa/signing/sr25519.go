@@ -0,0 +1,88 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+
+	"github.com/ChainSafe/go-schnorrkel"
+	"github.com/agl/ed25519/edwards25519"
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/ristretto255"
+)
+
+// sr25519Marker is set in the last byte of an sr25519 signature to tell it apart from an Ed25519 signature
+const sr25519Marker = 0x80
+
+// sr25519Challenge returns the challenge k of schnorrkel for the ristretto255 encodings of R and A, reduced mod L:
+// the "sign:c" challenge of the Merlin transcript of the signing context, the message, A and R
+func sr25519Challenge(context, encodedR, encodedPubKey, msg []byte) *[32]byte {
+	t := schnorrkel.NewSigningContext(context, msg)
+	t.AppendMessage([]byte("proto-name"), []byte("Schnorr-sig"))
+	t.AppendMessage([]byte("sign:pk"), encodedPubKey)
+	t.AppendMessage([]byte("sign:R"), encodedR)
+
+	var k [64]byte
+	copy(k[:], t.ExtractBytes([]byte("sign:c"), 64))
+	var kReduced [32]byte
+	edwards25519.ScReduce(&kReduced, &k)
+	return &kReduced
+}
+
+// sr25519Signature converts the signature R || S computed by the rounds, with R encoded as an Ed25519 point, to the
+// encoding of schnorrkel: R encoded in ristretto255 || S, with the sr25519 marker set
+func sr25519Signature(encodedR, s *[32]byte) ([]byte, error) {
+	R, err := edwardsToRistretto(encodedR)
+	if err != nil {
+		return nil, err
+	}
+	sig := append(R, s[:]...)
+	sig[63] |= sr25519Marker
+	return sig, nil
+}
+
+// sr25519Verify checks the schnorrkel signature R || S of `msg` by `pub` in the signing context `context` with
+// go-schnorrkel, the public key being encoded in ristretto255
+func sr25519Verify(pub *crypto.ECPoint, context, msg, sig []byte) bool {
+	if len(sig) != schnorrkel.SignatureSize {
+		return false
+	}
+	encodedPubKey, err := ristretto255.Encode(pub)
+	if err != nil {
+		return false
+	}
+	var pkBytes [schnorrkel.PublicKeySize]byte
+	copy(pkBytes[:], encodedPubKey)
+	pk, err := schnorrkel.NewPublicKey(pkBytes)
+	if err != nil {
+		return false
+	}
+	var sigBytes [schnorrkel.SignatureSize]byte
+	copy(sigBytes[:], sig)
+	// Decode rejects a signature without the marker, an invalid R and an S that is not reduced mod L
+	signature := new(schnorrkel.Signature)
+	if err = signature.Decode(sigBytes); err != nil {
+		return false
+	}
+	ok, err := pk.Verify(signature, schnorrkel.NewSigningContext(context, msg))
+	return err == nil && ok
+}
+
+// edwardsToRistretto re-encodes a point in the Ed25519 encoding in ristretto255
+func edwardsToRistretto(encoded *[32]byte) ([]byte, error) {
+	pk, err := edwards.ParsePubKey(encoded[:])
+	if err != nil {
+		return nil, err
+	}
+	point, err := crypto.NewECPoint(edwards.Edwards(), pk.X, pk.Y)
+	if err != nil {
+		return nil, errors.New("invalid Ed25519 point")
+	}
+	return ristretto255.Encode(point)
+}
@@ -0,0 +1,178 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/sha512"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+
+	"github.com/agl/ed25519/edwards25519"
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+)
+
+// Variant is the signature scheme that a signing session produces: a variant of Ed25519 of RFC 8032, or sr25519
+type Variant int
+
+const (
+	// Ed25519 is pure Ed25519, the default
+	Ed25519 Variant = iota
+	// Ed25519ctx binds the signature to a non-empty context string
+	Ed25519ctx
+	// Ed25519ph signs the SHA-512 hash of the message, optionally bound to a context string
+	Ed25519ph
+	// Sr25519 is the Schnorr signature of schnorrkel over ristretto255, used by Polkadot and Kusama. The context string
+	// is the signing context, e.g. "substrate", and the public key is the ristretto255 encoding of EDDSAPub.
+	// It signs with the key shares of eddsa/keygen and eddsa/resharing as they are: EDDSAPub and the shares lie in the
+	// prime-order subgroup of edwards25519, which ristretto255 encodes one-to-one, so there is no separate sr25519 keygen
+	// or resharing. The key is a new threshold key; an existing sr25519 secret key cannot be imported.
+	Sr25519
+)
+
+const (
+	// MaxContextLength is the maximum length of the context string
+	MaxContextLength = 255
+
+	// dom2 prefix of RFC 8032, section 5.1
+	dom2Prefix = "SigEd25519 no Ed25519 collisions"
+)
+
+// Options selects the signature scheme of a signing session
+type Options struct {
+	Variant Variant
+	// Context is the context string; 1 to 255 bytes for Ed25519ctx, up to 255 bytes for Ed25519ph and Sr25519 and empty
+	// for Ed25519
+	Context []byte
+}
+
+func (v Variant) String() string {
+	switch v {
+	case Ed25519:
+		return "Ed25519"
+	case Ed25519ctx:
+		return "Ed25519ctx"
+	case Ed25519ph:
+		return "Ed25519ph"
+	case Sr25519:
+		return "Sr25519"
+	}
+	return fmt.Sprintf("Variant(%d)", int(v))
+}
+
+func (opts Options) validate() error {
+	switch opts.Variant {
+	case Ed25519:
+		if len(opts.Context) != 0 {
+			return errors.New("a context string cannot be used with pure Ed25519")
+		}
+	case Ed25519ctx:
+		if len(opts.Context) == 0 {
+			return errors.New("a non-empty context string is required by Ed25519ctx")
+		}
+	case Ed25519ph, Sr25519:
+	default:
+		return fmt.Errorf("unknown signing variant %s", opts.Variant)
+	}
+	if MaxContextLength < len(opts.Context) {
+		return fmt.Errorf("the context string is longer than %d bytes", MaxContextLength)
+	}
+	return nil
+}
+
+// challenge returns SHA-512(dom2(F, C) || R || A || PH(M)) reduced mod L, where dom2 is empty for pure Ed25519, or the
+// challenge of schnorrkel for Sr25519. R and A are encoded as Ed25519 points; the error is from their conversion to
+// ristretto255 for Sr25519.
+func (opts Options) challenge(encodedR, encodedPubKey *[32]byte, msg []byte) (*[32]byte, error) {
+	if opts.Variant == Sr25519 {
+		R, err := edwardsToRistretto(encodedR)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode R in ristretto255: %v", err)
+		}
+		A, err := edwardsToRistretto(encodedPubKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode the public key in ristretto255: %v", err)
+		}
+		return sr25519Challenge(opts.Context, R, A, msg), nil
+	}
+	h := sha512.New()
+	if opts.Variant != Ed25519 {
+		var phflag byte
+		if opts.Variant == Ed25519ph {
+			phflag = 1
+		}
+		h.Write([]byte(dom2Prefix))
+		h.Write([]byte{phflag, byte(len(opts.Context))})
+		h.Write(opts.Context)
+	}
+	h.Write(encodedR[:])
+	h.Write(encodedPubKey[:])
+	if opts.Variant == Ed25519ph {
+		ph := sha512.Sum512(msg)
+		h.Write(ph[:])
+	} else {
+		h.Write(msg)
+	}
+
+	var lambda [64]byte
+	h.Sum(lambda[:0])
+	var lambdaReduced [32]byte
+	edwards25519.ScReduce(&lambdaReduced, &lambda)
+	return &lambdaReduced, nil
+}
+
+// Verify reports whether `sig`, the 64-byte R || S of RFC 8032 or of schnorrkel for Sr25519, is a signature of `msg` by `pub` with the variant of
+// `opts`. It needs no key share, so it can check the output of a signing session, e.g. with signature.VerifyEdDSA.
+func (opts Options) Verify(pub *crypto.ECPoint, msg, sig []byte) bool {
+	if pub == nil || opts.validate() != nil {
+		return false
+	}
+	if opts.Variant == Sr25519 {
+		return sr25519Verify(pub, opts.Context, msg, sig)
+	}
+	return opts.verify(ecPointToEncodedBytes(pub.X(), pub.Y()), msg, sig)
+}
+
+// verify checks the 64-byte signature R || S of `msg` by `encodedPubKey` with the variant of `opts`: [S]B = R + [k]A
+func (opts Options) verify(encodedPubKey *[32]byte, msg, sig []byte) bool {
+	if opts.Variant == Sr25519 {
+		pk, err := edwards.ParsePubKey(encodedPubKey[:])
+		if err != nil {
+			return false
+		}
+		pub, err := crypto.NewECPoint(edwards.Edwards(), pk.X, pk.Y)
+		if err != nil {
+			return false
+		}
+		return sr25519Verify(pub, opts.Context, msg, sig)
+	}
+	if len(sig) != 64 || sig[63]&224 != 0 {
+		return false
+	}
+	var A edwards25519.ExtendedGroupElement
+	if !A.FromBytes(encodedPubKey) {
+		return false
+	}
+	edwards25519.FeNeg(&A.X, &A.X)
+	edwards25519.FeNeg(&A.T, &A.T)
+
+	var encodedR, s [32]byte
+	copy(encodedR[:], sig[:32])
+	copy(s[:], sig[32:])
+	k, err := opts.challenge(&encodedR, encodedPubKey, msg)
+	if err != nil {
+		return false
+	}
+
+	var R edwards25519.ProjectiveGroupElement
+	edwards25519.GeDoubleScalarMultVartime(&R, k, &A, &s)
+	var checkR [32]byte
+	R.ToBytes(&checkR)
+	return subtle.ConstantTimeCompare(encodedR[:], checkR[:]) == 1
+}
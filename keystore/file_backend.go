@@ -0,0 +1,109 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keystore
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const fileExtension = ".json"
+
+var validID = regexp.MustCompile(`^[A-Za-z0-9_-][A-Za-z0-9._-]*$`)
+
+// FileBackend stores each entry as a file in a directory that only the current user can access.
+type FileBackend struct {
+	dir string
+}
+
+var _ Backend = (*FileBackend)(nil)
+
+// NewFileBackend returns a Backend that stores the entries in `dir`, which is created if it does not exist.
+func NewFileBackend(dir string) (*FileBackend, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &FileBackend{dir: dir}, nil
+}
+
+// Put writes the entry to a temporary file and renames it, so that an existing entry is never left half-written.
+func (b *FileBackend) Put(id string, entry []byte) error {
+	path, err := b.path(id)
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(b.dir, ".tmp-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err = tmp.Chmod(0600); err == nil {
+		if _, err = tmp.Write(entry); err == nil {
+			err = tmp.Sync()
+		}
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (b *FileBackend) Get(id string) ([]byte, error) {
+	path, err := b.path(id)
+	if err != nil {
+		return nil, err
+	}
+	bz, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	return bz, err
+}
+
+func (b *FileBackend) Delete(id string) error {
+	path, err := b.path(id)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(path)
+	if os.IsNotExist(err) {
+		return ErrNotFound
+	}
+	return err
+}
+
+func (b *FileBackend) List() ([]string, error) {
+	files, err := ioutil.ReadDir(b.dir)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(files))
+	for _, file := range files {
+		name := file.Name()
+		if file.IsDir() || !strings.HasSuffix(name, fileExtension) || strings.HasPrefix(name, ".") {
+			continue
+		}
+		ids = append(ids, strings.TrimSuffix(name, fileExtension))
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+func (b *FileBackend) path(id string) (string, error) {
+	if !validID.MatchString(id) {
+		return "", fmt.Errorf("the ID %q is invalid; use letters, digits, '.', '_' and '-'", id)
+	}
+	return filepath.Join(b.dir, id+fileExtension), nil
+}
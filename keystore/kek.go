@@ -0,0 +1,197 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keystore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	// KeyLength is the length of the data encryption keys and of the keys accepted by NewAESKeyEncryptionKey.
+	KeyLength = 32
+
+	kdfArgon2id   = "argon2id"
+	saltLength    = 16
+	maxArgon2Mem  = 4 * 1024 * 1024 // KiB
+	maxArgon2Time = 64
+)
+
+type (
+	// KeyEncryptionKey wraps the random data encryption key of each keystore entry. Implement it to keep the
+	// key-encryption-key in a KMS or an HSM; NewPassphraseKeyEncryptionKey and NewAESKeyEncryptionKey are provided.
+	KeyEncryptionKey interface {
+		WrapKey(dek []byte) ([]byte, error)
+		UnwrapKey(wrapped []byte) ([]byte, error)
+	}
+
+	// Argon2Params are the cost parameters of the Argon2id key derivation, see RFC 9106.
+	// Memory is in KiB.
+	Argon2Params struct {
+		Time    uint32
+		Memory  uint32
+		Threads uint8
+	}
+
+	passphraseKEK struct {
+		passphrase []byte
+		params     Argon2Params
+	}
+
+	aesKEK struct {
+		key []byte
+	}
+
+	passphraseWrappedKey struct {
+		KDF    string
+		Params Argon2Params
+		Salt   []byte
+		Nonce  []byte
+		Sealed []byte
+	}
+)
+
+// DefaultArgon2Params are the parameters recommended by RFC 9106 for memory-constrained environments.
+var DefaultArgon2Params = Argon2Params{Time: 3, Memory: 64 * 1024, Threads: 4}
+
+// NewPassphraseKeyEncryptionKey derives the key-encryption-key from `passphrase` with Argon2id and a random salt per
+// entry, using DefaultArgon2Params.
+func NewPassphraseKeyEncryptionKey(passphrase []byte) KeyEncryptionKey {
+	return NewPassphraseKeyEncryptionKeyWithParams(passphrase, DefaultArgon2Params)
+}
+
+// NewPassphraseKeyEncryptionKeyWithParams is like NewPassphraseKeyEncryptionKey with custom Argon2id parameters.
+// The parameters are stored with each entry, so entries written with other parameters can still be decrypted.
+func NewPassphraseKeyEncryptionKeyWithParams(passphrase []byte, params Argon2Params) KeyEncryptionKey {
+	return &passphraseKEK{passphrase: append([]byte{}, passphrase...), params: params}
+}
+
+func (kek *passphraseKEK) WrapKey(dek []byte) ([]byte, error) {
+	if len(kek.passphrase) == 0 {
+		return nil, errors.New("the passphrase is empty")
+	}
+	if err := kek.params.validate(); err != nil {
+		return nil, err
+	}
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	key := argon2.IDKey(kek.passphrase, salt, kek.params.Time, kek.params.Memory, kek.params.Threads, KeyLength)
+	nonce, sealed, err := seal(key, dek, salt)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(&passphraseWrappedKey{
+		KDF:    kdfArgon2id,
+		Params: kek.params,
+		Salt:   salt,
+		Nonce:  nonce,
+		Sealed: sealed,
+	})
+}
+
+func (kek *passphraseKEK) UnwrapKey(wrapped []byte) ([]byte, error) {
+	w := new(passphraseWrappedKey)
+	if err := json.Unmarshal(wrapped, w); err != nil {
+		return nil, err
+	}
+	if w.KDF != kdfArgon2id {
+		return nil, fmt.Errorf("the key was not wrapped with a passphrase but with %q", w.KDF)
+	}
+	if err := w.Params.validate(); err != nil {
+		return nil, err
+	}
+	key := argon2.IDKey(kek.passphrase, w.Salt, w.Params.Time, w.Params.Memory, w.Params.Threads, KeyLength)
+	dek, err := open(key, w.Nonce, w.Sealed, w.Salt)
+	if err != nil {
+		return nil, errors.New("the passphrase is wrong or the key is corrupted")
+	}
+	return dek, nil
+}
+
+func (params Argon2Params) validate() error {
+	if params.Time < 1 || maxArgon2Time < params.Time ||
+		params.Memory < 8*uint32(params.Threads) || maxArgon2Mem < params.Memory ||
+		params.Threads < 1 {
+		return fmt.Errorf("the Argon2id parameters %+v are invalid", params)
+	}
+	return nil
+}
+
+// NewAESKeyEncryptionKey wraps the data encryption keys with AES-256-GCM under `kek`, a key of KeyLength bytes held
+// by the caller.
+func NewAESKeyEncryptionKey(kek []byte) (KeyEncryptionKey, error) {
+	if len(kek) != KeyLength {
+		return nil, fmt.Errorf("the key-encryption-key must be %d bytes long", KeyLength)
+	}
+	return &aesKEK{key: append([]byte{}, kek...)}, nil
+}
+
+func (kek *aesKEK) WrapKey(dek []byte) ([]byte, error) {
+	nonce, sealed, err := seal(kek.key, dek, nil)
+	if err != nil {
+		return nil, err
+	}
+	return append(nonce, sealed...), nil
+}
+
+func (kek *aesKEK) UnwrapKey(wrapped []byte) ([]byte, error) {
+	if len(wrapped) < gcmNonceSize {
+		return nil, errors.New("the wrapped key is too short")
+	}
+	dek, err := open(kek.key, wrapped[:gcmNonceSize], wrapped[gcmNonceSize:], nil)
+	if err != nil {
+		return nil, errors.New("the key-encryption-key is wrong or the key is corrupted")
+	}
+	return dek, nil
+}
+
+// ----- //
+
+const gcmNonceSize = 12
+
+// seal encrypts `plaintext` with AES-256-GCM under `key` and a random nonce
+func seal(key, plaintext, additionalData []byte) (nonce, ciphertext []byte, err error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	nonce = make([]byte, aead.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, nil, err
+	}
+	return nonce, aead.Seal(nil, nonce, plaintext, additionalData), nil
+}
+
+func open(key, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != aead.NonceSize() {
+		return nil, errors.New("the nonce has an invalid length")
+	}
+	return aead.Open(nil, nonce, ciphertext, additionalData)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	if len(key) != KeyLength {
+		return nil, fmt.Errorf("the key must be %d bytes long", KeyLength)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
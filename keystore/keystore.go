@@ -0,0 +1,287 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package keystore encrypts the save data and pre-params of a party at rest.
+//
+// Each entry is encrypted with AES-256-GCM under a random data encryption key, which is in turn wrapped by a
+// KeyEncryptionKey: a passphrase stretched with Argon2id, a caller-held AES key, or a custom implementation backed by
+// a KMS or an HSM. Changing the passphrase only re-wraps the data encryption key.
+package keystore
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	ecdsakeygen "github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	eddsakeygen "github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	// EntryVersion is the version of the entry format written by the Keystore.
+	EntryVersion = 1
+
+	KindECDSAKey       = "ecdsa-key"
+	KindEdDSAKey       = "eddsa-key"
+	KindECDSAPreParams = "ecdsa-pre-params"
+
+	keyIDLength = 16
+)
+
+type (
+	// Backend persists the encrypted entries of a Keystore. Get returns ErrNotFound for unknown IDs.
+	Backend interface {
+		Put(id string, entry []byte) error
+		Get(id string) ([]byte, error)
+		Delete(id string) error
+		List() ([]string, error)
+	}
+
+	Keystore struct {
+		backend Backend
+	}
+
+	entry struct {
+		Version    int
+		Kind       string
+		WrappedKey []byte
+		Nonce      []byte
+		Ciphertext []byte
+	}
+)
+
+var (
+	// ErrNotFound is returned when the keystore has no entry with the requested ID.
+	ErrNotFound = errors.New("keystore: entry not found")
+	// ErrExists is returned when an entry would overwrite another one that was not explicitly replaced.
+	ErrExists = errors.New("keystore: entry already exists")
+)
+
+func New(backend Backend) *Keystore {
+	return &Keystore{backend: backend}
+}
+
+// KeyID returns the ID under which the key share `shareID` of the group public key `pub` is stored, so that the shares
+// of several parties of a group can be kept in one keystore. A share keeps its ID after a refresh.
+func KeyID(pub *crypto.ECPoint, shareID *big.Int) (string, error) {
+	if pub == nil {
+		return "", errors.New("the public key is missing")
+	}
+	if shareID == nil {
+		return "", errors.New("the share ID is missing")
+	}
+	curveName, ok := tss.GetCurveName(pub.Curve())
+	if !ok {
+		return "", fmt.Errorf("cannot find %T name in curve registry, please call tss.RegisterCurve(name, curve) to register it first", pub.Curve())
+	}
+	hash := common.SHA512_256([]byte(curveName), pub.X().Bytes(), pub.Y().Bytes(), shareID.Bytes())
+	return hex.EncodeToString(hash[:keyIDLength]), nil
+}
+
+// PutECDSAKey encrypts and stores the save data of an ECDSA keygen with `threshold`. It returns the KeyID of the entry,
+// or ErrExists if the share is already stored; use ReplaceECDSAKey to store a refreshed share in its place.
+func (ks *Keystore) PutECDSAKey(save ecdsakeygen.LocalPartySaveData, threshold int, kek KeyEncryptionKey) (string, error) {
+	return ks.putECDSAKey(save, threshold, kek, false)
+}
+
+// ReplaceECDSAKey is like PutECDSAKey but replaces the share if it is already stored, e.g. after a refresh.
+// The previous share is lost, so the new one should have been checked with Validate first.
+func (ks *Keystore) ReplaceECDSAKey(save ecdsakeygen.LocalPartySaveData, threshold int, kek KeyEncryptionKey) (string, error) {
+	return ks.putECDSAKey(save, threshold, kek, true)
+}
+
+// GetECDSAKey decrypts the save data stored with PutECDSAKey and returns it with the threshold of the keygen.
+func (ks *Keystore) GetECDSAKey(id string, kek KeyEncryptionKey) (ecdsakeygen.LocalPartySaveData, int, error) {
+	bz, err := ks.get(id, KindECDSAKey, kek)
+	if err != nil {
+		return ecdsakeygen.LocalPartySaveData{}, 0, err
+	}
+	return ecdsakeygen.UnmarshalSaveData(bz)
+}
+
+// PutEdDSAKey encrypts and stores the save data of an EdDSA keygen with `threshold`. It returns the KeyID of the entry,
+// or ErrExists if the share is already stored; use ReplaceEdDSAKey to store a refreshed share in its place.
+func (ks *Keystore) PutEdDSAKey(save eddsakeygen.LocalPartySaveData, threshold int, kek KeyEncryptionKey) (string, error) {
+	return ks.putEdDSAKey(save, threshold, kek, false)
+}
+
+// ReplaceEdDSAKey is like PutEdDSAKey but replaces the share if it is already stored, e.g. after a refresh.
+// The previous share is lost, so the new one should have been checked with Validate first.
+func (ks *Keystore) ReplaceEdDSAKey(save eddsakeygen.LocalPartySaveData, threshold int, kek KeyEncryptionKey) (string, error) {
+	return ks.putEdDSAKey(save, threshold, kek, true)
+}
+
+// GetEdDSAKey decrypts the save data stored with PutEdDSAKey and returns it with the threshold of the keygen.
+func (ks *Keystore) GetEdDSAKey(id string, kek KeyEncryptionKey) (eddsakeygen.LocalPartySaveData, int, error) {
+	bz, err := ks.get(id, KindEdDSAKey, kek)
+	if err != nil {
+		return eddsakeygen.LocalPartySaveData{}, 0, err
+	}
+	return eddsakeygen.UnmarshalSaveData(bz)
+}
+
+// PutPreParams encrypts and stores pre-params for a later keygen under the caller-chosen `id`, since they do not
+// belong to a key yet. It returns ErrExists if the `id` is already used.
+func (ks *Keystore) PutPreParams(id string, preParams ecdsakeygen.LocalPreParams, kek KeyEncryptionKey) error {
+	if !preParams.ValidateWithProof() {
+		return errors.New("the pre-params failed to validate")
+	}
+	bz, err := json.Marshal(&preParams)
+	if err != nil {
+		return err
+	}
+	return ks.put(id, KindECDSAPreParams, bz, kek, false)
+}
+
+// GetPreParams decrypts the pre-params stored with PutPreParams.
+func (ks *Keystore) GetPreParams(id string, kek KeyEncryptionKey) (*ecdsakeygen.LocalPreParams, error) {
+	bz, err := ks.get(id, KindECDSAPreParams, kek)
+	if err != nil {
+		return nil, err
+	}
+	preParams := new(ecdsakeygen.LocalPreParams)
+	if err = json.Unmarshal(bz, preParams); err != nil {
+		return nil, err
+	}
+	return preParams, nil
+}
+
+// ChangeKeyEncryptionKey re-wraps the data encryption key of the entry `id` from `oldKEK` to `newKEK`, e.g. to change
+// the passphrase. The encrypted data itself is left untouched.
+func (ks *Keystore) ChangeKeyEncryptionKey(id string, oldKEK, newKEK KeyEncryptionKey) error {
+	e, err := ks.load(id)
+	if err != nil {
+		return err
+	}
+	dek, err := oldKEK.UnwrapKey(e.WrappedKey)
+	if err != nil {
+		return err
+	}
+	// make sure that the entry decrypts before it is re-wrapped
+	if _, err = open(dek, e.Nonce, e.Ciphertext, additionalData(id, e.Kind)); err != nil {
+		return errors.New("the entry is corrupted")
+	}
+	if e.WrappedKey, err = newKEK.WrapKey(dek); err != nil {
+		return err
+	}
+	return ks.store(id, e)
+}
+
+func (ks *Keystore) Delete(id string) error {
+	return ks.backend.Delete(id)
+}
+
+func (ks *Keystore) List() ([]string, error) {
+	return ks.backend.List()
+}
+
+// ----- //
+
+func (ks *Keystore) putECDSAKey(save ecdsakeygen.LocalPartySaveData, threshold int, kek KeyEncryptionKey, replace bool) (string, error) {
+	id, err := KeyID(save.ECDSAPub, save.ShareID)
+	if err != nil {
+		return "", err
+	}
+	bz, err := save.Marshal(threshold)
+	if err != nil {
+		return "", err
+	}
+	return id, ks.put(id, KindECDSAKey, bz, kek, replace)
+}
+
+func (ks *Keystore) putEdDSAKey(save eddsakeygen.LocalPartySaveData, threshold int, kek KeyEncryptionKey, replace bool) (string, error) {
+	id, err := KeyID(save.EDDSAPub, save.ShareID)
+	if err != nil {
+		return "", err
+	}
+	bz, err := save.Marshal(threshold)
+	if err != nil {
+		return "", err
+	}
+	return id, ks.put(id, KindEdDSAKey, bz, kek, replace)
+}
+
+func (ks *Keystore) put(id, kind string, plaintext []byte, kek KeyEncryptionKey, replace bool) error {
+	if !replace {
+		if _, err := ks.backend.Get(id); err == nil {
+			return ErrExists
+		} else if err != ErrNotFound {
+			return err
+		}
+	}
+	dek := make([]byte, KeyLength)
+	if _, err := rand.Read(dek); err != nil {
+		return err
+	}
+	nonce, ciphertext, err := seal(dek, plaintext, additionalData(id, kind))
+	if err != nil {
+		return err
+	}
+	wrapped, err := kek.WrapKey(dek)
+	if err != nil {
+		return err
+	}
+	return ks.store(id, &entry{
+		Version:    EntryVersion,
+		Kind:       kind,
+		WrappedKey: wrapped,
+		Nonce:      nonce,
+		Ciphertext: ciphertext,
+	})
+}
+
+func (ks *Keystore) get(id, kind string, kek KeyEncryptionKey) ([]byte, error) {
+	e, err := ks.load(id)
+	if err != nil {
+		return nil, err
+	}
+	if e.Kind != kind {
+		return nil, fmt.Errorf("the entry %s holds %s, not %s", id, e.Kind, kind)
+	}
+	dek, err := kek.UnwrapKey(e.WrappedKey)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := open(dek, e.Nonce, e.Ciphertext, additionalData(id, kind))
+	if err != nil {
+		return nil, errors.New("the entry is corrupted")
+	}
+	return plaintext, nil
+}
+
+func (ks *Keystore) load(id string) (*entry, error) {
+	bz, err := ks.backend.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	e := new(entry)
+	if err = json.Unmarshal(bz, e); err != nil {
+		return nil, fmt.Errorf("the entry %s could not be parsed: %v", id, err)
+	}
+	if e.Version != EntryVersion {
+		return nil, fmt.Errorf("the entry version %d is not supported; it might have been written by a newer version of tss-lib", e.Version)
+	}
+	return e, nil
+}
+
+func (ks *Keystore) store(id string, e *entry) error {
+	bz, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	return ks.backend.Put(id, bz)
+}
+
+// additionalData binds the ciphertext to its ID and kind, so that entries cannot be swapped
+func additionalData(id, kind string) []byte {
+	return common.SHA512_256([]byte(id), []byte(kind))
+}
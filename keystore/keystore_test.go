@@ -0,0 +1,159 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keystore
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	ecdsakeygen "github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	eddsakeygen "github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/test"
+)
+
+// cheap parameters to keep the tests fast
+var testArgon2Params = Argon2Params{Time: 1, Memory: 8 * 1024, Threads: 1}
+
+func newTestKeystore(t *testing.T) (*Keystore, string) {
+	dir := t.TempDir()
+	backend, err := NewFileBackend(dir)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return New(backend), dir
+}
+
+func TestECDSAKeyWithPassphrase(t *testing.T) {
+	keys, _, err := ecdsakeygen.LoadKeygenTestFixtures(1)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	ks, _ := newTestKeystore(t)
+	kek := NewPassphraseKeyEncryptionKeyWithParams([]byte("correct horse"), testArgon2Params)
+
+	id, err := ks.PutECDSAKey(keys[0], test.TestThreshold, kek)
+	if !assert.NoError(t, err) {
+		return
+	}
+	expectedID, _ := KeyID(keys[0].ECDSAPub, keys[0].ShareID)
+	assert.Equal(t, expectedID, id)
+	ids, err := ks.List()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{id}, ids)
+
+	save, threshold, err := ks.GetECDSAKey(id, kek)
+	assert.NoError(t, err)
+	assert.Equal(t, test.TestThreshold, threshold)
+	assert.Equal(t, keys[0], save)
+
+	_, _, err = ks.GetECDSAKey(id, NewPassphraseKeyEncryptionKeyWithParams([]byte("wrong horse"), testArgon2Params))
+	assert.Error(t, err, "a wrong passphrase should fail")
+
+	// the entry holds an ECDSA key
+	_, _, err = ks.GetEdDSAKey(id, kek)
+	assert.Error(t, err)
+
+	// change the passphrase
+	newKEK := NewPassphraseKeyEncryptionKeyWithParams([]byte("battery staple"), testArgon2Params)
+	assert.NoError(t, ks.ChangeKeyEncryptionKey(id, kek, newKEK))
+	_, _, err = ks.GetECDSAKey(id, kek)
+	assert.Error(t, err, "the old passphrase should no longer work")
+	save, _, err = ks.GetECDSAKey(id, newKEK)
+	assert.NoError(t, err)
+	assert.Equal(t, keys[0], save)
+
+	assert.NoError(t, ks.Delete(id))
+	_, _, err = ks.GetECDSAKey(id, newKEK)
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestPutDoesNotReplace(t *testing.T) {
+	keys, _, err := ecdsakeygen.LoadKeygenTestFixtures(2)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	ks, _ := newTestKeystore(t)
+	kek, err := NewAESKeyEncryptionKey(make([]byte, KeyLength))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// the shares of two parties of the same key are stored side by side
+	id0, err := ks.PutECDSAKey(keys[0], test.TestThreshold, kek)
+	assert.NoError(t, err)
+	id1, err := ks.PutECDSAKey(keys[1], test.TestThreshold, kek)
+	assert.NoError(t, err)
+	assert.NotEqual(t, id0, id1)
+
+	// a share with the same ID is not overwritten by Put
+	refreshed := keys[0]
+	refreshed.Xi = keys[1].Xi
+	_, err = ks.PutECDSAKey(refreshed, test.TestThreshold, kek)
+	assert.Equal(t, ErrExists, err)
+	save, _, err := ks.GetECDSAKey(id0, kek)
+	assert.NoError(t, err)
+	assert.Equal(t, keys[0], save)
+
+	// but it is by Replace
+	id, err := ks.ReplaceECDSAKey(refreshed, test.TestThreshold, kek)
+	assert.NoError(t, err)
+	assert.Equal(t, id0, id)
+	save, _, err = ks.GetECDSAKey(id0, kek)
+	assert.NoError(t, err)
+	assert.Equal(t, refreshed, save)
+
+	assert.NoError(t, ks.PutPreParams("next-keygen", keys[0].LocalPreParams, kek))
+	assert.Equal(t, ErrExists, ks.PutPreParams("next-keygen", keys[1].LocalPreParams, kek))
+}
+
+func TestEdDSAKeyAndPreParamsWithAESKey(t *testing.T) {
+	eddsaKeys, _, err := eddsakeygen.LoadKeygenTestFixtures(1)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	ecdsaKeys, _, err := ecdsakeygen.LoadKeygenTestFixtures(1)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	ks, dir := newTestKeystore(t)
+	kek, err := NewAESKeyEncryptionKey(make([]byte, KeyLength))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	id, err := ks.PutEdDSAKey(eddsaKeys[0], test.TestThreshold, kek)
+	if !assert.NoError(t, err) {
+		return
+	}
+	save, _, err := ks.GetEdDSAKey(id, kek)
+	assert.NoError(t, err)
+	assert.Equal(t, eddsaKeys[0], save)
+	_, err = ks.PutEdDSAKey(eddsaKeys[0], test.TestThreshold, kek)
+	assert.Equal(t, ErrExists, err)
+	_, err = ks.ReplaceEdDSAKey(eddsaKeys[0], test.TestThreshold, kek)
+	assert.NoError(t, err)
+
+	assert.NoError(t, ks.PutPreParams("next-keygen", ecdsaKeys[0].LocalPreParams, kek))
+	preParams, err := ks.GetPreParams("next-keygen", kek)
+	assert.NoError(t, err)
+	assert.Equal(t, ecdsaKeys[0].LocalPreParams, *preParams)
+
+	// a tampered entry is detected
+	path := filepath.Join(dir, id+fileExtension)
+	bz, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	bz[len(bz)-10] ^= 0x01
+	assert.NoError(t, ioutil.WriteFile(path, bz, 0600))
+	_, _, err = ks.GetEdDSAKey(id, kek)
+	assert.Error(t, err)
+
+	// IDs cannot escape the directory
+	assert.Error(t, ks.PutPreParams("../escape", ecdsaKeys[0].LocalPreParams, kek))
+}
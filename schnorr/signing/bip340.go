@@ -0,0 +1,88 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/elliptic"
+	"crypto/sha256"
+	"errors"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+)
+
+const (
+	tagChallenge = "BIP0340/challenge"
+	tagTapTweak  = "TapTweak"
+)
+
+// XOnlyPubKey returns the 32-byte x-only encoding of BIP-340 for a public key
+func XOnlyPubKey(pub *crypto.ECPoint) []byte {
+	return pub.X().FillBytes(make([]byte, 32))
+}
+
+// TaprootOutputKey returns the BIP-341 output key Q = P + int(hash_TapTweak(P || merkleRoot))*G for the internal key P,
+// where P is taken with an even Y. Pass an empty merkleRoot for an output without a script path (BIP-86).
+func TaprootOutputKey(pub *crypto.ECPoint, merkleRoot []byte) (*crypto.ECPoint, error) {
+	t, err := taprootTweak(pub, merkleRoot)
+	if err != nil {
+		return nil, err
+	}
+	return evenY(pub).Add(crypto.ScalarBaseMult(pub.Curve(), t))
+}
+
+func taprootTweak(pub *crypto.ECPoint, merkleRoot []byte) (*big.Int, error) {
+	if len(merkleRoot) != 0 && len(merkleRoot) != 32 {
+		return nil, errors.New("the merkle root must be empty or 32 bytes long")
+	}
+	t := new(big.Int).SetBytes(taggedHash(tagTapTweak, XOnlyPubKey(pub), merkleRoot))
+	if t.Cmp(pub.Curve().Params().N) >= 0 {
+		return nil, errors.New("the taproot tweak is not a valid scalar")
+	}
+	return t, nil
+}
+
+// challenge computes e = int(hash_BIP0340/challenge(bytes(R) || bytes(P) || m)) mod n
+func challenge(ec elliptic.Curve, R, pub *crypto.ECPoint, msg []byte) *big.Int {
+	e := new(big.Int).SetBytes(taggedHash(tagChallenge, XOnlyPubKey(R), XOnlyPubKey(pub), msg))
+	return e.Mod(e, ec.Params().N)
+}
+
+func taggedHash(tag string, msgs ...[]byte) []byte {
+	tagHash := sha256.Sum256([]byte(tag))
+	h := sha256.New()
+	h.Write(tagHash[:])
+	h.Write(tagHash[:])
+	for _, msg := range msgs {
+		h.Write(msg)
+	}
+	return h.Sum(nil)
+}
+
+func hasEvenY(p *crypto.ECPoint) bool {
+	return p.Y().Bit(0) == 0
+}
+
+// evenY returns the point with the same x and an even y
+func evenY(p *crypto.ECPoint) *crypto.ECPoint {
+	if hasEvenY(p) {
+		return p
+	}
+	return negate(p)
+}
+
+func negate(p *crypto.ECPoint) *crypto.ECPoint {
+	return crypto.NewECPointNoCurveCheck(p.Curve(), p.X(), new(big.Int).Sub(p.Curve().Params().P, p.Y()))
+}
+
+// parity returns 1 for a point with an even y and n-1 otherwise, the factor that maps the point to evenY(p)
+func parity(ec elliptic.Curve, p *crypto.ECPoint) *big.Int {
+	if hasEvenY(p) {
+		return big.NewInt(1)
+	}
+	return new(big.Int).Sub(ec.Params().N, big.NewInt(1))
+}
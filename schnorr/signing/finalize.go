@@ -0,0 +1,92 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func (round *finalization) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 4
+	round.started = true
+	round.resetOK()
+
+	ec := round.Params().EC()
+	modN := common.ModInt(ec.Params().N)
+	eKeyParity := modN.Mul(round.temp.e, round.temp.keyParity)
+
+	// 1. verify every share: sj*G == parity(R)*Kj + e*parity(P)*Wj, and sum them up
+	sumS := round.temp.si
+	culprits := make([]*tss.PartyID, 0, len(round.temp.signRound3Messages))
+	for j, msg := range round.temp.signRound3Messages {
+		round.ok[j] = true
+		if j == round.PartyID().Index {
+			continue
+		}
+		sj := msg.Content().(*SignRound3Message).UnmarshalS()
+		expected, err := round.temp.bigKs[j].ScalarMult(round.temp.rParity).Add(round.temp.bigWs[j].ScalarMult(eKeyParity))
+		if err != nil || sj.Cmp(ec.Params().N) >= 0 || !crypto.ScalarBaseMult(ec, sj).Equals(expected) {
+			culprits = append(culprits, msg.GetFrom())
+			continue
+		}
+		sumS = modN.Add(sumS, sj)
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("invalid signature shares"), culprits...)
+	}
+
+	// 2. add the taproot tweak: s = sum(sj) + e*t
+	s := modN.Add(sumS, modN.Mul(round.temp.e, round.temp.tweak))
+
+	// save the signature for final output
+	rBytes := XOnlyPubKey(round.temp.bigR)
+	sBytes := s.FillBytes(make([]byte, 32))
+	round.data.Signature = append(append([]byte{}, rBytes...), sBytes...)
+	round.data.R = rBytes
+	round.data.S = sBytes
+	round.data.M = round.temp.m
+
+	// 3. verify with btcec
+	pk, err := schnorr.ParsePubKey(XOnlyPubKey(round.temp.pubKey))
+	if err != nil {
+		return round.WrapError(err)
+	}
+	sig, err := schnorr.ParseSignature(round.data.Signature)
+	if err != nil {
+		return round.WrapError(err)
+	}
+	if !sig.Verify(round.temp.m, pk) {
+		return round.WrapError(fmt.Errorf("signature verification failed"))
+	}
+	round.end <- round.data
+
+	return nil
+}
+
+func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *finalization) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *finalization) NextRound() tss.Round {
+	return nil // finished!
+}
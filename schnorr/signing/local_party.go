@@ -0,0 +1,219 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	cmt "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// Implements Party
+// Implements Stringer
+var _ tss.Party = (*LocalParty)(nil)
+var _ fmt.Stringer = (*LocalParty)(nil)
+
+type (
+	// LocalParty creates BIP-340 Schnorr signatures on secp256k1 with the key shares of ECDSA keygen.
+	// The signatures are 64 bytes long and verify against the x-only public key of the group, or of its taproot output.
+	LocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		keys keygen.LocalPartySaveData
+		temp localTempData
+		data *common.SignatureData
+
+		// outbound messaging
+		out chan<- tss.Message
+		end chan<- *common.SignatureData
+	}
+
+	localMessageStore struct {
+		signRound1Messages,
+		signRound2Messages,
+		signRound3Messages []tss.ParsedMessage
+	}
+
+	localTempData struct {
+		localMessageStore
+
+		// temp data (thrown away after sign) / round 1
+		wi,
+		ki *big.Int
+		m        []byte
+		pointKi  *crypto.ECPoint
+		deCommit cmt.HashDeCommitment
+		bigWs    []*crypto.ECPoint
+
+		// key used for verification: the group key or its taproot output key
+		taproot    bool
+		merkleRoot []byte
+		pubKey     *crypto.ECPoint
+		keyParity, // the sign applied to the shares so that the secret matches pubKey
+		tweak *big.Int // the taproot tweak, with the same sign applied
+
+		// round 2
+		cjs []*big.Int
+
+		// round 3
+		bigKs   []*crypto.ECPoint
+		bigR    *crypto.ECPoint
+		rParity *big.Int
+		e       *big.Int
+		si      *big.Int
+
+		ssid      []byte
+		ssidNonce *big.Int
+	}
+)
+
+// NewLocalParty returns a party that signs the 32-byte message `msg` for the group key of `key`.
+func NewLocalParty(
+	msg []byte,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+) tss.Party {
+	return newLocalParty(msg, params, key, false, nil, out, end)
+}
+
+// NewLocalPartyWithTaprootTweak returns a party that signs the 32-byte message `msg` for the BIP-341 taproot output
+// key of the group key, i.e. a key path spend. `merkleRoot` is the root of the script tree, or empty if there is none.
+// Use TaprootOutputKey to obtain the output key.
+func NewLocalPartyWithTaprootTweak(
+	msg []byte,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	merkleRoot []byte,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+) tss.Party {
+	return newLocalParty(msg, params, key, true, merkleRoot, out, end)
+}
+
+func newLocalParty(
+	msg []byte,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	taproot bool,
+	merkleRoot []byte,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+) tss.Party {
+	partyCount := len(params.Parties().IDs())
+	p := &LocalParty{
+		BaseParty: new(tss.BaseParty),
+		params:    params,
+		keys:      keygen.BuildLocalSaveDataSubset(key, params.Parties().IDs()),
+		temp:      localTempData{},
+		data:      &common.SignatureData{},
+		out:       out,
+		end:       end,
+	}
+	// msgs init
+	p.temp.signRound1Messages = make([]tss.ParsedMessage, partyCount)
+	p.temp.signRound2Messages = make([]tss.ParsedMessage, partyCount)
+	p.temp.signRound3Messages = make([]tss.ParsedMessage, partyCount)
+
+	// temp data init
+	p.temp.m = msg
+	p.temp.taproot = taproot
+	p.temp.merkleRoot = merkleRoot
+	p.temp.cjs = make([]*big.Int, partyCount)
+	p.temp.bigKs = make([]*crypto.ECPoint, partyCount)
+	return p
+}
+
+func (p *LocalParty) FirstRound() tss.Round {
+	return newRound1(p.params, &p.keys, p.data, &p.temp, p.out, p.end)
+}
+
+func (p *LocalParty) Start() *tss.Error {
+	return tss.BaseStart(p, TaskName, p.prepare)
+}
+
+func (p *LocalParty) StartWithContext(ctx context.Context, errCh chan<- *tss.Error) *tss.Error {
+	return tss.BaseStartWithContext(ctx, p, TaskName, errCh, p.prepare)
+}
+
+func (p *LocalParty) prepare(round tss.Round) *tss.Error {
+	round1, ok := round.(*round1)
+	if !ok {
+		return round.WrapError(errors.New("unable to Start(). party is in an unexpected round"))
+	}
+	if err := round1.prepare(); err != nil {
+		return round.WrapError(err)
+	}
+	return nil
+}
+
+func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(p, msg, TaskName)
+}
+
+func (p *LocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *LocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if ok, err := p.BaseParty.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	// check that the message's "from index" will fit into the array
+	if maxFromIdx := len(p.params.Parties().IDs()) - 1; maxFromIdx < msg.GetFrom().Index {
+		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+			maxFromIdx, msg.GetFrom().Index), msg.GetFrom())
+	}
+	return true, nil
+}
+
+func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	fromPIdx := msg.GetFrom().Index
+
+	// switch/case is necessary to store any messages beyond current round
+	// this does not handle message replays. we expect the caller to apply replay and spoofing protection.
+	switch msg.Content().(type) {
+	case *SignRound1Message:
+		p.temp.signRound1Messages[fromPIdx] = msg
+
+	case *SignRound2Message:
+		p.temp.signRound2Messages[fromPIdx] = msg
+
+	case *SignRound3Message:
+		p.temp.signRound3Messages[fromPIdx] = msg
+
+	default: // unrecognised message, just ignore!
+		common.Logger.Warningf("unrecognised message ignored: %v", msg)
+		return false, nil
+	}
+	return true, nil
+}
+
+func (p *LocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *LocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
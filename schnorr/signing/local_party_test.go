@@ -0,0 +1,162 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	msg := sha256.Sum256([]byte("BIP-340"))
+	data := runSigning(t, keys, signPIDs, func(params *tss.Parameters, key keygen.LocalPartySaveData, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Party {
+		return NewLocalParty(msg[:], params, key, out, end)
+	})
+	assertValidSignature(t, XOnlyPubKey(keys[0].ECDSAPub), msg[:], data)
+}
+
+func TestE2ETaprootTweak(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	msg := sha256.Sum256([]byte("taproot key path spend"))
+	scriptRoot := sha256.Sum256([]byte("script tree"))
+	for _, merkleRoot := range [][]byte{nil, scriptRoot[:]} {
+		outputKey, err := TaprootOutputKey(keys[0].ECDSAPub, merkleRoot)
+		if !assert.NoError(t, err) {
+			return
+		}
+		data := runSigning(t, keys, signPIDs, func(params *tss.Parameters, key keygen.LocalPartySaveData, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Party {
+			return NewLocalPartyWithTaprootTweak(msg[:], params, key, merkleRoot, out, end)
+		})
+		assertValidSignature(t, XOnlyPubKey(outputKey), msg[:], data)
+	}
+}
+
+func TestTaprootOutputKey(t *testing.T) {
+	// BIP-86 test vector for m/86'/0'/0'/0/0
+	internalKey, _ := hex.DecodeString("cc8a4bc64d897bddc5fbc2f670f7a8ba0b386779106cf1223c6fc5d7cd6fc115")
+	outputKey, _ := hex.DecodeString("a60869f0dbcf1dc659c9cecbaf8050135ea9e8cdc487053f1dc6880949dc684c")
+
+	pk, err := schnorr.ParsePubKey(internalKey)
+	if !assert.NoError(t, err) {
+		return
+	}
+	pub, err := crypto.NewECPoint(tss.S256(), pk.X(), pk.Y())
+	if !assert.NoError(t, err) {
+		return
+	}
+	Q, err := TaprootOutputKey(pub, nil)
+	if assert.NoError(t, err) {
+		assert.Equal(t, outputKey, XOnlyPubKey(Q))
+	}
+}
+
+func TestMessageLength(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	params := tss.NewParameters(tss.S256(), tss.NewPeerContext(signPIDs), signPIDs[0], len(signPIDs), testThreshold)
+	P := NewLocalParty([]byte("not a hash"), params, keys[0], make(chan tss.Message, 1), make(chan *common.SignatureData, 1))
+	if err := P.Start(); assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "32 bytes")
+	}
+}
+
+func runSigning(
+	t *testing.T,
+	keys []keygen.LocalPartySaveData,
+	signPIDs tss.SortedPartyIDs,
+	newParty func(*tss.Parameters, keygen.LocalPartySaveData, chan<- tss.Message, chan<- *common.SignatureData) tss.Party,
+) *common.SignatureData {
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]tss.Party, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		P := newParty(params, keys[i], outCh, endCh)
+		parties = append(parties, P)
+		go func(P tss.Party) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	sigs := make([]*common.SignatureData, 0, len(signPIDs))
+	for len(sigs) < len(signPIDs) {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case msg := <-outCh:
+			if dest := msg.GetTo(); dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index != msg.GetFrom().Index {
+						go test.SharedPartyUpdater(P, msg, errCh)
+					}
+				}
+			} else {
+				go test.SharedPartyUpdater(parties[dest[0].Index], msg, errCh)
+			}
+		case data := <-endCh:
+			sigs = append(sigs, data)
+		}
+	}
+	for _, data := range sigs {
+		assert.Equal(t, sigs[0].Signature, data.Signature, "all the parties should output the same signature")
+	}
+	return sigs[0]
+}
+
+func assertValidSignature(t *testing.T, xOnlyPub, msg []byte, data *common.SignatureData) {
+	pk, err := schnorr.ParsePubKey(xOnlyPub)
+	if !assert.NoError(t, err) {
+		return
+	}
+	sig, err := schnorr.ParseSignature(data.Signature)
+	if assert.NoError(t, err) {
+		assert.True(t, sig.Verify(msg, pk), "the signature should verify with btcec")
+	}
+	assert.Len(t, data.Signature, 64)
+	assert.Equal(t, data.Signature, append(append([]byte{}, data.R...), data.S...))
+	assert.Equal(t, msg, data.M)
+}
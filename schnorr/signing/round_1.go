@@ -0,0 +1,143 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	ecdsasigning "github.com/bnb-chain/tss-lib/v2/ecdsa/signing"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+var (
+	zero = big.NewInt(0)
+)
+
+// round 1 represents round 1 of the signing part of the BIP-340 Schnorr TSS spec
+func newRound1(params *tss.Parameters, key *keygen.LocalPartySaveData, data *common.SignatureData, temp *localTempData, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Round {
+	return &round1{
+		&base{params, key, data, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1}}
+}
+
+func (round *round1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	round.temp.ssidNonce = new(big.Int).SetUint64(0)
+	var err error
+	round.temp.ssid, err = round.getSSID()
+	if err != nil {
+		return round.WrapError(err)
+	}
+	// 1. select ki
+	ki := common.GetRandomPositiveInt(round.Params().EC().Params().N)
+
+	// 2. make commitment
+	pointKi := crypto.ScalarBaseMult(round.Params().EC(), ki)
+	cmt := commitments.NewHashCommitment(pointKi.X(), pointKi.Y())
+
+	// 3. store r1 message pieces
+	round.temp.ki = ki
+	round.temp.pointKi = pointKi
+	round.temp.deCommit = cmt.D
+
+	i := round.PartyID().Index
+	round.ok[i] = true
+
+	// 4. broadcast commitment
+	r1msg := NewSignRound1Message(round.PartyID(), cmt.C)
+	round.temp.signRound1Messages[i] = r1msg
+	round.out <- r1msg
+
+	return nil
+}
+
+func (round *round1) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.signRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignRound1Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &round2{round}
+}
+
+// ----- //
+
+// helper to call into PrepareForSigning() and to work out the parity of the key that is signed for
+func (round *round1) prepare() error {
+	ec := round.Params().EC()
+	if !tss.SameCurve(ec, tss.S256()) {
+		return errors.New("BIP-340 signatures are only defined on secp256k1")
+	}
+	if len(round.temp.m) != 32 {
+		return fmt.Errorf("the message must be 32 bytes long, got %d", len(round.temp.m))
+	}
+	i := round.PartyID().Index
+
+	xi := round.key.Xi
+	ks := round.key.Ks
+	bigXs := round.key.BigXj
+
+	if round.Threshold()+1 > len(ks) {
+		return fmt.Errorf("t+1=%d is not satisfied by the key count of %d", round.Threshold()+1, len(ks))
+	}
+	wi, bigWs := ecdsasigning.PrepareForSigning(ec, i, len(ks), xi, ks, bigXs)
+
+	round.temp.wi = wi
+	round.temp.bigWs = bigWs
+
+	// the secret is negated whenever a key has an odd y, so that it matches the x-only key
+	modN := common.ModInt(ec.Params().N)
+	pub := round.key.ECDSAPub
+	round.temp.pubKey = evenY(pub)
+	round.temp.keyParity = parity(ec, pub)
+	round.temp.tweak = big.NewInt(0)
+	if round.temp.taproot {
+		t, err := taprootTweak(pub, round.temp.merkleRoot)
+		if err != nil {
+			return err
+		}
+		Q, err := round.temp.pubKey.Add(crypto.ScalarBaseMult(ec, t))
+		if err != nil {
+			return fmt.Errorf("invalid taproot output key: %v", err)
+		}
+		qParity := parity(ec, Q)
+		round.temp.pubKey = evenY(Q)
+		round.temp.keyParity = modN.Mul(round.temp.keyParity, qParity)
+		round.temp.tweak = modN.Mul(t, qParity)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"math/big"
+
+	errors2 "github.com/pkg/errors"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto/schnorr"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func (round *round2) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	i := round.PartyID().Index
+
+	// 1. store r1 message pieces
+	for j, msg := range round.temp.signRound1Messages {
+		r1msg := msg.Content().(*SignRound1Message)
+		round.temp.cjs[j] = r1msg.UnmarshalCommitment()
+	}
+
+	// 2. compute Schnorr prove
+	ContextI := append(round.temp.ssid, new(big.Int).SetUint64(uint64(i)).Bytes()...)
+	pir, err := schnorr.NewZKProof(ContextI, round.temp.ki, round.temp.pointKi)
+	if err != nil {
+		return round.WrapError(errors2.Wrapf(err, "NewZKProof(ki, pointKi)"))
+	}
+
+	// 3. BROADCAST de-commitments of Shamir poly*G and Schnorr prove
+	r2msg2 := NewSignRound2Message(round.PartyID(), round.temp.deCommit, pir)
+	round.temp.signRound2Messages[i] = r2msg2
+	round.out <- r2msg2
+
+	return nil
+}
+
+func (round *round2) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignRound2Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round2) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.signRound2Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round2) NextRound() tss.Round {
+	round.started = false
+	return &round3{round}
+}
@@ -0,0 +1,119 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"math/big"
+
+	"github.com/pkg/errors"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func (round *round3) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+
+	round.number = 3
+	round.started = true
+	round.resetOK()
+
+	// 1. init R
+	R := round.temp.pointKi
+	round.temp.bigKs[round.PartyID().Index] = R
+
+	// 2-6. compute R
+	i := round.PartyID().Index
+	for j, Pj := range round.Parties().IDs() {
+		if j == i {
+			continue
+		}
+
+		ContextJ := common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(j)))
+		msg := round.temp.signRound2Messages[j]
+		r2msg := msg.Content().(*SignRound2Message)
+		cmtDeCmt := commitments.HashCommitDecommit{C: round.temp.cjs[j], D: r2msg.UnmarshalDeCommitment()}
+		ok, coordinates := cmtDeCmt.DeCommit()
+		if !ok {
+			return round.WrapError(errors.New("de-commitment verify failed"), Pj)
+		}
+		if len(coordinates) != 2 {
+			return round.WrapError(errors.New("length of de-commitment should be 2"), Pj)
+		}
+
+		Kj, err := crypto.NewECPoint(round.Params().EC(), coordinates[0], coordinates[1])
+		if err != nil {
+			return round.WrapError(errors.Wrapf(err, "NewECPoint(Kj)"), Pj)
+		}
+		proof, err := r2msg.UnmarshalZKProof(round.Params().EC())
+		if err != nil {
+			return round.WrapError(errors.New("failed to unmarshal Kj proof"), Pj)
+		}
+		ok = proof.Verify(ContextJ, Kj)
+		if !ok {
+			return round.WrapError(errors.New("failed to prove Kj"), Pj)
+		}
+
+		round.temp.bigKs[j] = Kj
+		if R, err = R.Add(Kj); err != nil {
+			return round.WrapError(errors.Wrapf(err, "R.Add(Kj)"), Pj)
+		}
+	}
+
+	// 7. negate the nonces if R has an odd y and compute the challenge e
+	round.temp.bigR = R
+	round.temp.rParity = parity(round.Params().EC(), R)
+	round.temp.e = challenge(round.Params().EC(), R, round.temp.pubKey, round.temp.m)
+
+	// 8. compute si = ki*parity(R) + e*wi*parity(P)
+	modN := common.ModInt(round.Params().EC().Params().N)
+	ki := modN.Mul(round.temp.ki, round.temp.rParity)
+	si := modN.Add(ki, modN.Mul(round.temp.e, modN.Mul(round.temp.wi, round.temp.keyParity)))
+	round.temp.si = si
+
+	// clear temp.wi and temp.ki from memory, lint ignore
+	round.temp.wi = zero
+	round.temp.ki = zero
+
+	// 9. broadcast si to other parties
+	r3msg := NewSignRound3Message(round.PartyID(), si)
+	round.temp.signRound3Messages[i] = r3msg
+	round.out <- r3msg
+
+	return nil
+}
+
+func (round *round3) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.signRound3Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round3) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignRound3Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round3) NextRound() tss.Round {
+	round.started = false
+	return &finalization{round}
+}
@@ -0,0 +1,79 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signature
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	// CompactSignatureLength is the length of a Bitcoin compact signature: header || r || s
+	CompactSignatureLength = 65
+
+	compactHeaderBase       = 27
+	compactHeaderCompressed = 4
+)
+
+// ToCompact encodes a secp256k1 ECDSA signature as a Bitcoin compact signature, as used for signed messages: a header
+// byte of 27 + recovery id, plus 4 if the public key is compressed, followed by r and s in 32 bytes each.
+func ToCompact(data *common.SignatureData, compressed bool) ([]byte, error) {
+	r, s, err := ecdsaRS(data)
+	if err != nil {
+		return nil, err
+	}
+	recid, err := recoveryID(data)
+	if err != nil {
+		return nil, err
+	}
+	ec := tss.S256()
+	if err := checkScalar(ec, "r", r); err != nil {
+		return nil, err
+	}
+	if err := checkScalar(ec, "s", s); err != nil {
+		return nil, err
+	}
+	header := compactHeaderBase + recid
+	if compressed {
+		header += compactHeaderCompressed
+	}
+	compact := make([]byte, CompactSignatureLength)
+	compact[0] = header
+	r.FillBytes(compact[1:33])
+	s.FillBytes(compact[33:])
+	return compact, nil
+}
+
+// FromCompact parses a Bitcoin compact signature. It returns the signature with its recovery id and whether the
+// header marks the public key as compressed.
+func FromCompact(compact []byte) (*common.SignatureData, bool, error) {
+	if len(compact) != CompactSignatureLength {
+		return nil, false, fmt.Errorf("a compact signature must be %d bytes", CompactSignatureLength)
+	}
+	header := compact[0]
+	if header < compactHeaderBase || compactHeaderBase+compactHeaderCompressed+3 < header {
+		return nil, false, errors.New("invalid compact signature header")
+	}
+	recid := header - compactHeaderBase
+	compressed := compactHeaderCompressed <= recid
+	if compressed {
+		recid -= compactHeaderCompressed
+	}
+	ec := tss.S256()
+	r, s := new(big.Int).SetBytes(compact[1:33]), new(big.Int).SetBytes(compact[33:])
+	if err := checkScalar(ec, "r", r); err != nil {
+		return nil, false, err
+	}
+	if err := checkScalar(ec, "s", s); err != nil {
+		return nil, false, err
+	}
+	return newECDSASignatureData(ec, r, s, &recid), compressed, nil
+}
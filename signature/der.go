@@ -0,0 +1,53 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signature
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"math/big"
+
+	"golang.org/x/crypto/cryptobyte"
+	"golang.org/x/crypto/cryptobyte/asn1"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+)
+
+// ToDER encodes an ECDSA signature as the ASN.1 DER structure SEQUENCE { r INTEGER, s INTEGER }
+func ToDER(data *common.SignatureData) ([]byte, error) {
+	r, s, err := ecdsaRS(data)
+	if err != nil {
+		return nil, err
+	}
+	var b cryptobyte.Builder
+	b.AddASN1(asn1.SEQUENCE, func(b *cryptobyte.Builder) {
+		b.AddASN1BigInt(r)
+		b.AddASN1BigInt(s)
+	})
+	return b.Bytes()
+}
+
+// FromDER parses an ECDSA signature on `ec` in ASN.1 DER. The recovery id is not part of the encoding, so it is not
+// set in the returned SignatureData.
+func FromDER(ec elliptic.Curve, der []byte) (*common.SignatureData, error) {
+	var inner cryptobyte.String
+	input := cryptobyte.String(der)
+	if !input.ReadASN1(&inner, asn1.SEQUENCE) || !input.Empty() {
+		return nil, errors.New("invalid DER signature: expected a single SEQUENCE")
+	}
+	r, s := new(big.Int), new(big.Int)
+	if !inner.ReadASN1Integer(r) || !inner.ReadASN1Integer(s) || !inner.Empty() {
+		return nil, errors.New("invalid DER signature: expected two INTEGERs")
+	}
+	if err := checkScalar(ec, "r", r); err != nil {
+		return nil, err
+	}
+	if err := checkScalar(ec, "s", s); err != nil {
+		return nil, err
+	}
+	return newECDSASignatureData(ec, r, s, nil), nil
+}
@@ -0,0 +1,101 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signature
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+var (
+	ethereumLegacyV = big.NewInt(27)
+	eip155V         = big.NewInt(35)
+)
+
+// EthereumV returns the v of an Ethereum signature with the recovery id `recid`: 27 + recid without a chain id, or
+// chainID * 2 + 35 + recid as in EIP-155
+func EthereumV(recid byte, chainID *big.Int) *big.Int {
+	if chainID == nil {
+		return new(big.Int).Add(ethereumLegacyV, big.NewInt(int64(recid)))
+	}
+	v := new(big.Int).Lsh(chainID, 1)
+	v.Add(v, eip155V)
+	return v.Add(v, big.NewInt(int64(recid)))
+}
+
+// ToEthereum encodes a secp256k1 ECDSA signature as r || s || v, with r and s in 32 bytes each and v as in EthereumV.
+// v is a single byte without a chain id; with a chain id it is in big-endian bytes and may be longer than a byte.
+// Ethereum only accepts low-S signatures (EIP-2) with a recovery id of 0 or 1, which the signing protocol outputs
+// unless tss.Parameters.SetNoLowS was called.
+func ToEthereum(data *common.SignatureData, chainID *big.Int) ([]byte, error) {
+	r, s, err := ecdsaRS(data)
+	if err != nil {
+		return nil, err
+	}
+	recid, err := recoveryID(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkEthereum(r, s, recid, chainID); err != nil {
+		return nil, err
+	}
+	sig := make([]byte, 64, 65)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:])
+	return append(sig, EthereumV(recid, chainID).Bytes()...), nil
+}
+
+// FromEthereum parses an Ethereum signature r || s || v. `chainID` must be the chain id that v was computed with,
+// or nil for a v of 27 or 28.
+func FromEthereum(sig []byte, chainID *big.Int) (*common.SignatureData, error) {
+	if len(sig) <= 64 {
+		return nil, errors.New("an Ethereum signature must be longer than 64 bytes")
+	}
+	vBz := sig[64:]
+	if vBz[0] == 0 {
+		return nil, errors.New("v has leading zero bytes")
+	}
+	v := new(big.Int).SetBytes(vBz)
+	var recid byte
+	switch {
+	case v.Cmp(EthereumV(0, chainID)) == 0:
+		recid = 0
+	case v.Cmp(EthereumV(1, chainID)) == 0:
+		recid = 1
+	default:
+		return nil, fmt.Errorf("v %s does not match the chain id", v)
+	}
+	r, s := new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:64])
+	if err := checkEthereum(r, s, recid, chainID); err != nil {
+		return nil, err
+	}
+	return newECDSASignatureData(tss.S256(), r, s, &recid), nil
+}
+
+func checkEthereum(r, s *big.Int, recid byte, chainID *big.Int) error {
+	ec := tss.S256()
+	if chainID != nil && chainID.Sign() <= 0 {
+		return errors.New("the chain id must be positive")
+	}
+	if 1 < recid {
+		return fmt.Errorf("the recovery id %d cannot be encoded in an Ethereum signature", recid)
+	}
+	if err := checkScalar(ec, "r", r); err != nil {
+		return err
+	}
+	if err := checkScalar(ec, "s", s); err != nil {
+		return err
+	}
+	if !isLowS(ec, s) {
+		return errors.New("s is not in the lower half of the order, as required by EIP-2")
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signature
+
+import (
+	"crypto/elliptic"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// JWS algorithms (RFC 7518, RFC 8037 and RFC 8812)
+const (
+	ES256K = "ES256K"
+	ES256  = "ES256"
+	ES384  = "ES384"
+	EdDSA  = "EdDSA"
+)
+
+const ed25519SignatureLength = 64
+
+var (
+	jwsEncoding = base64.RawURLEncoding.Strict()
+
+	// order of the ed25519 base point
+	ed25519L, _ = new(big.Int).SetString("7237005577332262213973186563042994240857116359379907606001950938285454250989", 10)
+)
+
+// JWSAlgorithm returns the JWS algorithm of the signatures made with keys on `ec`
+func JWSAlgorithm(ec elliptic.Curve) (string, error) {
+	name, _ := tss.GetCurveName(ec)
+	switch name {
+	case tss.Secp256k1:
+		return ES256K, nil
+	case tss.Secp256r1:
+		return ES256, nil
+	case tss.Secp384r1:
+		return ES384, nil
+	case tss.Ed25519:
+		return EdDSA, nil
+	}
+	return "", fmt.Errorf("there is no JWS algorithm for the curve %T", ec)
+}
+
+// ToJWS encodes a signature as the base64url signature of a JWS with the algorithm `alg`: R || S in the size of the
+// curve for ECDSA, or the 64-byte signature of RFC 8032 for EdDSA
+func ToJWS(data *common.SignatureData, alg string) (string, error) {
+	if alg == EdDSA {
+		if data == nil || len(data.Signature) != ed25519SignatureLength {
+			return "", errors.New("an EdDSA signature must be 64 bytes")
+		}
+		return jwsEncoding.EncodeToString(data.Signature), nil
+	}
+	ec, err := jwsCurve(alg)
+	if err != nil {
+		return "", err
+	}
+	r, s, err := ecdsaRS(data)
+	if err != nil {
+		return "", err
+	}
+	if err := checkScalar(ec, "r", r); err != nil {
+		return "", err
+	}
+	if err := checkScalar(ec, "s", s); err != nil {
+		return "", err
+	}
+	return jwsEncoding.EncodeToString(newECDSASignatureData(ec, r, s, nil).Signature), nil
+}
+
+// FromJWS parses the base64url signature of a JWS with the algorithm `alg`
+func FromJWS(sig string, alg string) (*common.SignatureData, error) {
+	bz, err := jwsEncoding.DecodeString(sig)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JWS signature: %v", err)
+	}
+	if alg == EdDSA {
+		return fromEd25519(bz)
+	}
+	ec, err := jwsCurve(alg)
+	if err != nil {
+		return nil, err
+	}
+	size := (ec.Params().BitSize + 7) / 8
+	if len(bz) != 2*size {
+		return nil, fmt.Errorf("an %s signature must be %d bytes", alg, 2*size)
+	}
+	r, s := new(big.Int).SetBytes(bz[:size]), new(big.Int).SetBytes(bz[size:])
+	if err := checkScalar(ec, "r", r); err != nil {
+		return nil, err
+	}
+	if err := checkScalar(ec, "s", s); err != nil {
+		return nil, err
+	}
+	return newECDSASignatureData(ec, r, s, nil), nil
+}
+
+func jwsCurve(alg string) (elliptic.Curve, error) {
+	switch alg {
+	case ES256K:
+		return tss.S256(), nil
+	case ES256:
+		return tss.P256(), nil
+	case ES384:
+		return tss.P384(), nil
+	}
+	return nil, fmt.Errorf("unsupported JWS algorithm %q", alg)
+}
+
+// fromEd25519 builds the SignatureData of an Ed25519 signature as the EdDSA signing protocol outputs it: R and S are
+// the big-endian bytes of the little-endian encodings in the signature
+func fromEd25519(sig []byte) (*common.SignatureData, error) {
+	if len(sig) != ed25519SignatureLength {
+		return nil, errors.New("an EdDSA signature must be 64 bytes")
+	}
+	r, s := new(big.Int).SetBytes(reverse(sig[:32])), new(big.Int).SetBytes(reverse(sig[32:]))
+	if s.Cmp(ed25519L) >= 0 {
+		return nil, errors.New("s is out of range")
+	}
+	return &common.SignatureData{
+		Signature: append([]byte{}, sig...),
+		R:         r.Bytes(),
+		S:         s.Bytes(),
+	}, nil
+}
+
+// reverse returns a reversed copy of bz
+func reverse(bz []byte) []byte {
+	out := make([]byte, len(bz))
+	for i, b := range bz {
+		out[len(bz)-1-i] = b
+	}
+	return out
+}
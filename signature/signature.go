@@ -0,0 +1,71 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package signature converts the SignatureData output by the signing protocols to and from the standard encodings:
+// ASN.1 DER, the 65-byte compact signatures of Bitcoin, the r || s || v signatures of Ethereum and JWS.
+//
+// The parsers are strict: they reject encodings that are not canonical, trailing data and values out of range, so
+// that a signature has exactly one accepted encoding.
+//
+// It also verifies signatures and recovers ECDSA public keys without a key share, e.g. on a coordinator.
+package signature
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+)
+
+// ecdsaRS returns the R and S of an ECDSA signature
+func ecdsaRS(data *common.SignatureData) (r, s *big.Int, err error) {
+	if data == nil || len(data.R) == 0 || len(data.S) == 0 {
+		return nil, nil, errors.New("the signature data has no R or S")
+	}
+	return new(big.Int).SetBytes(data.R), new(big.Int).SetBytes(data.S), nil
+}
+
+// recoveryID returns the recovery id of an ECDSA signature
+func recoveryID(data *common.SignatureData) (byte, error) {
+	if len(data.SignatureRecovery) == 0 {
+		return 0, errors.New("the signature data has no recovery id")
+	}
+	recid := data.SignatureRecovery[0]
+	if 3 < recid {
+		return 0, fmt.Errorf("invalid recovery id %d", recid)
+	}
+	return recid, nil
+}
+
+// checkScalar checks that 0 < x < N
+func checkScalar(ec elliptic.Curve, name string, x *big.Int) error {
+	if x.Sign() <= 0 || x.Cmp(ec.Params().N) >= 0 {
+		return fmt.Errorf("%s is out of range", name)
+	}
+	return nil
+}
+
+// isLowS reports whether s <= N/2
+func isLowS(ec elliptic.Curve, s *big.Int) bool {
+	return s.Cmp(new(big.Int).Rsh(ec.Params().N, 1)) <= 0
+}
+
+// newECDSASignatureData builds the SignatureData of an ECDSA signature in the same form as the signing protocol: R and
+// S padded to the size of the curve and Signature = R || S. `recid` is nil if it is unknown.
+func newECDSASignatureData(ec elliptic.Curve, r, s *big.Int, recid *byte) *common.SignatureData {
+	size := (ec.Params().BitSize + 7) / 8
+	data := &common.SignatureData{
+		R: r.FillBytes(make([]byte, size)),
+		S: s.FillBytes(make([]byte, size)),
+	}
+	data.Signature = append(append([]byte{}, data.R...), data.S...)
+	if recid != nil {
+		data.SignatureRecovery = []byte{*recid}
+	}
+	return data
+}
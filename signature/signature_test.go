@@ -0,0 +1,324 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signature
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	btcecdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	eddsasigning "github.com/bnb-chain/tss-lib/v2/eddsa/signing"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// btcecSignature signs a hash with a new secp256k1 key and returns the signature data as the signing protocol outputs it
+// together with the compact signature of btcec
+func btcecSignature(t *testing.T) (*common.SignatureData, []byte, *btcec.PrivateKey, []byte) {
+	key, err := btcec.NewPrivateKey()
+	assert.NoError(t, err)
+	hash := sha256.Sum256([]byte("hello"))
+	compact, err := btcecdsa.SignCompact(key, hash[:], true)
+	assert.NoError(t, err)
+	data, compressed, err := FromCompact(compact)
+	assert.NoError(t, err)
+	assert.True(t, compressed)
+	return data, compact, key, hash[:]
+}
+
+func TestDER(t *testing.T) {
+	data, _, key, hash := btcecSignature(t)
+
+	der, err := ToDER(data)
+	assert.NoError(t, err)
+	// btcec signs deterministically, so its DER signature is the same
+	assert.Equal(t, btcecdsa.Sign(key, hash).Serialize(), der)
+
+	parsed, err := FromDER(tss.S256(), der)
+	assert.NoError(t, err)
+	assert.Equal(t, data.Signature, parsed.Signature)
+	assert.Nil(t, parsed.SignatureRecovery)
+
+	// P-256, checked with the standard library
+	p256Key, err := ecdsa.GenerateKey(tss.P256(), rand.Reader)
+	assert.NoError(t, err)
+	der, err = ecdsa.SignASN1(rand.Reader, p256Key, hash)
+	assert.NoError(t, err)
+	parsed, err = FromDER(tss.P256(), der)
+	assert.NoError(t, err)
+	assert.Len(t, parsed.Signature, 64)
+	der2, err := ToDER(parsed)
+	assert.NoError(t, err)
+	assert.Equal(t, der, der2)
+}
+
+func TestDERStrict(t *testing.T) {
+	N := hex.EncodeToString(tss.S256().Params().N.Bytes())
+	for _, der := range []string{
+		"3006020101020101" + "00",        // trailing data
+		"308106020101020101",             // non-minimal length
+		"3007020101020101" + "02",        // trailing data in the sequence
+		"300702020001020101",             // non-minimal integer
+		"3006020101020181",               // wrong length of s
+		"30060201ff020101",               // negative r
+		"3006020100020101",               // zero r
+		"30260221" + "00" + N + "020101", // r = N
+		"3106020101020101",               // not a sequence
+		"",
+	} {
+		bz, err := hex.DecodeString(der)
+		assert.NoError(t, err)
+		_, err = FromDER(tss.S256(), bz)
+		assert.Error(t, err, "%s should be rejected", der)
+	}
+	bz, _ := hex.DecodeString("3006020101020101")
+	_, err := FromDER(tss.S256(), bz)
+	assert.NoError(t, err)
+}
+
+func TestCompact(t *testing.T) {
+	data, compact, key, hash := btcecSignature(t)
+
+	compact2, err := ToCompact(data, true)
+	assert.NoError(t, err)
+	assert.Equal(t, compact, compact2)
+	pub, compressed, err := btcecdsa.RecoverCompact(compact2, hash)
+	assert.NoError(t, err)
+	assert.True(t, compressed)
+	assert.True(t, pub.IsEqual(key.PubKey()))
+
+	uncompressed, err := ToCompact(data, false)
+	assert.NoError(t, err)
+	assert.Equal(t, compact[0]-4, uncompressed[0])
+
+	// strict parsing
+	bad := append([]byte{}, compact...)
+	bad[0] = 35
+	_, _, err = FromCompact(bad)
+	assert.Error(t, err)
+	_, _, err = FromCompact(compact[:64])
+	assert.Error(t, err)
+	bad = append([]byte{}, compact...)
+	tss.S256().Params().N.FillBytes(bad[33:])
+	_, _, err = FromCompact(bad)
+	assert.Error(t, err)
+	_, err = ToCompact(&common.SignatureData{R: data.R, S: data.S}, true)
+	assert.Error(t, err, "the recovery id is required")
+}
+
+func TestEthereum(t *testing.T) {
+	data, compact, _, _ := btcecSignature(t)
+	recid := compact[0] - 27 - 4
+
+	sig, err := ToEthereum(data, nil)
+	assert.NoError(t, err)
+	assert.Len(t, sig, 65)
+	assert.Equal(t, 27+recid, sig[64])
+	assert.Equal(t, data.Signature, sig[:64])
+	parsed, err := FromEthereum(sig, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, data.Signature, parsed.Signature)
+	assert.Equal(t, []byte{recid}, parsed.SignatureRecovery)
+
+	// EIP-155: v = chainID * 2 + 35 + recid
+	sig, err = ToEthereum(data, big.NewInt(1))
+	assert.NoError(t, err)
+	assert.Equal(t, 37+recid, sig[64])
+	_, err = FromEthereum(sig, nil)
+	assert.Error(t, err, "v does not match without a chain id")
+	_, err = FromEthereum(sig, big.NewInt(5))
+	assert.Error(t, err, "v does not match another chain id")
+	parsed, err = FromEthereum(sig, big.NewInt(1))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{recid}, parsed.SignatureRecovery)
+
+	// a chain id with a v longer than a byte
+	chainID := big.NewInt(137)
+	sig, err = ToEthereum(data, chainID)
+	assert.NoError(t, err)
+	assert.Len(t, sig, 66)
+	assert.Equal(t, EthereumV(recid, chainID), new(big.Int).SetBytes(sig[64:]))
+	_, err = FromEthereum(sig, chainID)
+	assert.NoError(t, err)
+	_, err = FromEthereum(append(append(append([]byte{}, sig[:64]...), 0), sig[64:]...), chainID)
+	assert.Error(t, err, "v with a leading zero byte should be rejected")
+
+	// high S is rejected (EIP-2)
+	N := tss.S256().Params().N
+	highS := &common.SignatureData{R: data.R, S: new(big.Int).Sub(N, new(big.Int).SetBytes(data.S)).Bytes(), SignatureRecovery: []byte{recid ^ 1}}
+	_, err = ToEthereum(highS, nil)
+	assert.Error(t, err)
+	bad := append(append(append([]byte{}, data.R...), highS.S...), 27)
+	_, err = FromEthereum(bad, nil)
+	assert.Error(t, err)
+}
+
+func TestJWS(t *testing.T) {
+	// ES256K
+	data, _, _, _ := btcecSignature(t)
+	jws, err := ToJWS(data, ES256K)
+	assert.NoError(t, err)
+	parsed, err := FromJWS(jws, ES256K)
+	assert.NoError(t, err)
+	assert.Equal(t, data.Signature, parsed.Signature)
+	_, err = FromJWS(jws+"=", ES256K)
+	assert.Error(t, err, "padding should be rejected")
+	_, err = FromJWS(jws, ES384)
+	assert.Error(t, err, "the length should match the algorithm")
+
+	// ES256
+	key, err := ecdsa.GenerateKey(tss.P256(), rand.Reader)
+	assert.NoError(t, err)
+	hash := sha256.Sum256([]byte("hello"))
+	r, s, err := ecdsa.Sign(rand.Reader, key, hash[:])
+	assert.NoError(t, err)
+	jws, err = ToJWS(&common.SignatureData{R: r.Bytes(), S: s.Bytes()}, ES256)
+	assert.NoError(t, err)
+	parsed, err = FromJWS(jws, ES256)
+	assert.NoError(t, err)
+	assert.Len(t, parsed.Signature, 64)
+	assert.True(t, ecdsa.Verify(&key.PublicKey, hash[:], new(big.Int).SetBytes(parsed.R), new(big.Int).SetBytes(parsed.S)))
+
+	// EdDSA
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	sig := ed25519.Sign(priv, []byte("hello"))
+	parsed, err = FromJWS(jwsEncoding.EncodeToString(sig), EdDSA)
+	assert.NoError(t, err)
+	assert.Equal(t, sig, parsed.Signature)
+	assert.Equal(t, new(big.Int).SetBytes(reverse(sig[32:])).Bytes(), parsed.S)
+	jws, err = ToJWS(parsed, EdDSA)
+	assert.NoError(t, err)
+	assert.Equal(t, jwsEncoding.EncodeToString(sig), jws)
+	assert.True(t, ed25519.Verify(pub, []byte("hello"), parsed.Signature))
+	bad := append([]byte{}, sig...)
+	bad[63] |= 0xf0
+	_, err = FromJWS(jwsEncoding.EncodeToString(bad), EdDSA)
+	assert.Error(t, err, "s >= L should be rejected")
+
+	for _, tc := range []struct {
+		ec  elliptic.Curve
+		alg string
+	}{
+		{tss.S256(), ES256K}, {tss.P256(), ES256}, {tss.P384(), ES384}, {tss.Edwards(), EdDSA},
+	} {
+		alg, err := JWSAlgorithm(tc.ec)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.alg, alg)
+	}
+	_, err = JWSAlgorithm(elliptic.P224())
+	assert.Error(t, err)
+}
+
+func TestVerifyAndRecoverECDSA(t *testing.T) {
+	// secp256k1, recovered from the recovery id of btcec
+	data, _, key, hash := btcecSignature(t)
+	data.M = hash
+	pub, err := crypto.NewECPoint(tss.S256(), key.PubKey().X(), key.PubKey().Y())
+	assert.NoError(t, err)
+	assert.NoError(t, VerifyECDSA(pub, data))
+	recovered, err := RecoverECDSA(tss.S256(), data)
+	assert.NoError(t, err)
+	assert.True(t, recovered.Equals(pub))
+
+	other := &common.SignatureData{R: data.R, S: data.S, SignatureRecovery: []byte{data.SignatureRecovery[0] ^ 1}, M: hash}
+	recovered, err = RecoverECDSA(tss.S256(), other)
+	assert.NoError(t, err)
+	assert.False(t, recovered.Equals(pub), "the other recovery id should give another key")
+	wrong := sha256.Sum256([]byte("hellO"))
+	assert.Error(t, VerifyECDSA(pub, &common.SignatureData{R: data.R, S: data.S, M: wrong[:]}))
+
+	// NIST curves; the recovery id is found by trying both parities
+	for _, ec := range []elliptic.Curve{tss.P256(), tss.P384()} {
+		key, err := ecdsa.GenerateKey(ec, rand.Reader)
+		assert.NoError(t, err)
+		digest := make([]byte, 64) // longer than the order of both curves, so it is truncated
+		_, _ = rand.Read(digest)
+		r, s, err := ecdsa.Sign(rand.Reader, key, digest)
+		assert.NoError(t, err)
+		pub, err := crypto.NewECPoint(ec, key.X, key.Y)
+		assert.NoError(t, err)
+		found := false
+		for recid := byte(0); recid < 2; recid++ {
+			data := &common.SignatureData{R: r.Bytes(), S: s.Bytes(), SignatureRecovery: []byte{recid}, M: digest}
+			assert.NoError(t, VerifyECDSA(pub, data))
+			recovered, err := RecoverECDSA(ec, data)
+			assert.NoError(t, err)
+			found = found || recovered.Equals(pub)
+		}
+		assert.True(t, found, "one of the recovery ids should give the key")
+	}
+
+	_, err = RecoverECDSA(elliptic.P224(), data)
+	assert.Error(t, err)
+	_, err = RecoverECDSA(tss.S256(), &common.SignatureData{R: data.R, S: data.S, SignatureRecovery: []byte{4}, M: hash})
+	assert.Error(t, err)
+}
+
+func TestVerifyEdDSA(t *testing.T) {
+	pubBz, priv, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	edPub, err := edwards.ParsePubKey(pubBz)
+	assert.NoError(t, err)
+	pub, err := crypto.NewECPoint(tss.Edwards(), edPub.X, edPub.Y)
+	assert.NoError(t, err)
+	msg := []byte("hello")
+
+	data := &common.SignatureData{Signature: ed25519.Sign(priv, msg), M: msg}
+	assert.NoError(t, VerifyEdDSA(pub, data))
+	assert.Error(t, VerifyEdDSA(pub, data, eddsasigning.Options{Variant: eddsasigning.Ed25519ctx, Context: []byte("ctx")}))
+	assert.Error(t, VerifyEdDSA(pub, &common.SignatureData{Signature: data.Signature, M: []byte("hellO")}))
+
+	sig, err := priv.Sign(nil, msg, &ed25519.Options{Context: "ctx"})
+	assert.NoError(t, err)
+	data = &common.SignatureData{Signature: sig, M: msg}
+	assert.NoError(t, VerifyEdDSA(pub, data, eddsasigning.Options{Variant: eddsasigning.Ed25519ctx, Context: []byte("ctx")}))
+	assert.Error(t, VerifyEdDSA(pub, data))
+}
+
+func TestVerifySchnorr(t *testing.T) {
+	key, err := btcec.NewPrivateKey()
+	assert.NoError(t, err)
+	hash := sha256.Sum256([]byte("hello"))
+	sig, err := schnorr.Sign(key, hash[:])
+	assert.NoError(t, err)
+	pub, err := crypto.NewECPoint(tss.S256(), key.PubKey().X(), key.PubKey().Y())
+	assert.NoError(t, err)
+	data := &common.SignatureData{Signature: sig.Serialize(), M: hash[:]}
+	assert.NoError(t, VerifySchnorr(pub, data))
+	other := sha256.Sum256([]byte("hellO"))
+	assert.Error(t, VerifySchnorr(pub, &common.SignatureData{Signature: data.Signature, M: other[:]}))
+}
+
+func TestChildPublicKey(t *testing.T) {
+	ec := tss.S256()
+	x := common.GetRandomPositiveInt(ec.Params().N)
+	delta := common.GetRandomPositiveInt(ec.Params().N)
+	child, err := ChildPublicKey(crypto.ScalarBaseMult(ec, x), delta)
+	assert.NoError(t, err)
+	expected := crypto.ScalarBaseMult(ec, new(big.Int).Mod(new(big.Int).Add(x, delta), ec.Params().N))
+	assert.True(t, child.Equals(expected))
+
+	// a signature by the child key verifies against it
+	digest := sha256.Sum256([]byte("hello"))
+	priv := &ecdsa.PrivateKey{PublicKey: *expected.ToECDSAPubKey(), D: new(big.Int).Mod(new(big.Int).Add(x, delta), ec.Params().N)}
+	r, s, err := ecdsa.Sign(rand.Reader, priv, digest[:])
+	assert.NoError(t, err)
+	assert.NoError(t, VerifyECDSA(child, &common.SignatureData{R: r.Bytes(), S: s.Bytes(), M: digest[:]}))
+}
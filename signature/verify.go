@@ -0,0 +1,179 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signature
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	ecdsasigning "github.com/bnb-chain/tss-lib/v2/ecdsa/signing"
+	eddsasigning "github.com/bnb-chain/tss-lib/v2/eddsa/signing"
+	schnorrsigning "github.com/bnb-chain/tss-lib/v2/schnorr/signing"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// The functions in this file check the output of a signing session without a key share, e.g. on a coordinator before
+// a transaction is broadcast. The public key is the ECDSAPub or EDDSAPub of the save data, or the child key returned by
+// ChildPublicKey for a session that was run with a key derivation delta.
+
+// ChildPublicKey returns pub + keyDerivationDelta*G, the public key that the parties sign for when they are
+// constructed with NewLocalPartyWithKDD and `keyDerivationDelta`
+func ChildPublicKey(pub *crypto.ECPoint, keyDerivationDelta *big.Int) (*crypto.ECPoint, error) {
+	if pub == nil || keyDerivationDelta == nil {
+		return nil, errors.New("the public key and the key derivation delta are required")
+	}
+	ec := pub.Curve()
+	delta := new(big.Int).Mod(keyDerivationDelta, ec.Params().N)
+	if delta.Sign() == 0 {
+		return pub, nil
+	}
+	return pub.Add(crypto.ScalarBaseMult(ec, delta))
+}
+
+// VerifyECDSA verifies an ECDSA signature of data.M by `pub`. M is the digest that was signed; a digest longer than
+// the order of the curve is truncated as in FIPS 186-4, as it was when signing.
+func VerifyECDSA(pub *crypto.ECPoint, data *common.SignatureData) error {
+	if pub == nil || !pub.ValidateBasic() {
+		return errors.New("invalid public key")
+	}
+	r, s, err := ecdsaRS(data)
+	if err != nil {
+		return err
+	}
+	if !ecdsa.Verify(pub.ToECDSAPubKey(), data.M, r, s) {
+		return errors.New("ECDSA signature verification failed")
+	}
+	return nil
+}
+
+// VerifyEdDSA verifies an EdDSA signature of data.M by `pub`. Pass the options of the signing session for an
+// Ed25519ctx, Ed25519ph or Sr25519 signature; pure Ed25519 is the default.
+func VerifyEdDSA(pub *crypto.ECPoint, data *common.SignatureData, optionalOpts ...eddsasigning.Options) error {
+	if pub == nil || !pub.ValidateBasic() {
+		return errors.New("invalid public key")
+	}
+	if data == nil {
+		return errors.New("the signature data is nil")
+	}
+	var opts eddsasigning.Options
+	if 0 < len(optionalOpts) {
+		opts = optionalOpts[0]
+	}
+	if !opts.Verify(pub, data.M, data.Signature) {
+		return fmt.Errorf("%s signature verification failed", opts.Variant)
+	}
+	return nil
+}
+
+// VerifySchnorr verifies a BIP-340 Schnorr signature of data.M by `pub`. For a taproot output, `pub` is the output key
+// returned by schnorr/signing.TaprootOutputKey.
+func VerifySchnorr(pub *crypto.ECPoint, data *common.SignatureData) error {
+	if pub == nil || !pub.ValidateBasic() {
+		return errors.New("invalid public key")
+	}
+	if data == nil {
+		return errors.New("the signature data is nil")
+	}
+	pk, err := schnorr.ParsePubKey(schnorrsigning.XOnlyPubKey(pub))
+	if err != nil {
+		return err
+	}
+	sig, err := schnorr.ParseSignature(data.Signature)
+	if err != nil {
+		return err
+	}
+	if !sig.Verify(data.M, pk) {
+		return errors.New("schnorr signature verification failed")
+	}
+	return nil
+}
+
+// RecoverECDSA recovers the public key of an ECDSA signature of data.M on `ec` from its recovery id, which the signing
+// protocol outputs in SignatureRecovery. Check the recovered key against the expected one; any valid signature
+// recovers to some key.
+func RecoverECDSA(ec elliptic.Curve, data *common.SignatureData) (*crypto.ECPoint, error) {
+	r, s, err := ecdsaRS(data)
+	if err != nil {
+		return nil, err
+	}
+	recid, err := recoveryID(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkScalar(ec, "r", r); err != nil {
+		return nil, err
+	}
+	if err := checkScalar(ec, "s", s); err != nil {
+		return nil, err
+	}
+	a, err := curveA(ec)
+	if err != nil {
+		return nil, err
+	}
+	params := ec.Params()
+	N, P := params.N, params.P
+
+	// 1. R = (x, y) with x = r + j*N and the parity of y from the recovery id
+	x := new(big.Int).Set(r)
+	if recid&2 != 0 {
+		x.Add(x, N)
+	}
+	if x.Cmp(P) >= 0 {
+		return nil, errors.New("invalid recovery id: R.x is not a field element")
+	}
+	// y^2 = x^3 + a*x + b
+	y2 := new(big.Int).Exp(x, big.NewInt(3), P)
+	y2.Add(y2, new(big.Int).Mul(a, x))
+	y2.Add(y2, params.B)
+	y2.Mod(y2, P)
+	y := new(big.Int).ModSqrt(y2, P)
+	if y == nil {
+		return nil, errors.New("invalid signature: R.x is not on the curve")
+	}
+	if y.Bit(0) != uint(recid&1) {
+		y.Sub(P, y)
+	}
+	R, err := crypto.NewECPoint(ec, x, y)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Q = r^-1 * (s*R - e*G)
+	modN := common.ModInt(N)
+	e := ecdsasigning.HashToInt(ec, data.M)
+	sR := R.ScalarMult(s)
+	Q := sR
+	if e.Sign() != 0 {
+		if Q, err = sR.Add(crypto.ScalarBaseMult(ec, modN.Sub(N, e))); err != nil {
+			return nil, errors.New("invalid signature: the recovered key is the point at infinity")
+		}
+	}
+	Q = Q.ScalarMult(modN.ModInverse(r))
+	if err := VerifyECDSA(Q, data); err != nil {
+		return nil, err
+	}
+	return Q, nil
+}
+
+// curveA returns the coefficient a of y^2 = x^3 + a*x + b, which elliptic.CurveParams leaves out
+func curveA(ec elliptic.Curve) (*big.Int, error) {
+	name, ok := tss.GetCurveName(ec)
+	switch {
+	case ok && name == tss.Secp256k1:
+		return big.NewInt(0), nil
+	case ok && (name == tss.Secp256r1 || name == tss.Secp384r1):
+		return big.NewInt(-3), nil
+	}
+	return nil, fmt.Errorf("public key recovery is not supported on the curve %T", ec)
+}
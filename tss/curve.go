@@ -20,6 +20,8 @@ type CurveName string
 const (
 	Secp256k1 CurveName = "secp256k1"
 	Ed25519   CurveName = "ed25519"
+	Secp256r1 CurveName = "secp256r1" // NIST P-256
+	Secp384r1 CurveName = "secp384r1" // NIST P-384
 )
 
 var (
@@ -34,6 +36,8 @@ func init() {
 	registry = make(map[CurveName]elliptic.Curve)
 	registry[Secp256k1] = s256k1.S256()
 	registry[Ed25519] = edwards.Edwards()
+	registry[Secp256r1] = elliptic.P256()
+	registry[Secp384r1] = elliptic.P384()
 }
 
 func RegisterCurve(name CurveName, curve elliptic.Curve) {
@@ -51,6 +55,12 @@ func GetCurveByName(name CurveName) (elliptic.Curve, bool) {
 
 // return name, exist(bool)
 func GetCurveName(curve elliptic.Curve) (CurveName, bool) {
+	// some curves share a type, e.g. the NIST curves in older versions of Go, so look for the same instance first
+	for name, e := range registry {
+		if curve == e {
+			return name, true
+		}
+	}
 	for name, e := range registry {
 		if reflect.TypeOf(curve) == reflect.TypeOf(e) {
 			return name, true
@@ -93,3 +103,13 @@ func S256() elliptic.Curve {
 func Edwards() elliptic.Curve {
 	return edwards.Edwards()
 }
+
+// NIST P-256, also known as secp256r1 and prime256v1
+func P256() elliptic.Curve {
+	return elliptic.P256()
+}
+
+// NIST P-384, also known as secp384r1
+func P384() elliptic.Curve {
+	return elliptic.P384()
+}
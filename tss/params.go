@@ -21,11 +21,14 @@ type (
 		threshold           int
 		concurrency         int
 		safePrimeGenTimeout time.Duration
+		roundTimeout        time.Duration
 		// proof session info
 		nonce int
 		// for keygen
 		noProofMod bool
 		noProofFac bool
+		// for ECDSA signing
+		noLowS bool
 	}
 
 	ReSharingParameters struct {
@@ -81,6 +84,10 @@ func (params *Parameters) SafePrimeGenTimeout() time.Duration {
 	return params.safePrimeGenTimeout
 }
 
+func (params *Parameters) RoundTimeout() time.Duration {
+	return params.roundTimeout
+}
+
 // The concurrency level must be >= 1.
 func (params *Parameters) SetConcurrency(concurrency int) {
 	params.concurrency = concurrency
@@ -90,6 +97,11 @@ func (params *Parameters) SetSafePrimeGenTimeout(timeout time.Duration) {
 	params.safePrimeGenTimeout = timeout
 }
 
+// The round timeout applies to parties started with StartWithContext; a timeout of 0 (the default) disables it.
+func (params *Parameters) SetRoundTimeout(timeout time.Duration) {
+	params.roundTimeout = timeout
+}
+
 func (params *Parameters) NoProofMod() bool {
 	return params.noProofMod
 }
@@ -106,6 +118,16 @@ func (params *Parameters) SetNoProofFac() {
 	params.noProofFac = true
 }
 
+func (params *Parameters) NoLowS() bool {
+	return params.noLowS
+}
+
+// By default ECDSA signing outputs the low-S form of a signature (s <= N/2), which Bitcoin and Ethereum require on
+// secp256k1. SetNoLowS keeps s as it was computed; verifiers on the NIST curves, e.g. WebAuthn, accept both forms.
+func (params *Parameters) SetNoLowS() {
+	params.noLowS = true
+}
+
 // ----- //
 
 // Exported, used in `tss` client
@@ -7,6 +7,7 @@
 package tss
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"sync"
@@ -14,8 +15,15 @@ import (
 	"github.com/bnb-chain/tss-lib/v2/common"
 )
 
+// ErrRoundTimeout is the cause of the error returned when a party started with StartWithContext
+// did not complete a round within the round timeout set in its Parameters.
+var ErrRoundTimeout = errors.New("the round did not complete before its deadline")
+
 type Party interface {
 	Start() *Error
+	// StartWithContext starts the party and aborts it when `ctx` is done or a round exceeds Parameters.RoundTimeout().
+	// The abort error is sent to `errCh`; its culprits are the parties that the round was still waiting for.
+	StartWithContext(ctx context.Context, errCh chan<- *Error) *Error
 	// The main entry point when updating a party's state from the wire.
 	// isBroadcast should represent whether the message was received via a reliable broadcast
 	UpdateFromBytes(wireBytes []byte, from *PartyID, isBroadcast bool) (ok bool, err *Error)
@@ -26,6 +34,10 @@ type Party interface {
 	ValidateMessage(msg ParsedMessage) (bool, *Error)
 	StoreMessage(msg ParsedMessage) (bool, *Error)
 	FirstRound() Round
+	// Snapshot exports the in-flight state of the party encrypted with `key`; restore it with the protocol's RestoreLocalParty
+	Snapshot(key []byte) ([]byte, *Error)
+	// ResumeWithContext watches a party restored from a snapshot like StartWithContext; the party is not started again
+	ResumeWithContext(ctx context.Context, errCh chan<- *Error) *Error
 	WrapError(err error, culprits ...*PartyID) *Error
 	PartyID() *PartyID
 	String() string
@@ -34,17 +46,34 @@ type Party interface {
 	setRound(Round) *Error
 	round() Round
 	advance()
+	end()
 	lock()
 	unlock()
+	setContext(context.Context, chan<- *Error)
+	setRestored(task string)
+	abortError() *Error
+	watch(task string)
+	unwatch()
 }
 
 type BaseParty struct {
 	mtx        sync.Mutex
 	rnd        Round
 	FirstRound Round
+
+	// set by StartWithContext
+	ctx         context.Context
+	errCh       chan<- *Error
+	cancelWatch context.CancelFunc
+	abortErr    *Error
+
+	// set by a restore; the task of the restored party
+	restoredTask string
 }
 
 func (p *BaseParty) Running() bool {
+	p.lock()
+	defer p.unlock()
 	return p.rnd != nil
 }
 
@@ -105,6 +134,12 @@ func (p *BaseParty) advance() {
 	p.rnd = p.rnd.NextRound()
 }
 
+// end stops a party whose round has failed to start. must be called with the party locked
+func (p *BaseParty) end() {
+	p.rnd = nil
+	p.unwatch()
+}
+
 func (p *BaseParty) lock() {
 	p.mtx.Lock()
 }
@@ -113,6 +148,65 @@ func (p *BaseParty) unlock() {
 	p.mtx.Unlock()
 }
 
+func (p *BaseParty) setContext(ctx context.Context, errCh chan<- *Error) {
+	p.ctx, p.errCh = ctx, errCh
+}
+
+func (p *BaseParty) setRestored(task string) {
+	p.restoredTask = task
+}
+
+func (p *BaseParty) abortError() *Error {
+	return p.abortErr
+}
+
+// watch aborts the party if the context is done or the round timeout passes before the current round completes.
+// must be called with the party locked
+func (p *BaseParty) watch(task string) {
+	p.unwatch()
+	if p.ctx == nil || p.rnd == nil {
+		return
+	}
+	var ctx context.Context
+	if timeout := p.rnd.Params().RoundTimeout(); 0 < timeout {
+		ctx, p.cancelWatch = context.WithTimeout(p.ctx, timeout)
+	} else {
+		ctx, p.cancelWatch = context.WithCancel(p.ctx)
+	}
+	go p.abortWhenDone(ctx, p.rnd, task)
+}
+
+func (p *BaseParty) unwatch() {
+	if p.cancelWatch != nil {
+		p.cancelWatch()
+		p.cancelWatch = nil
+	}
+}
+
+func (p *BaseParty) abortWhenDone(ctx context.Context, rnd Round, task string) {
+	<-ctx.Done()
+	p.lock()
+	if p.rnd != rnd || (ctx.Err() == context.Canceled && p.ctx.Err() == nil) {
+		// the round has completed or failed, or the watch was cancelled by unwatch; nothing to abort
+		p.unlock()
+		return
+	}
+	cause := p.ctx.Err()
+	if cause == nil {
+		cause = ErrRoundTimeout
+	}
+	err := rnd.WrapError(cause, rnd.WaitingFor()...)
+	p.abortErr = err
+	p.rnd = nil
+	p.unwatch()
+	errCh := p.errCh
+	p.unlock()
+	common.Logger.Warningf("party %s: %s aborted in round %d: %s", rnd.Params().PartyID(), task, rnd.RoundNumber(), cause)
+	if errCh != nil {
+		errCh <- err
+	}
+}
+
 // ----- //
 
 func BaseStart(p Party, task string, prepare ...func(Round) *Error) *Error {
@@ -140,10 +234,56 @@ func BaseStart(p Party, task string, prepare ...func(Round) *Error) *Error {
 	defer func() {
 		common.Logger.Debugf("party %s: %s round %d finished", p.round().Params().PartyID(), task, 1)
 	}()
-	return p.round().Start()
+	if err := p.round().Start(); err != nil {
+		return err
+	}
+	p.watch(task)
+	return nil
+}
+
+// BaseStartWithContext is like BaseStart, but the party is aborted when `ctx` is done or a round does not complete
+// within Parameters.RoundTimeout(). An aborted party stops running, the abort error is sent to `errCh` (if not nil) and
+// it is returned by any later Update. The culprits of the abort error are the parties that the round was waiting for.
+func BaseStartWithContext(ctx context.Context, p Party, task string, errCh chan<- *Error, prepare ...func(Round) *Error) *Error {
+	if ctx == nil {
+		return p.WrapError(errors.New("could not start. a nil context was given"))
+	}
+	if err := ctx.Err(); err != nil {
+		return p.WrapError(err)
+	}
+	p.lock()
+	p.setContext(ctx, errCh)
+	p.unlock()
+	return BaseStart(p, task, prepare...)
 }
 
-// an implementation of Update that is shared across the different types of parties (keygen, signing, dynamic groups)
+// ResumeWithContext aborts a party restored from a snapshot when `ctx` is done or its round does not complete within
+// Parameters.RoundTimeout(), like BaseStartWithContext. The timeout of the round in which the party was restored starts
+// when it is resumed.
+func (p *BaseParty) ResumeWithContext(ctx context.Context, errCh chan<- *Error) *Error {
+	if ctx == nil {
+		return p.WrapError(errors.New("could not resume. a nil context was given"))
+	}
+	if err := ctx.Err(); err != nil {
+		return p.WrapError(err)
+	}
+	p.lock()
+	defer p.unlock()
+	if p.restoredTask == "" || p.rnd == nil {
+		return p.WrapError(errors.New("could not resume. this party was not restored from a snapshot or is not running"))
+	}
+	if p.ctx != nil {
+		return p.WrapError(errors.New("could not resume. this party is already watched by a context"))
+	}
+	p.setContext(ctx, errCh)
+	p.watch(p.restoredTask)
+	return nil
+}
+
+// an implementation of Update that is shared across the different types of parties (keygen, signing, dynamic groups).
+// a rejected message leaves the watch set up by StartWithContext armed, as the party keeps running and waiting for its
+// peers; the watch stops only when the party ends.
+// a round that fails to start ends the party: it stops running, and later messages are stored but not processed
 func BaseUpdate(p Party, msg ParsedMessage, task string) (ok bool, err *Error) {
 	// fast-fail on an invalid message; do not lock the mutex yet
 	if _, err := p.ValidateMessage(msg); err != nil {
@@ -155,6 +295,9 @@ func BaseUpdate(p Party, msg ParsedMessage, task string) (ok bool, err *Error) {
 		return ok, err
 	}
 	p.lock() // data is written to P state below
+	if err := p.abortError(); err != nil {
+		return r(false, err)
+	}
 	common.Logger.Debugf("party %s received message: %s", p.PartyID(), msg.String())
 	if p.round() != nil {
 		common.Logger.Debugf("party %s round %d update: %s", p.PartyID(), p.round().RoundNumber(), msg.String())
@@ -170,12 +313,15 @@ func BaseUpdate(p Party, msg ParsedMessage, task string) (ok bool, err *Error) {
 		if p.round().CanProceed() {
 			if p.advance(); p.round() != nil {
 				if err := p.round().Start(); err != nil {
+					p.end()
 					return r(false, err)
 				}
+				p.watch(task)
 				rndNum := p.round().RoundNumber()
 				common.Logger.Infof("party %s: %s round %d started", p.round().Params().PartyID(), task, rndNum)
 			} else {
 				// finished! the round implementation will have sent the data through the `end` channel.
+				p.unwatch()
 				common.Logger.Infof("party %s: %s finished!", p.PartyID(), task)
 			}
 			p.unlock()                      // recursive so can't defer after return
@@ -0,0 +1,209 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v3.20.3
+// source: protob/save-data.proto
+
+package tss
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Self-describing container of the save data of a party, as written to disk.
+// The `data` is the protocol-specific save data message, encoded in the format `version` of the `protocol`.
+type SaveDataFile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version   uint32   `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Protocol  string   `protobuf:"bytes,2,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	Curve     string   `protobuf:"bytes,3,opt,name=curve,proto3" json:"curve,omitempty"`
+	Threshold uint32   `protobuf:"varint,4,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	Ks        [][]byte `protobuf:"bytes,5,rep,name=ks,proto3" json:"ks,omitempty"`
+	Data      []byte   `protobuf:"bytes,6,opt,name=data,proto3" json:"data,omitempty"`
+	// SHA-512/256 of all the fields above
+	Checksum []byte `protobuf:"bytes,7,opt,name=checksum,proto3" json:"checksum,omitempty"`
+}
+
+func (x *SaveDataFile) Reset() {
+	*x = SaveDataFile{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_save_data_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SaveDataFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveDataFile) ProtoMessage() {}
+
+func (x *SaveDataFile) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_save_data_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveDataFile.ProtoReflect.Descriptor instead.
+func (*SaveDataFile) Descriptor() ([]byte, []int) {
+	return file_protob_save_data_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SaveDataFile) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *SaveDataFile) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+func (x *SaveDataFile) GetCurve() string {
+	if x != nil {
+		return x.Curve
+	}
+	return ""
+}
+
+func (x *SaveDataFile) GetThreshold() uint32 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *SaveDataFile) GetKs() [][]byte {
+	if x != nil {
+		return x.Ks
+	}
+	return nil
+}
+
+func (x *SaveDataFile) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *SaveDataFile) GetChecksum() []byte {
+	if x != nil {
+		return x.Checksum
+	}
+	return nil
+}
+
+var File_protob_save_data_proto protoreflect.FileDescriptor
+
+var file_protob_save_data_proto_rawDesc = []byte{
+	0x0a, 0x16, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x2f, 0x73, 0x61, 0x76, 0x65, 0x2d, 0x64, 0x61,
+	0x74, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0e, 0x62, 0x69, 0x6e, 0x61, 0x6e, 0x63,
+	0x65, 0x2e, 0x74, 0x73, 0x73, 0x6c, 0x69, 0x62, 0x22, 0xb8, 0x01, 0x0a, 0x0c, 0x53, 0x61, 0x76,
+	0x65, 0x44, 0x61, 0x74, 0x61, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12,
+	0x14, 0x0a, 0x05, 0x63, 0x75, 0x72, 0x76, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x63, 0x75, 0x72, 0x76, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f,
+	0x6c, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68,
+	0x6f, 0x6c, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x6b, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0c, 0x52,
+	0x02, 0x6b, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b,
+	0x73, 0x75, 0x6d, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b,
+	0x73, 0x75, 0x6d, 0x42, 0x07, 0x5a, 0x05, 0x2e, 0x2f, 0x74, 0x73, 0x73, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_protob_save_data_proto_rawDescOnce sync.Once
+	file_protob_save_data_proto_rawDescData = file_protob_save_data_proto_rawDesc
+)
+
+func file_protob_save_data_proto_rawDescGZIP() []byte {
+	file_protob_save_data_proto_rawDescOnce.Do(func() {
+		file_protob_save_data_proto_rawDescData = protoimpl.X.CompressGZIP(file_protob_save_data_proto_rawDescData)
+	})
+	return file_protob_save_data_proto_rawDescData
+}
+
+var file_protob_save_data_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_protob_save_data_proto_goTypes = []interface{}{
+	(*SaveDataFile)(nil), // 0: binance.tsslib.SaveDataFile
+}
+var file_protob_save_data_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_protob_save_data_proto_init() }
+func file_protob_save_data_proto_init() {
+	if File_protob_save_data_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_protob_save_data_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SaveDataFile); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_protob_save_data_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_protob_save_data_proto_goTypes,
+		DependencyIndexes: file_protob_save_data_proto_depIdxs,
+		MessageInfos:      file_protob_save_data_proto_msgTypes,
+	}.Build()
+	File_protob_save_data_proto = out.File
+	file_protob_save_data_proto_rawDesc = nil
+	file_protob_save_data_proto_goTypes = nil
+	file_protob_save_data_proto_depIdxs = nil
+}
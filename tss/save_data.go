@@ -0,0 +1,91 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package tss
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+)
+
+// MarshalSaveDataFile wraps the protocol specific save data `data`, encoded in the format `version` of `protocol`,
+// in a SaveDataFile that also describes the curve, the threshold and the party keys `ks` of the key, and adds a checksum.
+// It is used by the keygen packages to persist their LocalPartySaveData.
+func MarshalSaveDataFile(version int, protocol string, ec elliptic.Curve, threshold int, ks []*big.Int, data []byte) ([]byte, error) {
+	curveName, ok := GetCurveName(ec)
+	if !ok {
+		return nil, fmt.Errorf("cannot find %T name in curve registry, please call tss.RegisterCurve(name, curve) to register it first", ec)
+	}
+	if threshold < 0 || len(ks) <= threshold {
+		return nil, fmt.Errorf("the threshold %d is invalid for %d parties", threshold, len(ks))
+	}
+	for _, k := range ks {
+		if k == nil {
+			return nil, errors.New("the party keys are incomplete")
+		}
+	}
+	file := &SaveDataFile{
+		Version:   uint32(version),
+		Protocol:  protocol,
+		Curve:     string(curveName),
+		Threshold: uint32(threshold),
+		Ks:        common.BigIntsToBytes(ks),
+		Data:      data,
+	}
+	file.Checksum = file.checksum()
+	return proto.Marshal(file)
+}
+
+// UnmarshalSaveDataFile parses a SaveDataFile written by MarshalSaveDataFile and verifies that it is intact and that
+// it holds save data of `protocol`. The caller is expected to decode `Data` according to `Version`.
+func UnmarshalSaveDataFile(bz []byte, protocol string) (*SaveDataFile, error) {
+	file := new(SaveDataFile)
+	if err := proto.Unmarshal(bz, file); err != nil {
+		return nil, fmt.Errorf("the save data could not be parsed: %v", err)
+	}
+	if !bytes.Equal(file.GetChecksum(), file.checksum()) {
+		return nil, errors.New("the save data is corrupted: the checksum does not match")
+	}
+	if file.GetProtocol() != protocol {
+		return nil, fmt.Errorf("the save data belongs to the %q protocol, not %q", file.GetProtocol(), protocol)
+	}
+	if _, ok := GetCurveByName(CurveName(file.GetCurve())); !ok {
+		return nil, fmt.Errorf("cannot find curve named with %s in curve registry, please call tss.RegisterCurve(name, curve) to register it first", file.GetCurve())
+	}
+	if len(file.GetKs()) <= int(file.GetThreshold()) {
+		return nil, fmt.Errorf("the threshold %d is invalid for %d parties", file.GetThreshold(), len(file.GetKs()))
+	}
+	return file, nil
+}
+
+// EC returns the curve of the key in the file
+func (m *SaveDataFile) EC() elliptic.Curve {
+	ec, _ := GetCurveByName(CurveName(m.GetCurve()))
+	return ec
+}
+
+// UnmarshalKs returns the keys of the parties of the key in the file
+func (m *SaveDataFile) UnmarshalKs() []*big.Int {
+	return common.MultiBytesToBigInts(m.GetKs())
+}
+
+func (m *SaveDataFile) checksum() []byte {
+	header := make([]byte, 8)
+	binary.BigEndian.PutUint32(header[:4], m.GetVersion())
+	binary.BigEndian.PutUint32(header[4:], m.GetThreshold())
+	in := make([][]byte, 0, len(m.GetKs())+4)
+	in = append(in, header, []byte(m.GetProtocol()), []byte(m.GetCurve()), m.GetData())
+	in = append(in, m.GetKs()...)
+	return common.SHA512_256(in...)
+}
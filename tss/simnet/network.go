@@ -0,0 +1,364 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package simnet is an in-process network for running the parties of any protocol in this library together,
+// e.g. in tests. It routes messages the way a real transport must, including the old/new committee routing used
+// by resharing, and can inject drops, delays, reordering, duplication and partitions.
+package simnet
+
+import (
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// Faults configures the faults injected by a Network. The zero value is a perfect network that delivers every
+// message exactly once, in the order it was sent.
+type Faults struct {
+	// DropRate is the probability that a delivery to a party is lost
+	DropRate float64
+	// DuplicateRate is the probability that a delivery to a party happens twice
+	DuplicateRate float64
+	// ReorderRate is the probability that a delivery is held back and released after the next one
+	ReorderRate float64
+	// every delivery is delayed by a random duration in [MinDelay, MaxDelay]
+	MinDelay, MaxDelay time.Duration
+	// Seed seeds the random source used to inject the faults
+	Seed int64
+}
+
+// Stats counts the deliveries made by a Network; a broadcast counts once per recipient.
+type Stats struct {
+	Sent, Delivered, Dropped, Duplicated, Reordered int
+}
+
+// Network hosts a set of parties and delivers the messages they send to each other.
+// Pass Out() as the `out` channel to the party constructors, add the parties and call Start().
+type Network struct {
+	mtx          sync.Mutex
+	parties      []tss.Party // the only committee, or the new committee during resharing
+	oldCommittee []tss.Party // only used by resharing
+	out          chan tss.Message
+	errCh        chan *tss.Error
+	quit         chan struct{}
+	wg           sync.WaitGroup
+
+	faults   Faults
+	rand     *rand.Rand
+	isolated map[string]bool
+	held     []func()
+	stats    Stats
+	started  bool
+	stopped  bool
+	queue    []tss.Message
+	queued   chan struct{}
+}
+
+// NewNetwork returns a network that injects the given faults.
+func NewNetwork(faults Faults) *Network {
+	return &Network{
+		out:      make(chan tss.Message, 1024),
+		errCh:    make(chan *tss.Error, 1024),
+		quit:     make(chan struct{}),
+		faults:   faults,
+		rand:     rand.New(rand.NewSource(faults.Seed)),
+		isolated: make(map[string]bool),
+		queued:   make(chan struct{}, 1),
+	}
+}
+
+// Out returns the channel that the hosted parties must send their messages to.
+func (n *Network) Out() chan<- tss.Message {
+	return n.out
+}
+
+// Errors returns the channel that receives the errors returned by the parties while starting or updating.
+func (n *Network) Errors() <-chan *tss.Error {
+	return n.errCh
+}
+
+// AddParties adds parties to the network. A party must be added at the index of its PartyID.
+// For resharing these are the parties of the new committee.
+func (n *Network) AddParties(parties ...tss.Party) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	n.parties = append(n.parties, parties...)
+}
+
+// AddOldCommittee adds the parties of the old committee of a resharing. A party must be added at the index of its PartyID.
+func (n *Network) AddOldCommittee(parties ...tss.Party) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	n.oldCommittee = append(n.oldCommittee, parties...)
+}
+
+// SetFaults replaces the faults injected from now on.
+func (n *Network) SetFaults(faults Faults) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	n.faults = faults
+}
+
+// Partition cuts the given parties off from the rest of the network until Heal is called.
+// The given parties can still reach each other.
+func (n *Network) Partition(pIDs ...*tss.PartyID) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	for _, pID := range pIDs {
+		n.isolated[partyKey(pID)] = true
+	}
+}
+
+// Heal removes all partitions.
+func (n *Network) Heal() {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	n.isolated = make(map[string]bool)
+}
+
+// Stats returns the deliveries counted so far.
+func (n *Network) Stats() Stats {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	return n.stats
+}
+
+// Start starts routing messages and then starts every party that is not running yet, the new committee before the old one.
+// Parties that need StartWithContext should be started by the caller before calling Start.
+func (n *Network) Start() error {
+	n.mtx.Lock()
+	if n.started {
+		n.mtx.Unlock()
+		return errors.New("the network has already been started")
+	}
+	n.started = true
+	parties := append(append([]tss.Party{}, n.parties...), n.oldCommittee...)
+	n.mtx.Unlock()
+
+	n.wg.Add(2)
+	go n.receive()
+	go n.route()
+	for _, P := range parties {
+		if P.Running() {
+			continue
+		}
+		go func(P tss.Party) {
+			if err := P.Start(); err != nil {
+				n.reportError(err)
+			}
+		}(P)
+	}
+	return nil
+}
+
+// Stop stops routing messages. Messages that have not been delivered yet are discarded.
+func (n *Network) Stop() {
+	n.mtx.Lock()
+	if n.stopped {
+		n.mtx.Unlock()
+		return
+	}
+	n.stopped = true
+	n.mtx.Unlock()
+	close(n.quit)
+	n.wg.Wait()
+}
+
+// ----- //
+
+// receive moves the sent messages to an unbounded queue so that a party sending messages never waits for a delivery
+func (n *Network) receive() {
+	defer n.wg.Done()
+	for {
+		select {
+		case <-n.quit:
+			return
+		case msg := <-n.out:
+			n.mtx.Lock()
+			n.queue = append(n.queue, msg)
+			n.mtx.Unlock()
+			select {
+			case n.queued <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
+
+func (n *Network) route() {
+	defer n.wg.Done()
+	for {
+		n.mtx.Lock()
+		if len(n.queue) == 0 {
+			n.mtx.Unlock()
+			select {
+			case <-n.quit:
+				return
+			case <-n.queued:
+				continue
+			}
+		}
+		msg := n.queue[0]
+		n.queue = n.queue[1:]
+		n.mtx.Unlock()
+
+		recipients, err := n.recipients(msg)
+		if err != nil {
+			n.reportError(tss.NewError(err, "simnet", -1, nil, msg.GetFrom()))
+			continue
+		}
+		for _, P := range recipients {
+			for _, deliver := range n.send(P, msg) {
+				deliver()
+			}
+		}
+	}
+}
+
+// recipients returns the parties that `msg` must be delivered to
+func (n *Network) recipients(msg tss.Message) ([]tss.Party, error) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	dest := msg.GetTo()
+	if len(n.oldCommittee) == 0 {
+		if dest == nil {
+			recipients := make([]tss.Party, 0, len(n.parties))
+			for _, P := range n.parties {
+				if P.PartyID().Index != msg.GetFrom().Index {
+					recipients = append(recipients, P)
+				}
+			}
+			return recipients, nil
+		}
+		return pick(n.parties, dest)
+	}
+	// resharing
+	if dest == nil {
+		return nil, errors.New("did not expect a msg to have a nil destination during resharing")
+	}
+	var recipients []tss.Party
+	if msg.IsToOldCommittee() || msg.IsToOldAndNewCommittees() {
+		if len(n.oldCommittee) < len(dest) {
+			dest = dest[:len(n.oldCommittee)]
+		}
+		old, err := pick(n.oldCommittee, dest)
+		if err != nil {
+			return nil, err
+		}
+		recipients = append(recipients, old...)
+	}
+	if !msg.IsToOldCommittee() || msg.IsToOldAndNewCommittees() {
+		parties, err := pick(n.parties, msg.GetTo())
+		if err != nil {
+			return nil, err
+		}
+		recipients = append(recipients, parties...)
+	}
+	return recipients, nil
+}
+
+func pick(committee []tss.Party, dest []*tss.PartyID) ([]tss.Party, error) {
+	recipients := make([]tss.Party, 0, len(dest))
+	for _, pID := range dest {
+		if pID.Index < 0 || len(committee) <= pID.Index {
+			return nil, errors.New("a message was sent to a party that is not in the network")
+		}
+		recipients = append(recipients, committee[pID.Index])
+	}
+	return recipients, nil
+}
+
+// send applies the faults to a single delivery of `msg` to `P`. it returns the deliveries to run now, in order.
+func (n *Network) send(P tss.Party, msg tss.Message) []func() {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	n.stats.Sent++
+	if n.isolated[partyKey(P.PartyID())] != n.isolated[partyKey(msg.GetFrom())] ||
+		n.rand.Float64() < n.faults.DropRate {
+		n.stats.Dropped++
+		return nil
+	}
+	copies := 1
+	if n.rand.Float64() < n.faults.DuplicateRate {
+		copies++
+		n.stats.Duplicated++
+	}
+	delay := n.faults.MinDelay
+	if span := n.faults.MaxDelay - n.faults.MinDelay; 0 < span {
+		delay += time.Duration(n.rand.Int63n(int64(span) + 1))
+	}
+	deliver := n.schedule(delay, func() {
+		for i := 0; i < copies; i++ {
+			n.deliver(P, msg)
+		}
+	})
+	if n.rand.Float64() < n.faults.ReorderRate {
+		n.stats.Reordered++
+		n.held = append(n.held, deliver)
+		return nil
+	}
+	// deliveries held back are released after this one
+	deliveries := append([]func(){deliver}, n.held...)
+	n.held = nil
+	return deliveries
+}
+
+// schedule wraps `deliver` so that it runs after `delay` without blocking the routing of the other messages
+func (n *Network) schedule(delay time.Duration, deliver func()) func() {
+	if delay <= 0 {
+		return deliver
+	}
+	return func() {
+		n.wg.Add(1)
+		go func() {
+			defer n.wg.Done()
+			select {
+			case <-n.quit:
+			case <-time.After(delay):
+				deliver()
+			}
+		}()
+	}
+}
+
+// deliver re-parses the wire bytes of `msg` like a real transport would and updates `P` with them
+func (n *Network) deliver(P tss.Party, msg tss.Message) {
+	select {
+	case <-n.quit:
+		return
+	default:
+	}
+	bz, _, err := msg.WireBytes()
+	if err != nil {
+		n.reportError(P.WrapError(err))
+		return
+	}
+	pMsg, err := tss.ParseWireMessage(bz, msg.GetFrom(), msg.IsBroadcast())
+	if err != nil {
+		n.reportError(P.WrapError(err))
+		return
+	}
+	n.mtx.Lock()
+	n.stats.Delivered++
+	n.mtx.Unlock()
+	if _, err := P.Update(pMsg); err != nil {
+		n.reportError(err)
+	}
+}
+
+func (n *Network) reportError(err *tss.Error) {
+	select {
+	case n.errCh <- err:
+	case <-n.quit:
+	}
+}
+
+func partyKey(pID *tss.PartyID) string {
+	return string(pID.Key)
+}
@@ -0,0 +1,199 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package simnet
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/eddsa/resharing"
+	"github.com/bnb-chain/tss-lib/v2/eddsa/signing"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+
+	// only for test
+	tss.SetCurve(tss.Edwards())
+}
+
+func TestKeygenWithFaults(t *testing.T) {
+	setUp("info")
+
+	net := NewNetwork(Faults{
+		DuplicateRate: 0.3,
+		ReorderRate:   0.3,
+		MaxDelay:      10 * time.Millisecond,
+		Seed:          1,
+	})
+	defer net.Stop()
+
+	pIDs := tss.GenerateTestPartyIDs(testParticipants)
+	p2pCtx := tss.NewPeerContext(pIDs)
+	endCh := make(chan *keygen.LocalPartySaveData, len(pIDs))
+	for _, pID := range pIDs {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, pID, len(pIDs), testThreshold)
+		net.AddParties(keygen.NewLocalParty(params, net.Out(), endCh))
+	}
+	assert.NoError(t, net.Start())
+	assert.Error(t, net.Start(), "should not start twice")
+
+	saves := make([]*keygen.LocalPartySaveData, 0, len(pIDs))
+	for len(saves) < len(pIDs) {
+		select {
+		case err := <-net.Errors():
+			assert.FailNow(t, err.Error())
+		case save := <-endCh:
+			saves = append(saves, save)
+		}
+	}
+	for _, save := range saves {
+		assert.True(t, save.EDDSAPub.Equals(saves[0].EDDSAPub), "all parties should agree on the public key")
+	}
+	stats := net.Stats()
+	assert.Less(t, 0, stats.Duplicated)
+	assert.Less(t, 0, stats.Reordered)
+	assert.Equal(t, 0, stats.Dropped)
+	assert.Equal(t, stats.Sent+stats.Duplicated, stats.Delivered)
+}
+
+func TestResharing(t *testing.T) {
+	setUp("info")
+
+	oldKeys, oldPIDs, err := keygen.LoadKeygenTestFixtures(testThreshold + 2)
+	assert.NoError(t, err, "should load keygen fixtures")
+	oldP2PCtx := tss.NewPeerContext(oldPIDs)
+	newPIDs := tss.GenerateTestPartyIDs(testParticipants)
+	newP2PCtx := tss.NewPeerContext(newPIDs)
+
+	net := NewNetwork(Faults{ReorderRate: 0.2, Seed: 2})
+	defer net.Stop()
+
+	endCh := make(chan *keygen.LocalPartySaveData, len(oldPIDs)+len(newPIDs))
+	for j, pID := range oldPIDs {
+		params := tss.NewReSharingParameters(tss.Edwards(), oldP2PCtx, newP2PCtx, pID, testParticipants, testThreshold, len(newPIDs), testThreshold)
+		net.AddOldCommittee(resharing.NewLocalParty(params, oldKeys[j], net.Out(), endCh))
+	}
+	for _, pID := range newPIDs {
+		params := tss.NewReSharingParameters(tss.Edwards(), oldP2PCtx, newP2PCtx, pID, testParticipants, testThreshold, len(newPIDs), testThreshold)
+		net.AddParties(resharing.NewLocalParty(params, keygen.NewLocalPartySaveData(len(newPIDs)), net.Out(), endCh))
+	}
+	assert.NoError(t, net.Start())
+
+	var newKeys []*keygen.LocalPartySaveData
+	for ended := 0; ended < len(oldPIDs)+len(newPIDs); ended++ {
+		select {
+		case err := <-net.Errors():
+			assert.FailNow(t, err.Error())
+		case save := <-endCh:
+			// old committee members that aren't receiving a share have their Xi zeroed
+			if save.Xi != nil {
+				newKeys = append(newKeys, save)
+			}
+		}
+	}
+	assert.Equal(t, len(newPIDs), len(newKeys))
+	for _, save := range newKeys {
+		assert.True(t, save.EDDSAPub.Equals(oldKeys[0].EDDSAPub), "the public key should not change")
+	}
+}
+
+func TestSigningWithDuplicates(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(signPIDs)
+
+	net := NewNetwork(Faults{DuplicateRate: 1, Seed: 3})
+	defer net.Stop()
+
+	msg := big.NewInt(42)
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+	for i, pID := range signPIDs {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, pID, len(signPIDs), testThreshold)
+		net.AddParties(signing.NewLocalParty(msg, params, keys[i], net.Out(), endCh))
+	}
+	assert.NoError(t, net.Start())
+
+	pk := edwards.PublicKey{
+		Curve: tss.Edwards(),
+		X:     keys[0].EDDSAPub.X(),
+		Y:     keys[0].EDDSAPub.Y(),
+	}
+	for ended := 0; ended < len(signPIDs); ended++ {
+		select {
+		case err := <-net.Errors():
+			assert.FailNow(t, err.Error())
+		case data := <-endCh:
+			sig, err := edwards.ParseSignature(data.Signature)
+			assert.NoError(t, err)
+			assert.True(t, edwards.Verify(&pk, msg.Bytes(), sig.R, sig.S), "eddsa verify must pass")
+		}
+	}
+}
+
+func TestPartition(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(signPIDs)
+
+	net := NewNetwork(Faults{})
+	defer net.Stop()
+
+	isolated := signPIDs[0]
+	net.Partition(isolated)
+
+	abortCh := make(chan *tss.Error, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+	for i, pID := range signPIDs {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, pID, len(signPIDs), testThreshold)
+		params.SetRoundTimeout(500 * time.Millisecond)
+		P := signing.NewLocalParty(big.NewInt(42), params, keys[i], net.Out(), endCh)
+		net.AddParties(P)
+		if err := P.StartWithContext(context.Background(), abortCh); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+	}
+	assert.NoError(t, net.Start())
+
+	for aborted := 0; aborted < len(signPIDs); aborted++ {
+		select {
+		case err := <-abortCh:
+			assert.True(t, errors.Is(err, tss.ErrRoundTimeout), "expected a round timeout, got: %s", err)
+			assert.Equal(t, 1, err.Round())
+			if err.Victim().Index == isolated.Index {
+				assert.Equal(t, len(signPIDs)-1, len(err.Culprits()))
+			} else {
+				assert.Equal(t, []*tss.PartyID{isolated}, err.Culprits())
+			}
+		case <-endCh:
+			assert.FailNow(t, "signing should not have finished")
+		}
+	}
+	assert.Less(t, 0, net.Stats().Dropped)
+}
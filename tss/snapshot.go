@@ -0,0 +1,205 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package tss
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+)
+
+const (
+	// SnapshotVersion is the version of the snapshot format written by Snapshot(). It changes with the state of any
+	// protocol, so that a snapshot taken by an older version is rejected instead of being restored inconsistently.
+	SnapshotVersion = 3
+
+	// SnapshotKeyLength is the required length of the key used to encrypt and decrypt snapshots.
+	SnapshotKeyLength = 32
+)
+
+type (
+	// SnapshotMessage is the serialized form of a received message that is kept in a party snapshot.
+	SnapshotMessage struct {
+		FromIndex int
+		Wire      []byte
+	}
+
+	snapshotPayload struct {
+		Task     string
+		PartyKey []byte
+		Round    int
+		State    json.RawMessage
+	}
+)
+
+// BaseSnapshot exports the state of a running party as an encrypted and versioned snapshot.
+// `marshal` must return the protocol specific state of the party as a JSON-serializable value.
+// The party is locked while the snapshot is being taken, so the snapshot is always consistent with a completed Update().
+func BaseSnapshot(p Party, task string, key []byte, marshal func(Round) (interface{}, error)) ([]byte, *Error) {
+	p.lock()
+	defer p.unlock()
+	if p.round() == nil {
+		return nil, p.WrapError(errors.New("could not snapshot. this party is not running"))
+	}
+	state, err := marshal(p.round())
+	if err != nil {
+		return nil, p.WrapError(err)
+	}
+	stateBz, err := json.Marshal(state)
+	if err != nil {
+		return nil, p.WrapError(err)
+	}
+	payloadBz, err := json.Marshal(&snapshotPayload{
+		Task:     task,
+		PartyKey: p.PartyID().GetKey(),
+		Round:    p.round().RoundNumber(),
+		State:    stateBz,
+	})
+	if err != nil {
+		return nil, p.WrapError(err)
+	}
+	snapshot, err := sealSnapshot(key, payloadBz)
+	if err != nil {
+		return nil, p.WrapError(err)
+	}
+	return snapshot, nil
+}
+
+// BaseRestore rebuilds the state of a party from a snapshot created by BaseSnapshot.
+// `unmarshal` receives the protocol specific state and the round number, and must return the round to continue from.
+// The returned round is expected to have been started already; it will not be started again.
+// The context of StartWithContext is not part of the snapshot; call ResumeWithContext on the restored party to watch it.
+func BaseRestore(p Party, task string, snapshot, key []byte, unmarshal func(state []byte, number int) (Round, error)) *Error {
+	p.lock()
+	defer p.unlock()
+	if p.PartyID() == nil || !p.PartyID().ValidateBasic() {
+		return p.WrapError(fmt.Errorf("could not restore. this party has an invalid PartyID: %+v", p.PartyID()))
+	}
+	if p.round() != nil {
+		return p.WrapError(errors.New("could not restore. this party is in an unexpected state. use the restore constructor"))
+	}
+	payloadBz, err := openSnapshot(key, snapshot)
+	if err != nil {
+		return p.WrapError(err)
+	}
+	payload := new(snapshotPayload)
+	if err = json.Unmarshal(payloadBz, payload); err != nil {
+		return p.WrapError(err)
+	}
+	if payload.Task != task {
+		return p.WrapError(fmt.Errorf("could not restore. the snapshot is for task %q, expected %q", payload.Task, task))
+	}
+	if !bytes.Equal(payload.PartyKey, p.PartyID().GetKey()) {
+		return p.WrapError(errors.New("could not restore. the snapshot was taken by a different party"))
+	}
+	round, err := unmarshal(payload.State, payload.Round)
+	if err != nil {
+		return p.WrapError(err)
+	}
+	if err := p.setRound(round); err != nil {
+		return err
+	}
+	p.setRestored(task)
+	common.Logger.Infof("party %s: %s restored in round %d", p.PartyID(), task, payload.Round)
+	return nil
+}
+
+// NewSnapshotMessages converts a message store into a form that can be kept in a snapshot. Absent messages remain nil.
+func NewSnapshotMessages(msgs []ParsedMessage) ([]*SnapshotMessage, error) {
+	out := make([]*SnapshotMessage, len(msgs))
+	for j, msg := range msgs {
+		if msg == nil {
+			continue
+		}
+		wire, err := proto.Marshal(msg.WireMsg())
+		if err != nil {
+			return nil, err
+		}
+		out[j] = &SnapshotMessage{FromIndex: msg.GetFrom().Index, Wire: wire}
+	}
+	return out, nil
+}
+
+// ParseSnapshotMessages converts messages kept in a snapshot back into a message store of the expected length.
+func ParseSnapshotMessages(sms []*SnapshotMessage, length int) ([]ParsedMessage, error) {
+	if len(sms) != length {
+		return nil, fmt.Errorf("snapshot has %d stored messages, expected %d", len(sms), length)
+	}
+	out := make([]ParsedMessage, length)
+	for j, sm := range sms {
+		if sm == nil {
+			continue
+		}
+		wire := new(MessageWrapper)
+		if err := proto.Unmarshal(sm.Wire, wire); err != nil {
+			return nil, err
+		}
+		if wire.GetFrom() == nil {
+			return nil, errors.New("snapshot has a stored message without a sender")
+		}
+		from := &PartyID{MessageWrapper_PartyID: wire.GetFrom(), Index: sm.FromIndex}
+		msg, err := parseWrappedMessage(wire, from)
+		if err != nil {
+			return nil, err
+		}
+		out[j] = msg
+	}
+	return out, nil
+}
+
+// ----- //
+
+func newSnapshotAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != SnapshotKeyLength {
+		return nil, fmt.Errorf("snapshot key must be %d bytes long", SnapshotKeyLength)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// snapshot layout: version (1 byte) || nonce || AES-256-GCM ciphertext; the version byte is authenticated
+func sealSnapshot(key, plaintext []byte) ([]byte, error) {
+	aead, err := newSnapshotAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := common.GetRandomBytes(aead.NonceSize())
+	if err != nil {
+		return nil, err
+	}
+	header := append([]byte{SnapshotVersion}, nonce...)
+	return aead.Seal(header, nonce, plaintext, header[:1]), nil
+}
+
+func openSnapshot(key, snapshot []byte) ([]byte, error) {
+	aead, err := newSnapshotAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(snapshot) < 1+aead.NonceSize()+aead.Overhead() {
+		return nil, errors.New("snapshot is too short")
+	}
+	if snapshot[0] != SnapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d", snapshot[0])
+	}
+	nonce := snapshot[1 : 1+aead.NonceSize()]
+	plaintext, err := aead.Open(nil, nonce, snapshot[1+aead.NonceSize():], snapshot[:1])
+	if err != nil {
+		return nil, errors.New("could not decrypt snapshot; the key may be wrong or the snapshot corrupted")
+	}
+	return plaintext, nil
+}
@@ -0,0 +1,27 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package tss
+
+// StepBufferSize returns the capacity of the internal `out` channel of a step party.
+// It must hold every message a party may send during a single call; a round sends at most one broadcast and one P2P
+// message to each peer, and a single Update may cause a party to go through all of its rounds.
+func StepBufferSize(rounds, partyCount int) int {
+	return rounds * (partyCount + 1)
+}
+
+// DrainMessages returns the messages buffered in `out` without blocking.
+func DrainMessages(out <-chan Message) []Message {
+	msgs := make([]Message, 0, len(out))
+	for {
+		select {
+		case msg := <-out:
+			msgs = append(msgs, msg)
+		default:
+			return msgs
+		}
+	}
+}